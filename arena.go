@@ -0,0 +1,158 @@
+package lattice
+
+import "github.com/maladroitthief/mosaic"
+
+// Arena hands out query results and search scratch from buffers that are
+// reused every frame. Slices returned by an arena stay valid until Reset, so
+// a steady frame loop stops allocating once the buffers have grown to fit.
+type Arena[T comparable] struct {
+	grid     *SpatialGrid[T]
+	searcher *Searcher[T]
+	seen     map[T]struct{}
+	mask     []uint8
+	values   []T
+	points   []mosaic.Vector
+	costs    []float64
+	groups   []CellItems[T]
+}
+
+// CellItems is one cell's share of a grouped query
+type CellItems[T comparable] struct {
+	Cell   Cell
+	Values []T
+}
+
+func (sg *SpatialGrid[T]) NewArena() *Arena[T] {
+	return &Arena[T]{
+		grid:     sg,
+		searcher: sg.NewSearcher(),
+		seen:     map[T]struct{}{},
+	}
+}
+
+// Reset releases everything handed out since the last Reset
+func (a *Arena[T]) Reset() {
+	a.values = a.values[:0]
+	a.points = a.points[:0]
+	a.costs = a.costs[:0]
+	a.groups = a.groups[:0]
+}
+
+// FindNear matches SpatialGrid.FindNear, with values in cell scan order
+func (a *Arena[T]) FindNear(bounds mosaic.Rectangle) []T {
+	sg := a.grid.rlock()
+	defer sg.runlock()
+
+	return a.find(sg, bounds, sg.config.precise)
+}
+
+// FindIntersecting matches SpatialGrid.FindIntersecting, with values in cell
+// scan order
+func (a *Arena[T]) FindIntersecting(bounds mosaic.Rectangle) []T {
+	sg := a.grid.rlock()
+	defer sg.runlock()
+
+	return a.find(sg, bounds, true)
+}
+
+func (a *Arena[T]) find(sg *SpatialGrid[T], bounds mosaic.Rectangle, precise bool) []T {
+	start := len(a.values)
+	xMinIndex, yMinIndex, xMaxIndex, yMaxIndex, ok := sg.cellRange(bounds)
+	if !ok {
+		return a.values[start:start:start]
+	}
+
+	clear(a.seen)
+	for x := xMinIndex; x <= xMaxIndex; x++ {
+		for y := yMinIndex; y <= yMaxIndex; y++ {
+			node := sg.Nodes[x][y]
+			if precise {
+				a.mask = node.packed.intersect(bounds, a.mask)
+			}
+			for i, item := range node.Items {
+				if precise && a.mask[i] == 0 {
+					continue
+				}
+				_, ok := a.seen[item.value]
+				if ok {
+					continue
+				}
+				a.seen[item.value] = struct{}{}
+				a.values = append(a.values, item.value)
+			}
+		}
+	}
+
+	// capped so appending to one result never writes over the next
+	end := len(a.values)
+	return a.values[start:end:end]
+}
+
+// FindPath matches SpatialGrid.FindPath, with the waypoints and costs owned
+// by the arena
+func (a *Arena[T]) FindPath(start, end mosaic.Vector, opts PathOptions) (Path, error) {
+	path, err := a.searcher.FindPath(start, end, opts)
+
+	first := len(a.points)
+	a.points = append(a.points, path.Waypoints...)
+	last := len(a.points)
+	path.Waypoints = a.points[first:last:last]
+
+	first = len(a.costs)
+	a.costs = append(a.costs, path.Costs...)
+	last = len(a.costs)
+	path.Costs = a.costs[first:last:last]
+
+	return path, err
+}
+
+// VisibleItems returns the values whose bounds intersect camera grown by
+// margin on every side, for culling before a draw
+func (a *Arena[T]) VisibleItems(camera mosaic.Rectangle, margin float64) []T {
+	sg := a.grid.rlock()
+	defer sg.runlock()
+
+	return a.find(sg, inflate(camera, margin), true)
+}
+
+// VisibleCells is VisibleItems grouped by the cell holding each value, in
+// cell scan order, so draws can be batched per cell
+func (a *Arena[T]) VisibleCells(camera mosaic.Rectangle, margin float64) []CellItems[T] {
+	sg := a.grid.rlock()
+	defer sg.runlock()
+
+	bounds := inflate(camera, margin)
+	first := len(a.groups)
+	xMinIndex, yMinIndex, xMaxIndex, yMaxIndex, ok := sg.cellRange(bounds)
+	if !ok {
+		return a.groups[first:first:first]
+	}
+
+	for x := xMinIndex; x <= xMaxIndex; x++ {
+		for y := yMinIndex; y <= yMaxIndex; y++ {
+			node := sg.Nodes[x][y]
+			if len(node.Items) == 0 {
+				continue
+			}
+
+			start := len(a.values)
+			a.mask = node.packed.intersect(bounds, a.mask)
+			for i, item := range node.Items {
+				if a.mask[i] != 0 {
+					a.values = append(a.values, item.value)
+				}
+			}
+			end := len(a.values)
+			if end > start {
+				a.groups = append(a.groups, CellItems[T]{Cell: Cell{x, y}, Values: a.values[start:end:end]})
+			}
+		}
+	}
+
+	last := len(a.groups)
+	return a.groups[first:last:last]
+}
+
+func inflate(bounds mosaic.Rectangle, margin float64) mosaic.Rectangle {
+	return mosaic.NewRectangle(bounds.Position, bounds.Width+2*margin, bounds.Height+2*margin)
+}
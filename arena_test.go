@@ -0,0 +1,202 @@
+package lattice_test
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/mosaic"
+)
+
+func Test_arena_FindNear(t *testing.T) {
+	type params struct {
+		opts []lattice.Option
+	}
+	tests := []struct {
+		name   string
+		params params
+	}{
+		{name: "default"},
+		{name: "precise", params: params{opts: []lattice.Option{lattice.WithPreciseQueries()}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg, err := lattice.NewSpatialGridOpts[int](16, 16, 16, tt.params.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i := 0; i < 200; i++ {
+				sg.Insert(lattice.Item[int]{
+					i,
+					mosaic.NewRectangle(
+						mosaic.NewVector(rand.Float64()*256, rand.Float64()*256),
+						1+rand.Float64()*24,
+						1+rand.Float64()*24,
+					),
+					1,
+				})
+			}
+
+			arena := sg.NewArena()
+			for frame := 0; frame < 4; frame++ {
+				arena.Reset()
+				results := [][]int{}
+				queries := []mosaic.Rectangle{}
+				for i := 0; i < 16; i++ {
+					bounds := mosaic.NewRectangle(
+						mosaic.NewVector(rand.Float64()*256, rand.Float64()*256),
+						rand.Float64()*64,
+						rand.Float64()*64,
+					)
+					queries = append(queries, bounds)
+					results = append(results, arena.FindNear(bounds))
+				}
+
+				// earlier results must survive later queries in the same frame
+				for i, bounds := range queries {
+					want := sg.FindNear(bounds)
+					got := slices.Clone(results[i])
+					slices.Sort(want)
+					slices.Sort(got)
+					if !slices.Equal(want, got) {
+						t.Error(fmt.Errorf("arena.FindNear() want: %+v, got: %+v\n", want, got))
+					}
+				}
+			}
+
+			bounds := mosaic.NewRectangle(mosaic.NewVector(128, 128), 64, 64)
+			allocs := testing.AllocsPerRun(16, func() {
+				arena.Reset()
+				for i := 0; i < 16; i++ {
+					arena.FindNear(bounds)
+				}
+			})
+			if allocs != 0 {
+				t.Error(fmt.Errorf("arena.FindNear() want: 0 allocations, got: %+v\n", allocs))
+			}
+		})
+	}
+}
+
+func Test_arena_FindPath(t *testing.T) {
+	b := Builder{
+		x:    9,
+		y:    9,
+		size: 32,
+		layout: "" +
+			"000000000" +
+			"0xxxxxxx0" +
+			"0x00000x0" +
+			"0x0x0x0x0" +
+			"0x0x0x0x0" +
+			"0x0xxx0x0" +
+			"0x00000x0" +
+			"0x0xxx0x0" +
+			"000000000",
+	}
+	sg := lattice.NewSpatialGrid[int](b.x, b.y, float64(b.size))
+	setup_grid(sg, b)
+	arena := sg.NewArena()
+
+	starts := []mosaic.Vector{mosaic.NewVector(16, 16), mosaic.NewVector(272, 16)}
+	end := mosaic.NewVector(144, 144)
+	paths := []lattice.Path{}
+	for _, start := range starts {
+		path, err := arena.FindPath(start, end, lattice.PathOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		paths = append(paths, path)
+	}
+	for i, start := range starts {
+		want, _ := sg.FindPath(start, end, lattice.PathOptions{})
+		if !slices.Equal(want.Waypoints, paths[i].Waypoints) || want.Cost != paths[i].Cost {
+			t.Error(fmt.Errorf("arena.FindPath() want: %+v, got: %+v\n", want, paths[i]))
+		}
+	}
+
+	allocs := testing.AllocsPerRun(16, func() {
+		arena.Reset()
+		for _, start := range starts {
+			arena.FindPath(start, end, lattice.PathOptions{})
+		}
+	})
+	if allocs != 0 {
+		t.Error(fmt.Errorf("arena.FindPath() want: 0 allocations, got: %+v\n", allocs))
+	}
+}
+
+func Test_arena_VisibleCells(t *testing.T) {
+	sg := lattice.NewSpatialGrid[int](4, 4, 16)
+	square := func(x, y float64) mosaic.Rectangle {
+		return mosaic.NewRectangle(mosaic.NewVector(x, y), 4, 4)
+	}
+	sg.Insert(lattice.Item[int]{1, square(4, 4), 1})
+	sg.Insert(lattice.Item[int]{2, square(12, 12), 1})
+	sg.Insert(lattice.Item[int]{3, square(20, 4), 1})
+	sg.Insert(lattice.Item[int]{4, square(56, 56), 1})
+	camera := mosaic.NewRectangle(mosaic.NewVector(8, 8), 16, 16)
+
+	type want struct {
+		values []int
+		groups []lattice.CellItems[int]
+	}
+	tests := []struct {
+		name   string
+		margin float64
+		want   want
+	}{
+		{
+			name:   "inside the camera",
+			margin: 0,
+			want: want{
+				values: []int{1, 2},
+				groups: []lattice.CellItems[int]{{Cell: lattice.Cell{X: 0, Y: 0}, Values: []int{1, 2}}},
+			},
+		},
+		{
+			name:   "margin pulls in neighbors",
+			margin: 8,
+			want: want{
+				values: []int{1, 2, 3},
+				groups: []lattice.CellItems[int]{
+					{Cell: lattice.Cell{X: 0, Y: 0}, Values: []int{1, 2}},
+					{Cell: lattice.Cell{X: 1, Y: 0}, Values: []int{3}},
+				},
+			},
+		},
+	}
+	arena := sg.NewArena()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			arena.Reset()
+
+			values := arena.VisibleItems(camera, tt.margin)
+			slices.Sort(values)
+			if !slices.Equal(tt.want.values, values) {
+				t.Error(fmt.Errorf("arena.VisibleItems() want: %+v, got: %+v\n", tt.want.values, values))
+			}
+
+			groups := arena.VisibleCells(camera, tt.margin)
+			for _, group := range groups {
+				slices.Sort(group.Values)
+			}
+			if !slices.EqualFunc(tt.want.groups, groups, func(a, b lattice.CellItems[int]) bool {
+				return a.Cell == b.Cell && slices.Equal(a.Values, b.Values)
+			}) {
+				t.Error(fmt.Errorf("arena.VisibleCells() want: %+v, got: %+v\n", tt.want.groups, groups))
+			}
+		})
+	}
+
+	allocs := testing.AllocsPerRun(16, func() {
+		arena.Reset()
+		arena.VisibleItems(camera, 8)
+		arena.VisibleCells(camera, 8)
+	})
+	if allocs != 0 {
+		t.Error(fmt.Errorf("arena.VisibleCells() want: 0 allocations, got: %+v\n", allocs))
+	}
+}
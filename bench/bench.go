@@ -0,0 +1,236 @@
+// Package bench runs seeded workloads against a SpatialGrid so performance
+// can be compared across versions and configurations. Every scenario is
+// deterministic for a given seed, from go test benchmarks or user code.
+package bench
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/mosaic"
+)
+
+type (
+	// Scenario describes a workload: a grid of Width by Height cells, Agents
+	// items of AgentSize placed by the layout, and every tick each agent
+	// moving by up to Speed and querying QueryRadius around itself.
+	Scenario struct {
+		Name        string
+		Width       int
+		Height      int
+		ChunkSize   float64
+		Agents      int
+		AgentSize   float64
+		Speed       float64
+		QueryRadius float64
+		Seed        uint64
+		Options     []lattice.Option
+		place       func(r *rand.Rand, s Scenario) mosaic.Vector
+	}
+
+	// Workload is a populated scenario, stepped one tick at a time
+	Workload struct {
+		Grid     *lattice.SpatialGrid[int]
+		scenario Scenario
+		agents   []agent
+		r        *rand.Rand
+	}
+
+	// Result counts the work done by Run. Found sums every query's result
+	// length, a cheap check that two runs did the same work.
+	Result struct {
+		Ticks   int
+		Moves   int
+		Queries int
+		Found   int
+		Elapsed time.Duration
+	}
+
+	agent struct {
+		bounds   mosaic.Rectangle
+		velocity mosaic.Vector
+	}
+)
+
+func newRand(seed uint64) *rand.Rand {
+	return rand.New(rand.NewPCG(seed, seed^0x9e3779b97f4a7c15))
+}
+
+// Uniform scatters stationary agents evenly over a 64 by 64 grid
+func Uniform(agents int, seed uint64) Scenario {
+	return Scenario{
+		Name:        "uniform",
+		Width:       64,
+		Height:      64,
+		ChunkSize:   16,
+		Agents:      agents,
+		AgentSize:   4,
+		QueryRadius: 16,
+		Seed:        seed,
+		place: func(r *rand.Rand, s Scenario) mosaic.Vector {
+			return mosaic.NewVector(r.Float64()*s.worldWidth(), r.Float64()*s.worldHeight())
+		},
+	}
+}
+
+// Clustered packs stationary agents into a few normally spread groups, the
+// worst case for per-cell item counts
+func Clustered(agents, clusters int, seed uint64) Scenario {
+	s := Uniform(agents, seed)
+	s.Name = "clustered"
+
+	centers := make([]mosaic.Vector, max(clusters, 1))
+	r := newRand(seed ^ 0xc1)
+	for i := range centers {
+		centers[i] = mosaic.NewVector(r.Float64()*s.worldWidth(), r.Float64()*s.worldHeight())
+	}
+	s.place = func(r *rand.Rand, s Scenario) mosaic.Vector {
+		center := centers[r.IntN(len(centers))]
+		spread := 2 * s.ChunkSize
+		return s.clamp(mosaic.NewVector(center.X+r.NormFloat64()*spread, center.Y+r.NormFloat64()*spread))
+	}
+
+	return s
+}
+
+// MovingCrowd is Uniform with every agent walking in a straight line and
+// bouncing off the edges, so each tick is mostly updates
+func MovingCrowd(agents int, seed uint64) Scenario {
+	s := Uniform(agents, seed)
+	s.Name = "moving-crowd"
+	s.Speed = 4
+
+	return s
+}
+
+func (s Scenario) worldWidth() float64 {
+	return float64(s.Width) * s.ChunkSize
+}
+
+func (s Scenario) worldHeight() float64 {
+	return float64(s.Height) * s.ChunkSize
+}
+
+func (s Scenario) clamp(v mosaic.Vector) mosaic.Vector {
+	return mosaic.NewVector(
+		math.Min(math.Max(v.X, 0), math.Nextafter(s.worldWidth(), 0)),
+		math.Min(math.Max(v.Y, 0), math.Nextafter(s.worldHeight(), 0)),
+	)
+}
+
+// New builds the grid and inserts every agent
+func (s Scenario) New() (*Workload, error) {
+	sg, err := lattice.NewSpatialGridOpts[int](s.Width, s.Height, s.ChunkSize, s.Options...)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &Workload{Grid: sg, scenario: s, agents: make([]agent, s.Agents), r: newRand(s.Seed)}
+	for i := range w.agents {
+		position := mosaic.NewVector(s.worldWidth()/2, s.worldHeight()/2)
+		if s.place != nil {
+			position = s.place(w.r, s)
+		}
+		heading := w.r.Float64() * 2 * math.Pi
+		w.agents[i] = agent{
+			bounds:   mosaic.NewRectangle(position, s.AgentSize, s.AgentSize),
+			velocity: mosaic.NewVector(math.Cos(heading)*s.Speed, math.Sin(heading)*s.Speed),
+		}
+
+		err = sg.Insert(lattice.Item[int]{Value: i, Bounds: w.agents[i].bounds, Multiplier: 1})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return w, nil
+}
+
+// Step advances every agent one tick and runs its query, adding the work to
+// result
+func (w *Workload) Step(result *Result) error {
+	s := w.scenario
+	for i := range w.agents {
+		a := &w.agents[i]
+		if s.Speed > 0 {
+			old := a.bounds
+			a.bounds.Position, a.velocity = w.bounce(a.bounds.Position, a.velocity)
+
+			err := w.Grid.Update(lattice.Item[int]{Value: i, Bounds: a.bounds, Multiplier: 1}, old)
+			if err != nil {
+				return err
+			}
+			result.Moves++
+		}
+
+		if s.QueryRadius > 0 {
+			found := w.Grid.FindNear(mosaic.NewRectangle(a.bounds.Position, 2*s.QueryRadius, 2*s.QueryRadius))
+			result.Queries++
+			result.Found += len(found)
+		}
+	}
+	result.Ticks++
+
+	return nil
+}
+
+// bounce moves position by velocity, reflecting off the world edges
+func (w *Workload) bounce(position, velocity mosaic.Vector) (mosaic.Vector, mosaic.Vector) {
+	next := mosaic.NewVector(position.X+velocity.X, position.Y+velocity.Y)
+	if next.X < 0 || next.X >= w.scenario.worldWidth() {
+		velocity.X = -velocity.X
+	}
+	if next.Y < 0 || next.Y >= w.scenario.worldHeight() {
+		velocity.Y = -velocity.Y
+	}
+
+	return w.scenario.clamp(next), velocity
+}
+
+// Run builds the scenario and steps it ticks times, timing only the steps
+func (s Scenario) Run(ticks int) (Result, error) {
+	w, err := s.New()
+	if err != nil {
+		return Result{}, err
+	}
+
+	result := Result{}
+	start := time.Now()
+	for i := 0; i < ticks; i++ {
+		err = w.Step(&result)
+		if err != nil {
+			return result, err
+		}
+	}
+	result.Elapsed = time.Since(start)
+
+	return result, nil
+}
+
+// Benchmark runs one tick per b.N iteration, for use from a go test
+// benchmark:
+//
+//	func BenchmarkCrowd(b *testing.B) { bench.MovingCrowd(4096, 1).Benchmark(b) }
+func (s Scenario) Benchmark(b *testing.B) {
+	w, err := s.New()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	result := Result{}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		err = w.Step(&result)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.StopTimer()
+	if result.Queries > 0 {
+		b.ReportMetric(float64(result.Found)/float64(result.Queries), "found/query")
+	}
+}
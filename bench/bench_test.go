@@ -0,0 +1,81 @@
+package bench_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/lattice/bench"
+)
+
+func Test_Scenario_Run(t *testing.T) {
+	tests := []struct {
+		name     string
+		scenario bench.Scenario
+		moves    int
+	}{
+		{name: "uniform", scenario: bench.Uniform(256, 1), moves: 0},
+		{name: "clustered", scenario: bench.Clustered(256, 4, 1), moves: 0},
+		{name: "moving crowd", scenario: bench.MovingCrowd(256, 1), moves: 256 * 8},
+		{
+			name: "with options",
+			scenario: func() bench.Scenario {
+				s := bench.MovingCrowd(256, 1)
+				s.Options = []lattice.Option{lattice.WithPreciseQueries()}
+				return s
+			}(),
+			moves: 256 * 8,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			first, err := tt.scenario.Run(8)
+			if err != nil {
+				t.Fatal(err)
+			}
+			second, err := tt.scenario.Run(8)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			first.Elapsed, second.Elapsed = 0, 0
+			if first != second {
+				t.Error(fmt.Errorf("Scenario.Run() want: %+v, got: %+v\n", first, second))
+			}
+			if first.Ticks != 8 || first.Queries != 256*8 || first.Moves != tt.moves || first.Found < first.Queries {
+				t.Error(fmt.Errorf("Scenario.Run() want: %+v, got: %+v\n", tt.moves, first))
+			}
+		})
+	}
+}
+
+func Test_Scenario_New(t *testing.T) {
+	for _, s := range []bench.Scenario{bench.Uniform(500, 7), bench.Clustered(500, 3, 7), bench.MovingCrowd(500, 7)} {
+		w, err := s.New()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := w.Grid.Size(); got != 500 {
+			t.Error(fmt.Errorf("%s Scenario.New() want: %+v, got: %+v\n", s.Name, 500, got))
+		}
+	}
+
+	// clustering piles agents into fewer cells than an even spread
+	uniform, _ := bench.Uniform(500, 7).New()
+	clustered, _ := bench.Clustered(500, 3, 7).New()
+	if u, c := uniform.Grid.Occupancy(), clustered.Grid.Occupancy(); c <= u {
+		t.Error(fmt.Errorf("Scenario.New() want: clustered occupancy above %+v, got: %+v\n", u, c))
+	}
+}
+
+func BenchmarkUniform(b *testing.B) {
+	bench.Uniform(4096, 1).Benchmark(b)
+}
+
+func BenchmarkClustered(b *testing.B) {
+	bench.Clustered(4096, 8, 1).Benchmark(b)
+}
+
+func BenchmarkMovingCrowd(b *testing.B) {
+	bench.MovingCrowd(4096, 1).Benchmark(b)
+}
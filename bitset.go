@@ -0,0 +1,23 @@
+package lattice
+
+type bitset []uint64
+
+func newBitset(n int) bitset {
+	return make(bitset, (n+63)/64)
+}
+
+func (b bitset) clone() bitset {
+	return append(bitset(nil), b...)
+}
+
+func (b bitset) get(i int) bool {
+	return b[i>>6]&(1<<(uint(i)&63)) != 0
+}
+
+func (b bitset) set(i int, value bool) {
+	if value {
+		b[i>>6] |= 1 << (uint(i) & 63)
+		return
+	}
+	b[i>>6] &^= 1 << (uint(i) & 63)
+}
@@ -0,0 +1,42 @@
+package lattice
+
+func (sg *SpatialGrid[T]) Blocked(x, y int) bool {
+	sg = sg.rlock()
+	defer sg.runlock()
+
+	return sg.blocked.get(sg.index(x, y))
+}
+
+func (sg *SpatialGrid[T]) BlockedThreshold() float64 {
+	sg = sg.rlock()
+	defer sg.runlock()
+
+	return sg.blockedAt
+}
+
+func (sg *SpatialGrid[T]) SetBlockedThreshold(threshold float64) {
+	sg.lock()
+	defer sg.unlock()
+
+	sg.blockedAt = threshold
+	for x := range sg.Nodes {
+		for y := range sg.Nodes[x] {
+			sg.updateBlocked(x, y)
+		}
+	}
+}
+
+func (sg *SpatialGrid[T]) inBounds(x, y int) bool {
+	return x >= 0 && x < sg.SizeX && y >= 0 && y < sg.SizeY
+}
+
+func (sg *SpatialGrid[T]) index(x, y int) int {
+	return y*sg.SizeX + x
+}
+
+// updateBlocked follows every change to a cell weight, so it also marks the
+// tables precomputed from weights as stale
+func (sg *SpatialGrid[T]) updateBlocked(x, y int) {
+	sg.shape++
+	sg.blocked.set(sg.index(x, y), sg.Nodes[x][y].weight >= sg.blockedAt)
+}
@@ -0,0 +1,93 @@
+package lattice_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/mosaic"
+)
+
+func Test_spatial_grid_Blocked(t *testing.T) {
+	type setup struct {
+		builder   Builder
+		threshold float64
+	}
+	type want struct {
+		x       int
+		y       int
+		blocked bool
+	}
+	tests := []struct {
+		name  string
+		setup setup
+		wants []want
+	}{
+		{
+			name: "infinite weights",
+			setup: setup{
+				builder: Builder{
+					x:    3,
+					y:    3,
+					size: 32,
+					layout: "" +
+						"x10" +
+						"000" +
+						"00x",
+				},
+				threshold: math.Inf(1),
+			},
+			wants: []want{
+				{x: 0, y: 0, blocked: true},
+				{x: 1, y: 0, blocked: false},
+				{x: 2, y: 2, blocked: true},
+				{x: 1, y: 1, blocked: false},
+			},
+		},
+		{
+			name: "finite threshold",
+			setup: setup{
+				builder: Builder{
+					x:    3,
+					y:    3,
+					size: 32,
+					layout: "" +
+						"x10" +
+						"000" +
+						"00x",
+				},
+				threshold: 1024,
+			},
+			wants: []want{
+				{x: 0, y: 0, blocked: true},
+				{x: 1, y: 0, blocked: true},
+				{x: 2, y: 0, blocked: false},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg := lattice.NewSpatialGrid[int](
+				tt.setup.builder.x,
+				tt.setup.builder.y,
+				float64(tt.setup.builder.size),
+			)
+			sg.SetBlockedThreshold(tt.setup.threshold)
+			setup_grid(sg, tt.setup.builder)
+
+			for _, want := range tt.wants {
+				got := sg.Blocked(want.x, want.y)
+				if got != want.blocked {
+					t.Error(fmt.Errorf("spatialGrid.Blocked(%d, %d) want: %+v, got: %+v\n", want.x, want.y, want.blocked, got))
+				}
+			}
+
+			sg.Delete(9, mosaic.NewRectangle(mosaic.NewVector(16, 16), 32, 32))
+			got := sg.Blocked(0, 0)
+			if got {
+				t.Error(fmt.Errorf("spatialGrid.Blocked(0, 0) [after delete] want: false, got: %+v\n", got))
+			}
+		})
+	}
+}
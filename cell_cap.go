@@ -0,0 +1,154 @@
+package lattice
+
+import (
+	"errors"
+	"math"
+)
+
+type (
+	Overflow int
+)
+
+const (
+	// OverflowReject fails the insert with ErrCellFull
+	OverflowReject Overflow = iota
+	// OverflowEvict drops the dynamic item with the lowest multiplier to make
+	// room, or rejects the insert when that would be the new item itself
+	OverflowEvict
+	// OverflowSpill parks the item in the grid's overflow list, where it has
+	// no weight and is not found by spatial queries until it is deleted
+	OverflowSpill
+)
+
+const (
+	partitionDynamic partition = iota
+	partitionStatic
+	partitionPinned
+)
+
+var (
+	ErrCellFull = errors.New("cell has reached its item cap")
+)
+
+// Overflow returns the values currently spilled out of full cells
+func (sg *SpatialGrid[T]) Overflow() []T {
+	sg = sg.rlock()
+	defer sg.runlock()
+
+	values := make([]T, len(sg.overflow))
+	for i, item := range sg.overflow {
+		values[i] = item.Value
+	}
+
+	return values
+}
+
+// admit applies the cell cap before item is stored at x, y. It reports false
+// when the item was handled without being stored in the node.
+func (sg *SpatialGrid[T]) admit(x, y int, item Item[T], part partition) (bool, error) {
+	if sg.config.cellCap <= 0 || len(sg.Nodes[x][y].Items) < sg.config.cellCap {
+		return true, nil
+	}
+
+	switch sg.config.overflow {
+	case OverflowEvict:
+		node := sg.Nodes[x][y]
+		lowest, multiplier := -1, item.Multiplier
+		for i := node.static; i < len(node.Items); i++ {
+			if node.Items[i].multiplier < multiplier {
+				lowest, multiplier = i, node.Items[i].multiplier
+			}
+		}
+		if lowest < 0 {
+			return false, ErrCellFull
+		}
+
+		removed := node.Items[lowest].weight
+		delete(sg.expiries, node.Items[lowest].value)
+		sg.track(node.Items[lowest].value, node.Items[lowest].bounds, false)
+		node = sg.record(node, CellDeleted, node.Items[lowest].value, node.Items[lowest].bounds)
+		node.weight -= removed
+		node = node.removeAt(lowest)
+		if math.IsInf(removed, 0) {
+			node.weight = node.itemWeights()
+		}
+		sg.Nodes[x][y] = node
+		sg.itemCount--
+
+		return true, nil
+	case OverflowSpill:
+		sg.overflow = append(sg.overflow, spilled[T]{Item: item, partition: part})
+		sg.itemCount++
+
+		return false, nil
+	default:
+		return false, ErrCellFull
+	}
+}
+
+// wouldAdmit reports whether admit would store or spill item at x, y once
+// any copy of it already there has been deleted, without changing anything
+func (sg *SpatialGrid[T]) wouldAdmit(x, y int, item Item[T]) bool {
+	node := sg.Nodes[x][y]
+	if sg.config.cellCap <= 0 || len(node.Items) < sg.config.cellCap {
+		return true
+	}
+	for i := range node.Items {
+		if node.Items[i].value == item.Value {
+			return true
+		}
+	}
+
+	switch sg.config.overflow {
+	case OverflowEvict:
+		for i := node.static; i < len(node.Items); i++ {
+			if node.Items[i].multiplier < item.Multiplier {
+				return true
+			}
+		}
+		return false
+	case OverflowSpill:
+		return true
+	default:
+		return false
+	}
+}
+
+// copies counts how many times val is stored, spilled items included
+func (sg *SpatialGrid[T]) copies(val T) int {
+	sg = sg.rlock()
+	defer sg.runlock()
+
+	count := 0
+	for x := range sg.Nodes {
+		for y := range sg.Nodes[x] {
+			for _, item := range sg.Nodes[x][y].Items {
+				if item.value == val {
+					count++
+				}
+			}
+		}
+	}
+	for _, item := range sg.overflow {
+		if item.Value == val {
+			count++
+		}
+	}
+
+	return count
+}
+
+// unspill removes val from the overflow list, reporting whether it was there
+func (sg *SpatialGrid[T]) unspill(val T) bool {
+	for i, item := range sg.overflow {
+		if item.Value != val {
+			continue
+		}
+
+		sg.overflow = append(sg.overflow[:i], sg.overflow[i+1:]...)
+		sg.itemCount--
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,167 @@
+package lattice_test
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/mosaic"
+)
+
+func Test_spatial_grid_WithCellCap(t *testing.T) {
+	type want struct {
+		err      error
+		near     []int
+		overflow []int
+		size     int
+	}
+	tests := []struct {
+		name       string
+		overflow   lattice.Overflow
+		multiplier float64
+		want       want
+	}{
+		{
+			name:       "reject",
+			overflow:   lattice.OverflowReject,
+			multiplier: 5,
+			want:       want{err: lattice.ErrCellFull, near: []int{1, 2}, overflow: []int{}, size: 2},
+		},
+		{
+			name:       "evict lowest multiplier",
+			overflow:   lattice.OverflowEvict,
+			multiplier: 5,
+			want:       want{near: []int{2, 3}, overflow: []int{}, size: 2},
+		},
+		{
+			name:       "evict keeps heavier items",
+			overflow:   lattice.OverflowEvict,
+			multiplier: 0.5,
+			want:       want{err: lattice.ErrCellFull, near: []int{1, 2}, overflow: []int{}, size: 2},
+		},
+		{
+			name:       "spill",
+			overflow:   lattice.OverflowSpill,
+			multiplier: 5,
+			want:       want{near: []int{1, 2}, overflow: []int{3}, size: 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg, err := lattice.NewSpatialGridOpts[int](4, 4, 8, lattice.WithCellCap(2, tt.overflow))
+			if err != nil {
+				t.Fatal(err)
+			}
+			bounds := mosaic.NewRectangle(mosaic.NewVector(4, 4), 2, 2)
+			sg.Insert(lattice.Item[int]{1, bounds, 1})
+			sg.Insert(lattice.Item[int]{2, bounds, 2})
+
+			err = sg.Insert(lattice.Item[int]{3, bounds, tt.multiplier})
+			if !errors.Is(err, tt.want.err) {
+				t.Error(fmt.Errorf("spatialGrid.Insert() want error: %+v, got error: %+v\n", tt.want.err, err))
+			}
+
+			got := sg.FindNear(bounds)
+			slices.Sort(got)
+			if !slices.Equal(got, tt.want.near) {
+				t.Error(fmt.Errorf("spatialGrid.FindNear() want: %+v, got: %+v\n", tt.want.near, got))
+			}
+			if !slices.Equal(sg.Overflow(), tt.want.overflow) {
+				t.Error(fmt.Errorf("spatialGrid.Overflow() want: %+v, got: %+v\n", tt.want.overflow, sg.Overflow()))
+			}
+			if sg.Size() != tt.want.size {
+				t.Error(fmt.Errorf("spatialGrid.Size() want: %+v, got: %+v\n", tt.want.size, sg.Size()))
+			}
+
+			sg.Delete(3, bounds)
+			if len(sg.Overflow()) != 0 {
+				t.Error(fmt.Errorf("spatialGrid.Delete() want overflow: %+v, got: %+v\n", []int{}, sg.Overflow()))
+			}
+		})
+	}
+}
+
+func Test_spatial_grid_WithCellCap_evicted(t *testing.T) {
+	sg, err := lattice.NewSpatialGridOpts[int](4, 4, 8, lattice.WithCellCap(1, lattice.OverflowEvict))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cell := mosaic.NewRectangle(mosaic.NewVector(4, 4), 2, 2)
+	elsewhere := mosaic.NewRectangle(mosaic.NewVector(20, 20), 2, 2)
+
+	now := time.Unix(0, 0)
+	sg.InsertWithTTL(lattice.Item[int]{1, cell, 1}, now, time.Second)
+	sg.Insert(lattice.Item[int]{2, cell, 5})
+
+	err = sg.Delete(1, cell)
+	if !errors.Is(err, lattice.ErrItemNotFound) {
+		t.Error(fmt.Errorf("spatialGrid.Delete() want error: %+v, got error: %+v\n", lattice.ErrItemNotFound, err))
+	}
+	if got := sg.Size(); got != 1 {
+		t.Error(fmt.Errorf("spatialGrid.Size() want: %+v, got: %+v\n", 1, got))
+	}
+
+	// the evicted item's deadline must not expire the value once it is back
+	sg.Insert(lattice.Item[int]{1, elsewhere, 1})
+	if got := sg.Expire(now.Add(time.Hour)); len(got) != 0 {
+		t.Error(fmt.Errorf("spatialGrid.Expire() want: %+v, got: %+v\n", []int{}, got))
+	}
+	if got := sg.FindNear(elsewhere); !slices.Equal(got, []int{1}) {
+		t.Error(fmt.Errorf("spatialGrid.FindNear() want: %+v, got: %+v\n", []int{1}, got))
+	}
+	if got := sg.Size(); got != 2 {
+		t.Error(fmt.Errorf("spatialGrid.Size() want: %+v, got: %+v\n", 2, got))
+	}
+}
+
+func Test_spatial_grid_WithCellCap_DropDynamic(t *testing.T) {
+	sg, err := lattice.NewSpatialGridOpts[int](4, 4, 8, lattice.WithCellCap(1, lattice.OverflowSpill))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cell := mosaic.NewRectangle(mosaic.NewVector(4, 4), 2, 2)
+
+	sg.InsertStatic(lattice.Item[int]{1, cell, 1})
+	sg.InsertStatic(lattice.Item[int]{2, cell, 1})
+	sg.Insert(lattice.Item[int]{3, cell, 1})
+	sg.DropDynamic()
+
+	if got := sg.Overflow(); !slices.Equal(got, []int{2}) {
+		t.Error(fmt.Errorf("spatialGrid.Overflow() want: %+v, got: %+v\n", []int{2}, got))
+	}
+	if got := sg.Size(); got != 2 {
+		t.Error(fmt.Errorf("spatialGrid.Size() want: %+v, got: %+v\n", 2, got))
+	}
+}
+
+func Test_spatial_grid_WithCellCap_Update(t *testing.T) {
+	sg, err := lattice.NewSpatialGridOpts[int](4, 4, 8, lattice.WithCellCap(1, lattice.OverflowReject))
+	if err != nil {
+		t.Fatal(err)
+	}
+	home := mosaic.NewRectangle(mosaic.NewVector(4, 4), 2, 2)
+	full := mosaic.NewRectangle(mosaic.NewVector(20, 20), 2, 2)
+	sg.Insert(lattice.Item[int]{1, home, 1})
+	sg.Insert(lattice.Item[int]{2, full, 1})
+
+	err = sg.Update(lattice.Item[int]{1, full, 1}, home)
+	if !errors.Is(err, lattice.ErrCellFull) {
+		t.Error(fmt.Errorf("spatialGrid.Update() want error: %+v, got error: %+v\n", lattice.ErrCellFull, err))
+	}
+	if got := sg.FindNear(home); !slices.Equal(got, []int{1}) {
+		t.Error(fmt.Errorf("spatialGrid.FindNear() want: %+v, got: %+v\n", []int{1}, got))
+	}
+	if got := sg.Size(); got != 2 {
+		t.Error(fmt.Errorf("spatialGrid.Size() want: %+v, got: %+v\n", 2, got))
+	}
+
+	// moving within its own full cell frees the slot it needs
+	moved := mosaic.NewRectangle(mosaic.NewVector(5, 5), 2, 2)
+	err = sg.Update(lattice.Item[int]{1, moved, 1}, home)
+	if err != nil {
+		t.Error(fmt.Errorf("spatialGrid.Update() want error: %+v, got error: %+v\n", nil, err))
+	}
+}
@@ -0,0 +1,19 @@
+package lattice
+
+func (sg *SpatialGrid[T]) SetCellData(x, y int, data any) {
+	sg.lock()
+	defer sg.unlock()
+
+	sg.Nodes[x][y].data = data
+}
+
+func (sg *SpatialGrid[T]) CellData(x, y int) any {
+	sg = sg.rlock()
+	defer sg.runlock()
+
+	return sg.Nodes[x][y].data
+}
+
+func (sgn spatialGridNode[T]) Data() any {
+	return sgn.data
+}
@@ -0,0 +1,56 @@
+package lattice_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/mosaic"
+)
+
+func Test_spatial_grid_CellData(t *testing.T) {
+	type biome struct {
+		name string
+		room int
+	}
+	type params struct {
+		x    int
+		y    int
+		data any
+	}
+	tests := []struct {
+		name   string
+		params []params
+	}{
+		{
+			name: "survives drop",
+			params: []params{
+				{x: 0, y: 0, data: biome{name: "swamp", room: 1}},
+				{x: 3, y: 2, data: "lava"},
+				{x: 1, y: 3, data: 42},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg := lattice.NewSpatialGrid[int](4, 4, 8)
+			for _, param := range tt.params {
+				sg.SetCellData(param.x, param.y, param.data)
+			}
+			sg.Insert(lattice.Item[int]{1, mosaic.NewRectangle(mosaic.Vector{X: 4, Y: 4}, 2, 2), 1.0})
+			sg.Drop()
+
+			for _, param := range tt.params {
+				got := sg.CellData(param.x, param.y)
+				if got != param.data {
+					t.Error(fmt.Errorf("spatialGrid.CellData() want: %+v, got: %+v\n", param.data, got))
+				}
+			}
+
+			got := sg.CellData(2, 2)
+			if got != nil {
+				t.Error(fmt.Errorf("spatialGrid.CellData() want: nil, got: %+v\n", got))
+			}
+		})
+	}
+}
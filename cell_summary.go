@@ -0,0 +1,75 @@
+package lattice
+
+import "github.com/maladroitthief/mosaic"
+
+type (
+	// CellSummary is a cell's item count and weight, packed for strip reads
+	// by minimaps and debug overlays
+	CellSummary struct {
+		X      int
+		Y      int
+		Count  int
+		Weight float64
+	}
+)
+
+// Row summarizes every cell in row y from x = 0 up, or returns nil when y is
+// outside the grid
+func (sg *SpatialGrid[T]) Row(y int) []CellSummary {
+	sg = sg.rlock()
+	defer sg.runlock()
+
+	if y < 0 || y >= sg.SizeY {
+		return nil
+	}
+
+	row := make([]CellSummary, sg.SizeX)
+	for x := range row {
+		row[x] = sg.Nodes[x][y].summary()
+	}
+
+	return row
+}
+
+// Column summarizes every cell in column x from y = 0 up, or returns nil when
+// x is outside the grid
+func (sg *SpatialGrid[T]) Column(x int) []CellSummary {
+	sg = sg.rlock()
+	defer sg.runlock()
+
+	if x < 0 || x >= sg.SizeX {
+		return nil
+	}
+
+	column := make([]CellSummary, sg.SizeY)
+	for y := range column {
+		column[y] = sg.Nodes[x][y].summary()
+	}
+
+	return column
+}
+
+// Cells summarizes every cell under the world rectangle in index order, row
+// by row, under a single read lock
+func (sg *SpatialGrid[T]) Cells(rect mosaic.Rectangle) []CellSummary {
+	sg = sg.rlock()
+	defer sg.runlock()
+
+	xMin, yMin, xMax, yMax, ok := sg.cellRange(rect)
+	if !ok {
+		return []CellSummary{}
+	}
+
+	cells := make([]CellSummary, 0, (xMax-xMin+1)*(yMax-yMin+1))
+	for y := yMin; y <= yMax; y++ {
+		for x := xMin; x <= xMax; x++ {
+			cells = append(cells, sg.Nodes[x][y].summary())
+		}
+	}
+
+	return cells
+}
+
+func (sgn spatialGridNode[T]) summary() CellSummary {
+	return CellSummary{X: sgn.x, Y: sgn.y, Count: len(sgn.Items), Weight: sgn.weight}
+}
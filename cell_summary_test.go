@@ -0,0 +1,71 @@
+package lattice_test
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/mosaic"
+)
+
+func Test_spatial_grid_cell_summaries(t *testing.T) {
+	sg := lattice.NewSpatialGrid[int](3, 3, 8)
+	sg.SetTerrain(2, 1, 5)
+	sg.Insert(lattice.Item[int]{1, mosaic.NewRectangle(mosaic.Vector{X: 4, Y: 12}, 2, 2), 1})
+	sg.Insert(lattice.Item[int]{2, mosaic.NewRectangle(mosaic.Vector{X: 4, Y: 12}, 2, 2), 1})
+	sg.Insert(lattice.Item[int]{3, mosaic.NewRectangle(mosaic.Vector{X: 12, Y: 20}, 1, 1), 1})
+
+	tests := []struct {
+		name string
+		read func() []lattice.CellSummary
+		want []lattice.CellSummary
+	}{
+		{
+			name: "row",
+			read: func() []lattice.CellSummary { return sg.Row(1) },
+			want: []lattice.CellSummary{
+				{X: 0, Y: 1, Count: 2, Weight: 8},
+				{X: 1, Y: 1, Count: 0, Weight: 0},
+				{X: 2, Y: 1, Count: 0, Weight: 5},
+			},
+		},
+		{
+			name: "row out of bounds",
+			read: func() []lattice.CellSummary { return sg.Row(3) },
+		},
+		{
+			name: "column",
+			read: func() []lattice.CellSummary { return sg.Column(1) },
+			want: []lattice.CellSummary{
+				{X: 1, Y: 0, Count: 0, Weight: 0},
+				{X: 1, Y: 1, Count: 0, Weight: 0},
+				{X: 1, Y: 2, Count: 1, Weight: 1},
+			},
+		},
+		{
+			name: "column out of bounds",
+			read: func() []lattice.CellSummary { return sg.Column(-1) },
+		},
+		{
+			name: "cells",
+			read: func() []lattice.CellSummary {
+				return sg.Cells(mosaic.NewRectangle(mosaic.Vector{X: 12, Y: 16}, 8, 8))
+			},
+			want: []lattice.CellSummary{
+				{X: 1, Y: 1, Count: 0, Weight: 0},
+				{X: 2, Y: 1, Count: 0, Weight: 5},
+				{X: 1, Y: 2, Count: 1, Weight: 1},
+				{X: 2, Y: 2, Count: 0, Weight: 0},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.read()
+			if !slices.Equal(tt.want, got) {
+				t.Error(fmt.Errorf("spatialGrid.%s() want: %+v, got: %+v\n", tt.name, tt.want, got))
+			}
+		})
+	}
+}
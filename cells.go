@@ -0,0 +1,76 @@
+package lattice
+
+import (
+	"math"
+
+	"github.com/maladroitthief/mosaic"
+)
+
+func (sg *SpatialGrid[T]) CellBounds(x, y int) mosaic.Rectangle {
+	return mosaic.NewRectangle(sg.CellCenter(x, y), sg.ChunkSize, sg.ChunkSize)
+}
+
+func (sg *SpatialGrid[T]) CellCenter(x, y int) mosaic.Vector {
+	return mosaic.NewVector(
+		sg.origin.X+(float64(x)*sg.ChunkSize)+sg.ChunkSize/2,
+		sg.origin.Y+(float64(y)*sg.ChunkSize)+sg.ChunkSize/2,
+	)
+}
+
+// CellToWorld returns the minimum corner of the cell, use CellCenter for its
+// middle
+func (sg *SpatialGrid[T]) CellToWorld(x, y int) mosaic.Vector {
+	return mosaic.NewVector(sg.origin.X+float64(x)*sg.ChunkSize, sg.origin.Y+float64(y)*sg.ChunkSize)
+}
+
+// WorldToCell is Location without the clamping, ok is false when the point
+// falls outside the grid
+func (sg *SpatialGrid[T]) WorldToCell(v mosaic.Vector) (x, y int, ok bool) {
+	x = int(math.Floor((v.X - sg.origin.X) / sg.ChunkSize))
+	y = int(math.Floor((v.Y - sg.origin.Y) / sg.ChunkSize))
+
+	return x, y, sg.inBounds(x, y)
+}
+
+// CellIndex flattens x, y the way the grid's per-cell arrays are laid out,
+// row by row, so a slice of SizeX*SizeY entries can sit alongside them
+func (sg *SpatialGrid[T]) CellIndex(x, y int) int {
+	return sg.index(x, y)
+}
+
+// IndexToCell reverses CellIndex
+func (sg *SpatialGrid[T]) IndexToCell(i int) (x, y int) {
+	return i % sg.SizeX, i / sg.SizeX
+}
+
+// cell is Location, except that strict grids reject rather than clamp
+func (sg *SpatialGrid[T]) cell(x, y float64) (int, int, error) {
+	if !sg.config.strict {
+		xIndex, yIndex := sg.Location(x, y)
+		return xIndex, yIndex, nil
+	}
+
+	xIndex, yIndex, ok := sg.WorldToCell(mosaic.NewVector(x, y))
+	if !ok {
+		return 0, 0, ErrOutOfBounds
+	}
+
+	return xIndex, yIndex, nil
+}
+
+// cellRange is the inclusive block of cells under bounds, ok is false when a
+// strict grid has no cells there at all
+func (sg *SpatialGrid[T]) cellRange(bounds mosaic.Rectangle) (xMin, yMin, xMax, yMax int, ok bool) {
+	minPoint, maxPoint := bounds.MinPoint(), bounds.MaxPoint()
+	if sg.config.strict {
+		low, high := sg.CellToWorld(0, 0), sg.CellToWorld(sg.SizeX, sg.SizeY)
+		if maxPoint.X < low.X || maxPoint.Y < low.Y || minPoint.X >= high.X || minPoint.Y >= high.Y {
+			return 0, 0, 0, 0, false
+		}
+	}
+
+	xMin, yMin = sg.Location(minPoint.X, minPoint.Y)
+	xMax, yMax = sg.Location(maxPoint.X, maxPoint.Y)
+
+	return xMin, yMin, xMax, yMax, true
+}
@@ -0,0 +1,89 @@
+package lattice_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/mosaic"
+)
+
+func Test_spatial_grid_cell_conversions(t *testing.T) {
+	type want struct {
+		bounds mosaic.Rectangle
+		center mosaic.Vector
+		world  mosaic.Vector
+	}
+	tests := []struct {
+		name string
+		x, y int
+		want want
+	}{
+		{
+			name: "origin",
+			x:    0,
+			y:    0,
+			want: want{
+				bounds: mosaic.NewRectangle(mosaic.Vector{X: 4, Y: 4}, 8, 8),
+				center: mosaic.Vector{X: 4, Y: 4},
+				world:  mosaic.Vector{X: 0, Y: 0},
+			},
+		},
+		{
+			name: "far corner",
+			x:    3,
+			y:    2,
+			want: want{
+				bounds: mosaic.NewRectangle(mosaic.Vector{X: 28, Y: 20}, 8, 8),
+				center: mosaic.Vector{X: 28, Y: 20},
+				world:  mosaic.Vector{X: 24, Y: 16},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg := lattice.NewSpatialGrid[int](4, 4, 8)
+
+			got := want{
+				bounds: sg.CellBounds(tt.x, tt.y),
+				center: sg.CellCenter(tt.x, tt.y),
+				world:  sg.CellToWorld(tt.x, tt.y),
+			}
+			if got != tt.want {
+				t.Error(fmt.Errorf("spatialGrid cell conversions want: %+v, got: %+v\n", tt.want, got))
+			}
+
+			for _, point := range []mosaic.Vector{got.center, got.world} {
+				x, y, ok := sg.WorldToCell(point)
+				if x != tt.x || y != tt.y || !ok {
+					t.Error(fmt.Errorf("spatialGrid.WorldToCell() want: %+v %+v, got: %+v %+v %+v\n", tt.x, tt.y, x, y, ok))
+				}
+			}
+		})
+	}
+
+	sg := lattice.NewSpatialGrid[int](4, 4, 8)
+	x, y, ok := sg.WorldToCell(mosaic.Vector{X: -1, Y: 40})
+	if x != -1 || y != 5 || ok {
+		t.Error(fmt.Errorf("spatialGrid.WorldToCell() want: -1 5 false, got: %+v %+v %+v\n", x, y, ok))
+	}
+}
+
+func Test_spatial_grid_CellIndex(t *testing.T) {
+	sg := lattice.NewSpatialGrid[int](5, 3, 8)
+
+	want := 0
+	for y := 0; y < sg.SizeY; y++ {
+		for x := 0; x < sg.SizeX; x++ {
+			got := sg.CellIndex(x, y)
+			if got != want {
+				t.Error(fmt.Errorf("spatialGrid.CellIndex(%d, %d) want: %+v, got: %+v\n", x, y, want, got))
+			}
+			gotX, gotY := sg.IndexToCell(got)
+			if gotX != x || gotY != y {
+				t.Error(fmt.Errorf("spatialGrid.IndexToCell(%d) want: %+v %+v, got: %+v %+v\n", got, x, y, gotX, gotY))
+			}
+			want++
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package lattice
+
+// Chokepoints returns the articulation points of the open cells, the cells
+// whose loss would split a connected region in two, ordered by row. Moves
+// and portals count in both directions regardless of edge rules.
+func (sg *SpatialGrid[T]) Chokepoints() []Cell {
+	sg = sg.rlock()
+	defer sg.runlock()
+
+	cells := sg.SizeX * sg.SizeY
+	adjacent := make([][]int32, cells)
+	for i := 0; i < cells; i++ {
+		if sg.blocked.get(i) {
+			continue
+		}
+		sg.goalEdges(int32(i), TraversalProfile{}, func(next int32, _ int, _ float64) {
+			adjacent[i] = append(adjacent[i], next)
+			adjacent[next] = append(adjacent[next], int32(i))
+		})
+	}
+
+	// iterative Tarjan, disc 0 meaning not yet visited
+	type frame struct {
+		cell int32
+		edge int
+	}
+	disc := make([]int32, cells)
+	low := make([]int32, cells)
+	cut := make([]bool, cells)
+	stack := []frame{}
+	clock := int32(0)
+	for root := 0; root < cells; root++ {
+		if disc[root] != 0 || sg.blocked.get(root) {
+			continue
+		}
+
+		clock++
+		disc[root], low[root] = clock, clock
+		stack = append(stack[:0], frame{cell: int32(root)})
+		children := 0
+		for len(stack) > 0 {
+			top := &stack[len(stack)-1]
+			v := top.cell
+			if top.edge < len(adjacent[v]) {
+				w := adjacent[v][top.edge]
+				top.edge++
+				if disc[w] != 0 {
+					low[v] = min(low[v], disc[w])
+					continue
+				}
+
+				if int(v) == root {
+					children++
+				}
+				clock++
+				disc[w], low[w] = clock, clock
+				stack = append(stack, frame{cell: w})
+				continue
+			}
+
+			stack = stack[:len(stack)-1]
+			if len(stack) == 0 {
+				continue
+			}
+			parent := stack[len(stack)-1].cell
+			low[parent] = min(low[parent], low[v])
+			if int(parent) != root && low[v] >= disc[parent] {
+				cut[parent] = true
+			}
+		}
+		if children > 1 {
+			cut[root] = true
+		}
+	}
+
+	chokepoints := []Cell{}
+	for i, c := range cut {
+		if c {
+			chokepoints = append(chokepoints, Cell{i % sg.SizeX, i / sg.SizeX})
+		}
+	}
+
+	return chokepoints
+}
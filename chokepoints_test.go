@@ -0,0 +1,64 @@
+package lattice_test
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+)
+
+func Test_spatial_grid_Chokepoints(t *testing.T) {
+	tests := []struct {
+		name    string
+		builder Builder
+		want    []lattice.Cell
+	}{
+		{
+			name: "open room",
+			builder: Builder{x: 3, y: 3, size: 16, layout: "" +
+				"000" +
+				"000" +
+				"000"},
+			want: []lattice.Cell{},
+		},
+		{
+			name: "two rooms and a door",
+			builder: Builder{x: 5, y: 5, size: 16, layout: "" +
+				"00x00" +
+				"00x00" +
+				"00000" +
+				"00x00" +
+				"00x00"},
+			want: []lattice.Cell{{X: 1, Y: 2}, {X: 2, Y: 2}, {X: 3, Y: 2}},
+		},
+		{
+			name: "corridor",
+			builder: Builder{x: 4, y: 4, size: 16, layout: "" +
+				"0000" +
+				"xxxx" +
+				"xxxx" +
+				"xxxx"},
+			want: []lattice.Cell{{X: 1, Y: 0}, {X: 2, Y: 0}},
+		},
+		{
+			name: "loops have no chokepoints",
+			builder: Builder{x: 3, y: 3, size: 16, layout: "" +
+				"000" +
+				"0x0" +
+				"000"},
+			want: []lattice.Cell{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg := lattice.NewSpatialGrid[int](tt.builder.x, tt.builder.y, float64(tt.builder.size))
+			setup_grid(sg, tt.builder)
+
+			got := sg.Chokepoints()
+			if !slices.Equal(tt.want, got) {
+				t.Error(fmt.Errorf("spatialGrid.Chokepoints() want: %+v, got: %+v\n", tt.want, got))
+			}
+		})
+	}
+}
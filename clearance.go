@@ -0,0 +1,58 @@
+package lattice
+
+import "math"
+
+type (
+	// Clearance charges every cell penalty divided by its distance in cells
+	// to the nearest blocked cell, steering searches toward the middle of
+	// corridors. Like Landmarks it describes the grid as it was when built,
+	// searches after a write that changes a weight or blocks a cell ignore
+	// it.
+	Clearance struct {
+		costs []float64
+		width int
+		size  int
+		at    uint64
+	}
+)
+
+// PrecomputeClearance builds the clearance costs from the obstacle distance
+// field, with blocked as in ObstacleDistanceField
+func (sg *SpatialGrid[T]) PrecomputeClearance(penalty float64, blocked func(weight float64) bool) (*Clearance, error) {
+	sg = sg.rlock()
+	defer sg.runlock()
+
+	if penalty < 0 || math.IsInf(penalty, 0) || math.IsNaN(penalty) {
+		return nil, ErrInvalidOption
+	}
+
+	costs := sg.distanceField(blocked)
+	for i, d := range costs {
+		// blocked cells are never entered and open maps cost nothing extra
+		if d == 0 || math.IsInf(d, 1) {
+			costs[i] = 0
+			continue
+		}
+		costs[i] = penalty / d
+	}
+
+	return &Clearance{costs: costs, width: sg.SizeX, size: sg.SizeX * sg.SizeY, at: sg.shape}, nil
+}
+
+// Cost is the penalty for entering x, y
+func (c *Clearance) Cost(x, y int) float64 {
+	if x < 0 || x >= c.width || y < 0 || y*c.width+x >= c.size {
+		return 0
+	}
+
+	return c.costs[y*c.width+x]
+}
+
+func (sg *SpatialGrid[T]) clearance(opts PathOptions) []float64 {
+	c := opts.Clearance
+	if c == nil || c.size != sg.SizeX*sg.SizeY || c.width != sg.SizeX || c.at != sg.shape {
+		return nil
+	}
+
+	return c.costs
+}
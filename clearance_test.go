@@ -0,0 +1,86 @@
+package lattice_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/mosaic"
+)
+
+func Test_spatial_grid_PrecomputeClearance(t *testing.T) {
+	room := Builder{
+		x:    9,
+		y:    9,
+		size: 16,
+		layout: "" +
+			"xxxxxxxxx" +
+			"x0000000x" +
+			"x0000000x" +
+			"x0000000x" +
+			"x0000000x" +
+			"x0000000x" +
+			"x0000000x" +
+			"x0000000x" +
+			"xxxxxxxxx",
+	}
+	start, end := mosaic.NewVector(24, 24), mosaic.NewVector(120, 24)
+	type want struct {
+		wallSteps int
+		err       error
+	}
+	tests := []struct {
+		name    string
+		penalty float64
+		write   bool
+		other   bool
+		want    want
+	}{
+		{name: "scrapes the wall without clearance", want: want{wallSteps: 7}},
+		{name: "pulled off the wall", penalty: 8, want: want{wallSteps: 4}},
+		{name: "ignored after a write", penalty: 8, write: true, want: want{wallSteps: 7}},
+		{name: "kept after a write leaving weights alone", penalty: 8, other: true, want: want{wallSteps: 4}},
+		{name: "negative penalty", penalty: -1, want: want{err: lattice.ErrInvalidOption}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg := lattice.NewSpatialGrid[int](room.x, room.y, float64(room.size))
+			setup_grid(sg, room)
+
+			opts := lattice.PathOptions{}
+			if tt.penalty != 0 {
+				clearance, err := sg.PrecomputeClearance(tt.penalty, nil)
+				if !errors.Is(err, tt.want.err) {
+					t.Fatal(fmt.Errorf("spatialGrid.PrecomputeClearance() want: %+v, got: %+v\n", tt.want.err, err))
+				}
+				if err != nil {
+					return
+				}
+				opts.Clearance = clearance
+			}
+			if tt.write {
+				sg.Insert(lattice.Item[int]{2, mosaic.NewRectangle(mosaic.NewVector(200, 200), 1, 1), 0})
+			}
+			if tt.other {
+				sg.AddScent(24, 24, 1)
+				sg.SetCellData(1, 1, "start")
+				sg.Tick(1)
+			}
+
+			path, err := sg.FindPath(start, end, opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := 0
+			for _, waypoint := range path.Waypoints {
+				if waypoint.Y == 24 {
+					got++
+				}
+			}
+			if got != tt.want.wallSteps {
+				t.Error(fmt.Errorf("spatialGrid.FindPath() want: %+v cells along the wall, got: %+v %+v\n", tt.want.wallSteps, got, path.Waypoints))
+			}
+		})
+	}
+}
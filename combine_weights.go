@@ -0,0 +1,24 @@
+package lattice
+
+// CombineWeights returns op applied to the weights of every pair of matching
+// cells, indexed like Nodes, so a precomputed static cost grid can be folded
+// into a live one. The result fits a WeightLayer or SetTerrain. Both grids
+// must share dimensions, chunk size and origin.
+func (sg *SpatialGrid[T]) CombineWeights(other *SpatialGrid[T], op func(a, b float64) float64) ([][]float64, error) {
+	sg, other, release := rlockPair(sg, other)
+	defer release()
+
+	if !sg.sameShape(other) {
+		return nil, ErrGridMismatch
+	}
+
+	weights := make([][]float64, sg.SizeX)
+	for x := range weights {
+		weights[x] = make([]float64, sg.SizeY)
+		for y := range weights[x] {
+			weights[x][y] = op(sg.Nodes[x][y].weight, other.Nodes[x][y].weight)
+		}
+	}
+
+	return weights, nil
+}
@@ -0,0 +1,126 @@
+package lattice_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/mosaic"
+)
+
+func Test_spatial_grid_CombineWeights(t *testing.T) {
+	add := func(a, b float64) float64 { return a + b }
+	scale := func(a, b float64) float64 { return a * b }
+	tests := []struct {
+		name    string
+		terrain [][]float64
+		items   []lattice.Item[int]
+		other   [][]float64
+		op      func(a, b float64) float64
+		size    int
+		want    [][]float64
+		err     error
+	}{
+		{
+			name:    "add static costs",
+			terrain: [][]float64{{1, 0}, {0, 2}},
+			items:   []lattice.Item[int]{{1, mosaic.NewRectangle(mosaic.Vector{X: 12, Y: 4}, 2, 2), 1}},
+			other:   [][]float64{{5, 5}, {0, 1}},
+			op:      add,
+			size:    2,
+			want:    [][]float64{{6, 5}, {4, 3}},
+		},
+		{
+			name:    "scale",
+			terrain: [][]float64{{1, 2}, {3, 4}},
+			other:   [][]float64{{2, 0}, {0.5, 1}},
+			op:      scale,
+			size:    2,
+			want:    [][]float64{{2, 0}, {1.5, 4}},
+		},
+		{
+			name:    "mismatch",
+			terrain: [][]float64{{1, 2}, {3, 4}},
+			other:   [][]float64{{1, 2, 3}, {3, 4, 5}, {1, 1, 1}},
+			op:      add,
+			size:    3,
+			err:     lattice.ErrGridMismatch,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg := lattice.NewSpatialGrid[int](len(tt.terrain), len(tt.terrain[0]), 8)
+			for x := range tt.terrain {
+				for y, w := range tt.terrain[x] {
+					sg.SetTerrain(x, y, w)
+				}
+			}
+			for _, item := range tt.items {
+				sg.Insert(item)
+			}
+			other := lattice.NewSpatialGrid[int](tt.size, tt.size, 8)
+			for x := range tt.other {
+				for y, w := range tt.other[x] {
+					other.SetTerrain(x, y, w)
+				}
+			}
+
+			got, err := sg.CombineWeights(other, tt.op)
+			if !errors.Is(err, tt.err) {
+				t.Error(fmt.Errorf("spatialGrid.CombineWeights() want: %+v, got: %+v\n", tt.err, err))
+			}
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Error(fmt.Errorf("spatialGrid.CombineWeights() want: %+v, got: %+v\n", tt.want, got))
+			}
+		})
+	}
+}
+
+func Test_spatial_grid_CombineWeights_self(t *testing.T) {
+	sg, err := lattice.NewSpatialGridOpts[int](2, 2, 8, lattice.WithLocking(lattice.LockingExclusive))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sg.SetTerrain(1, 1, 3)
+
+	got, err := sg.CombineWeights(sg, func(a, b float64) float64 { return a + b })
+	want := [][]float64{{0, 0}, {0, 6}}
+	if err != nil || !reflect.DeepEqual(want, got) {
+		t.Error(fmt.Errorf("spatialGrid.CombineWeights() want: %+v, got: %+v, %+v\n", want, got, err))
+	}
+}
+
+func Test_spatial_grid_CombineWeights_crossed(t *testing.T) {
+	a, err := lattice.NewSpatialGridOpts[int](2, 2, 8, lattice.WithLocking(lattice.LockingExclusive))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := lattice.NewSpatialGridOpts[int](2, 2, 8, lattice.WithLocking(lattice.LockingExclusive))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sum := func(a, b float64) float64 { return a + b }
+
+	// each side holding one grid while waiting on the other deadlocks, which
+	// takes more than one thread to show
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+	var wg sync.WaitGroup
+	for _, pair := range [][2]*lattice.SpatialGrid[int]{{a, b}, {b, a}} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 10000; i++ {
+				_, err := pair[0].CombineWeights(pair[1], sum)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
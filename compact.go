@@ -0,0 +1,84 @@
+package lattice
+
+import (
+	"math"
+	"unsafe"
+)
+
+// Compact shrinks every cell's backing arrays down to its items, or the
+// configured capacity if that is larger, and returns roughly how many bytes
+// were released. Cells keep the capacity they grew to until this runs, so
+// call it after heavy churn in long running processes.
+func (sg *SpatialGrid[T]) Compact() int {
+	sg.lock()
+	defer sg.unlock()
+
+	reclaimed := 0
+	for x := range sg.Nodes {
+		for y := range sg.Nodes[x] {
+			var freed int
+			sg.Nodes[x][y], freed = sg.Nodes[x][y].compact(sg.config.capacity)
+			reclaimed += freed
+		}
+	}
+	if spare := cap(sg.overflow) - len(sg.overflow); spare > 0 {
+		reclaimed += spare * int(unsafe.Sizeof(spilled[T]{}))
+		sg.overflow = shrink(sg.overflow, len(sg.overflow))
+	}
+
+	return reclaimed
+}
+
+// WithAutoCompact compacts a cell as soon as a delete leaves its backing
+// array more than ratio times larger than it needs to be
+func WithAutoCompact(ratio float64) Option {
+	return func(c *config) error {
+		if ratio < 1 || math.IsInf(ratio, 0) || math.IsNaN(ratio) {
+			return ErrInvalidOption
+		}
+
+		c.autoCompact = ratio
+		return nil
+	}
+}
+
+// autoCompact applies WithAutoCompact to the cell at x, y
+func (sg *SpatialGrid[T]) autoCompact(x, y int) {
+	if sg.config.autoCompact == 0 {
+		return
+	}
+
+	node := sg.Nodes[x][y]
+	if float64(cap(node.Items)) > sg.config.autoCompact*float64(max(len(node.Items), sg.config.capacity, 1)) {
+		sg.Nodes[x][y], _ = node.compact(sg.config.capacity)
+	}
+}
+
+func (sgn spatialGridNode[T]) compact(capacity int) (spatialGridNode[T], int) {
+	freed := 0
+	keep := max(len(sgn.Items), capacity)
+	if cap(sgn.Items) > keep {
+		freed += (cap(sgn.Items) - keep) * int(unsafe.Sizeof(spatialGridNodeItem[T]{}))
+		sgn.Items = shrink(sgn.Items, keep)
+	}
+
+	if cap(sgn.packed.minX) > keep {
+		freed += 4 * (cap(sgn.packed.minX) - keep) * int(unsafe.Sizeof(float64(0)))
+		sgn.packed = packedBounds{
+			minX: shrink(sgn.packed.minX, keep),
+			minY: shrink(sgn.packed.minY, keep),
+			maxX: shrink(sgn.packed.maxX, keep),
+			maxY: shrink(sgn.packed.maxY, keep),
+		}
+	}
+
+	return sgn, freed
+}
+
+// shrink copies s into a new backing array of capacity keep
+func shrink[E any](s []E, keep int) []E {
+	shrunk := make([]E, len(s), keep)
+	copy(shrunk, s)
+
+	return shrunk
+}
@@ -0,0 +1,93 @@
+package lattice_test
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/mosaic"
+)
+
+func Test_spatial_grid_Compact(t *testing.T) {
+	tests := []struct {
+		name    string
+		inserts int
+		deletes int
+		freed   bool
+	}{
+		{name: "empty", freed: false},
+		{name: "within capacity", inserts: 4, deletes: 2, freed: false},
+		{name: "after churn", inserts: 200, deletes: 195, freed: true},
+		{name: "still full", inserts: 200, deletes: 0, freed: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg, err := lattice.NewSpatialGridOpts[int](4, 4, 8, lattice.WithCapacity(4))
+			if err != nil {
+				t.Fatal(err)
+			}
+			bounds := mosaic.NewRectangle(mosaic.NewVector(4, 4), 2, 2)
+			for i := 0; i < tt.inserts; i++ {
+				sg.Insert(lattice.Item[int]{i, bounds, 1})
+			}
+			for i := 0; i < tt.deletes; i++ {
+				sg.Delete(i, bounds)
+			}
+
+			freed := sg.Compact()
+			if (freed > 0) != tt.freed {
+				t.Error(fmt.Errorf("spatialGrid.Compact() want: %+v, got: %+v\n", tt.freed, freed))
+			}
+			if again := sg.Compact(); again != 0 {
+				t.Error(fmt.Errorf("spatialGrid.Compact() want: %+v, got: %+v\n", 0, again))
+			}
+
+			want := []int{}
+			for i := tt.deletes; i < tt.inserts; i++ {
+				want = append(want, i)
+			}
+			got := sg.FindIntersecting(bounds)
+			slices.Sort(got)
+			if !slices.Equal(want, got) {
+				t.Error(fmt.Errorf("spatialGrid.FindIntersecting() want: %+v, got: %+v\n", want, got))
+			}
+		})
+	}
+}
+
+func Test_spatial_grid_WithAutoCompact(t *testing.T) {
+	for _, ratio := range []float64{0, 0.5} {
+		_, err := lattice.NewSpatialGridOpts[int](4, 4, 8, lattice.WithAutoCompact(ratio))
+		if !errors.Is(err, lattice.ErrInvalidOption) {
+			t.Error(fmt.Errorf("lattice.WithAutoCompact(%v) want: %+v, got: %+v\n", ratio, lattice.ErrInvalidOption, err))
+		}
+	}
+
+	churn := func(opts ...lattice.Option) *lattice.SpatialGrid[int] {
+		sg, err := lattice.NewSpatialGridOpts[int](4, 4, 8, append(opts, lattice.WithCapacity(4))...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		bounds := mosaic.NewRectangle(mosaic.NewVector(4, 4), 2, 2)
+		for i := 0; i < 200; i++ {
+			sg.Insert(lattice.Item[int]{i, bounds, 1})
+		}
+		for i := 0; i < 195; i++ {
+			sg.Delete(i, bounds)
+		}
+		return sg
+	}
+
+	// the deletes already shrank the cell, leaving less than twice what it
+	// holds for Compact to release
+	manual, auto := churn(), churn(lattice.WithAutoCompact(2))
+	manualFreed, autoFreed := manual.Compact(), auto.Compact()
+	if autoFreed >= manualFreed {
+		t.Error(fmt.Errorf("spatialGrid.Compact() want: below %+v, got: %+v\n", manualFreed, autoFreed))
+	}
+	if got := auto.Size(); got != 5 {
+		t.Error(fmt.Errorf("spatialGrid.Size() want: %+v, got: %+v\n", 5, got))
+	}
+}
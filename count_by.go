@@ -0,0 +1,22 @@
+package lattice
+
+// CountBy tallies every item in the grid, spilled items included, under the
+// key classify returns for its value
+func (sg *SpatialGrid[T]) CountBy(classify func(T) string) map[string]int {
+	sg = sg.rlock()
+	defer sg.runlock()
+
+	counts := map[string]int{}
+	for x := range sg.Nodes {
+		for y := range sg.Nodes[x] {
+			for _, item := range sg.Nodes[x][y].Items {
+				counts[classify(item.value)]++
+			}
+		}
+	}
+	for _, item := range sg.overflow {
+		counts[classify(item.Value)]++
+	}
+
+	return counts
+}
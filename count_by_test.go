@@ -0,0 +1,74 @@
+package lattice_test
+
+import (
+	"fmt"
+	"maps"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/mosaic"
+)
+
+func Test_spatial_grid_CountBy(t *testing.T) {
+	type params struct {
+		items []lattice.Item[int]
+		opts  []lattice.Option
+	}
+	type want struct {
+		counts map[string]int
+	}
+	parity := func(v int) string {
+		if v%2 == 0 {
+			return "even"
+		}
+		return "odd"
+	}
+	tests := []struct {
+		name   string
+		params params
+		want   want
+	}{
+		{
+			name: "empty",
+			want: want{counts: map[string]int{}},
+		},
+		{
+			name: "spanning items counted once",
+			params: params{
+				items: []lattice.Item[int]{
+					{1, mosaic.NewRectangle(mosaic.NewVector(16, 16), 64, 64), 1},
+					{2, mosaic.NewRectangle(mosaic.NewVector(48, 48), 8, 8), 1},
+					{3, mosaic.NewRectangle(mosaic.NewVector(80, 16), 8, 8), 1},
+				},
+			},
+			want: want{counts: map[string]int{"odd": 2, "even": 1}},
+		},
+		{
+			name: "spilled items",
+			params: params{
+				items: []lattice.Item[int]{
+					{1, mosaic.NewRectangle(mosaic.NewVector(16, 16), 8, 8), 1},
+					{2, mosaic.NewRectangle(mosaic.NewVector(16, 16), 8, 8), 1},
+				},
+				opts: []lattice.Option{lattice.WithCellCap(1, lattice.OverflowSpill)},
+			},
+			want: want{counts: map[string]int{"odd": 1, "even": 1}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg, err := lattice.NewSpatialGridOpts[int](4, 4, 32, tt.params.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, item := range tt.params.items {
+				sg.Insert(item)
+			}
+
+			got := sg.CountBy(parity)
+			if !maps.Equal(tt.want.counts, got) {
+				t.Error(fmt.Errorf("spatialGrid.CountBy() want: %+v, got: %+v\n", tt.want.counts, got))
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package lattice
+
+import (
+	"math"
+
+	"github.com/maladroitthief/mosaic"
+)
+
+// CoveragePath sweeps area in alternating rows stride cells apart, stopping
+// on every stride-th cell of each row and at both ends of every open run.
+// Stops the first one cannot reach are skipped, and consecutive stops are
+// joined by the cheapest route so the path steps between neighbors
+// throughout.
+func (sg *SpatialGrid[T]) CoveragePath(area mosaic.Rectangle, stride int) (Path, error) {
+	sg = sg.rlock()
+	defer sg.runlock()
+
+	if stride < 1 {
+		return Path{Waypoints: []mosaic.Vector{}}, ErrInvalidOption
+	}
+	xMin, yMin, xMax, yMax, ok := sg.cellRange(area)
+	if !ok {
+		return Path{Waypoints: []mosaic.Vector{}}, ErrPathNotFound
+	}
+
+	open := func(x, y int) bool {
+		return !sg.blocked.get(sg.index(x, y))
+	}
+	stops := []Cell{}
+	for row, y := 0, yMin; y <= yMax; row, y = row+1, y+stride {
+		start, end, step := xMin, xMax, 1
+		if row%2 == 1 {
+			start, end, step = xMax, xMin, -1
+		}
+		for x := start; x != end+step; x += step {
+			if !open(x, y) {
+				continue
+			}
+			// run ends keep the sweep from cutting corners around walls
+			runEnd := x == start || x == end || !open(x-step, y) || !open(x+step, y)
+			if runEnd || (x-xMin)%stride == 0 {
+				stops = append(stops, Cell{x, y})
+			}
+		}
+	}
+	if len(stops) == 0 {
+		return Path{Waypoints: []mosaic.Vector{}}, ErrPathNotFound
+	}
+
+	w := sg.newGoalWalker(sg.SizeX * sg.SizeY)
+	hops := make([]int32, sg.SizeX*sg.SizeY)
+	w.hops(int32(sg.index(stops[0].X, stops[0].Y)), hops, true)
+
+	s := sg.searcher()
+	defer sg.searchers.Put(s)
+
+	path := Path{Waypoints: []mosaic.Vector{sg.CellCenter(stops[0].X, stops[0].Y)}, Costs: []float64{0}}
+	from := stops[0]
+	for _, to := range stops[1:] {
+		if hops[sg.index(to.X, to.Y)] == math.MaxInt32 {
+			continue
+		}
+
+		leg, err := s.findPath(sg.CellCenter(from.X, from.Y), sg.CellCenter(to.X, to.Y), PathOptions{})
+		if err != nil {
+			return Path{Waypoints: []mosaic.Vector{}}, err
+		}
+		path.Waypoints = append(path.Waypoints, leg.Waypoints[1:]...)
+		for _, cost := range leg.Costs[1:] {
+			path.Costs = append(path.Costs, path.Cost+cost)
+		}
+		path.Cost += leg.Cost
+		from = to
+	}
+
+	return path, nil
+}
@@ -0,0 +1,78 @@
+package lattice_test
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/mosaic"
+)
+
+func Test_spatial_grid_CoveragePath(t *testing.T) {
+	room := Builder{
+		x:    5,
+		y:    5,
+		size: 16,
+		layout: "" +
+			"00000" +
+			"00x00" +
+			"00x00" +
+			"00000" +
+			"0000x",
+	}
+	cell := func(x, y float64) mosaic.Vector {
+		return mosaic.NewVector(x*16+8, y*16+8)
+	}
+	whole := mosaic.NewRectangle(mosaic.NewVector(40, 40), 80, 80)
+	type want struct {
+		waypoints []mosaic.Vector
+		err       error
+	}
+	tests := []struct {
+		name   string
+		area   mosaic.Rectangle
+		stride int
+		want   want
+	}{
+		{
+			name:   "every other row",
+			area:   whole,
+			stride: 2,
+			want: want{waypoints: []mosaic.Vector{
+				cell(0, 0), cell(1, 0), cell(2, 0), cell(3, 0), cell(4, 0),
+				cell(4, 1), cell(4, 2), cell(3, 2),
+				// around the wall to the other side of the row
+				cell(3, 3), cell(2, 3), cell(1, 3), cell(1, 2), cell(0, 2),
+				cell(0, 3), cell(0, 4), cell(1, 4), cell(2, 4), cell(3, 4),
+			}},
+		},
+		{
+			name:   "single row",
+			area:   mosaic.NewRectangle(mosaic.NewVector(40, 8), 80, 8),
+			stride: 3,
+			want:   want{waypoints: []mosaic.Vector{cell(0, 0), cell(1, 0), cell(2, 0), cell(3, 0), cell(4, 0)}},
+		},
+		{
+			name:   "invalid stride",
+			area:   whole,
+			stride: 0,
+			want:   want{waypoints: []mosaic.Vector{}, err: lattice.ErrInvalidOption},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg := lattice.NewSpatialGrid[int](room.x, room.y, float64(room.size))
+			setup_grid(sg, room)
+
+			got, err := sg.CoveragePath(tt.area, tt.stride)
+			if !errors.Is(err, tt.want.err) {
+				t.Fatal(fmt.Errorf("spatialGrid.CoveragePath() want: %+v, got: %+v\n", tt.want.err, err))
+			}
+			if !slices.Equal(tt.want.waypoints, got.Waypoints) {
+				t.Error(fmt.Errorf("spatialGrid.CoveragePath() want: %+v, got: %+v\n", tt.want.waypoints, got.Waypoints))
+			}
+		})
+	}
+}
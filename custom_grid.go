@@ -0,0 +1,213 @@
+package lattice
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/maladroitthief/mosaic"
+)
+
+type (
+	// CustomGrid stores values that are not comparable, or that need their own
+	// notion of identity, by interning each distinct value under an int handle
+	// kept in an ordinary SpatialGrid. Values are told apart by equal within
+	// a hash bucket, so equal values must hash alike.
+	CustomGrid[V any] struct {
+		mu      sync.RWMutex
+		grid    *SpatialGrid[int]
+		equal   func(a, b V) bool
+		hash    func(V) uint64
+		buckets map[uint64][]int
+		entries map[int]*customEntry[V]
+		next    int
+	}
+
+	CustomItem[V any] struct {
+		Value      V
+		Bounds     mosaic.Rectangle
+		Multiplier float64
+	}
+
+	customEntry[V any] struct {
+		value V
+		hash  uint64
+		refs  int
+	}
+)
+
+func NewCustomGrid[V any](
+	x, y int,
+	size float64,
+	equal func(a, b V) bool,
+	hash func(V) uint64,
+	opts ...Option,
+) (*CustomGrid[V], error) {
+	if equal == nil || hash == nil {
+		return nil, ErrInvalidOption
+	}
+
+	sg, err := NewSpatialGridOpts[int](x, y, size, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CustomGrid[V]{
+		grid:    sg,
+		equal:   equal,
+		hash:    hash,
+		buckets: map[uint64][]int{},
+		entries: map[int]*customEntry[V]{},
+	}, nil
+}
+
+// Grid returns the underlying grid of handles, for searches and weights.
+// Value translates the handles it reports back into values.
+func (cg *CustomGrid[V]) Grid() *SpatialGrid[int] {
+	return cg.grid
+}
+
+func (cg *CustomGrid[V]) Value(handle int) (V, bool) {
+	cg.mu.RLock()
+	defer cg.mu.RUnlock()
+
+	entry, ok := cg.entries[handle]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+
+	return entry.value, true
+}
+
+func (cg *CustomGrid[V]) Insert(item CustomItem[V]) error {
+	cg.mu.Lock()
+	defer cg.mu.Unlock()
+
+	handle := cg.intern(item.Value)
+	err := cg.grid.Insert(Item[int]{handle, item.Bounds, item.Multiplier})
+	if err != nil {
+		cg.release(handle)
+	}
+
+	return err
+}
+
+func (cg *CustomGrid[V]) Update(item CustomItem[V], oldBounds mosaic.Rectangle) error {
+	cg.mu.Lock()
+	defer cg.mu.Unlock()
+
+	handle, known := cg.lookup(item.Value)
+	if !known {
+		handle = cg.intern(item.Value)
+	}
+
+	err := cg.grid.Update(Item[int]{handle, item.Bounds, item.Multiplier}, oldBounds)
+	if err != nil && !known {
+		cg.release(handle)
+	}
+
+	return err
+}
+
+func (cg *CustomGrid[V]) Delete(value V, bounds mosaic.Rectangle) error {
+	cg.mu.Lock()
+	defer cg.mu.Unlock()
+
+	handle, ok := cg.lookup(value)
+	if !ok {
+		return ErrItemNotFound
+	}
+
+	err := cg.grid.Delete(handle, bounds)
+	if errors.Is(err, ErrItemNotFound) {
+		cg.settle(handle)
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	cg.release(handle)
+
+	return nil
+}
+
+func (cg *CustomGrid[V]) FindNear(bounds mosaic.Rectangle) []V {
+	return cg.values(cg.grid.FindNear(bounds))
+}
+
+func (cg *CustomGrid[V]) FindIntersecting(bounds mosaic.Rectangle) []V {
+	return cg.values(cg.grid.FindIntersecting(bounds))
+}
+
+func (cg *CustomGrid[V]) values(handles []int) []V {
+	cg.mu.RLock()
+	defer cg.mu.RUnlock()
+
+	values := make([]V, 0, len(handles))
+	for _, handle := range handles {
+		entry, ok := cg.entries[handle]
+		if ok {
+			values = append(values, entry.value)
+		}
+	}
+
+	return values
+}
+
+func (cg *CustomGrid[V]) lookup(value V) (int, bool) {
+	for _, handle := range cg.buckets[cg.hash(value)] {
+		if cg.equal(cg.entries[handle].value, value) {
+			return handle, true
+		}
+	}
+
+	return 0, false
+}
+
+func (cg *CustomGrid[V]) intern(value V) int {
+	handle, ok := cg.lookup(value)
+	if ok {
+		cg.entries[handle].refs++
+		return handle
+	}
+
+	cg.next++
+	handle = cg.next
+	hash := cg.hash(value)
+	cg.entries[handle] = &customEntry[V]{value: value, hash: hash, refs: 1}
+	cg.buckets[hash] = append(cg.buckets[hash], handle)
+
+	return handle
+}
+
+// settle drops the references of copies evicted from full cells, which
+// still hold their handle, while copies the caller only gave stale bounds
+// for keep theirs
+func (cg *CustomGrid[V]) settle(handle int) {
+	stored := cg.grid.copies(handle)
+	for entry, ok := cg.entries[handle]; ok && entry.refs > stored; entry, ok = cg.entries[handle] {
+		cg.release(handle)
+	}
+}
+
+func (cg *CustomGrid[V]) release(handle int) {
+	entry := cg.entries[handle]
+	entry.refs--
+	if entry.refs > 0 {
+		return
+	}
+
+	delete(cg.entries, handle)
+	bucket := cg.buckets[entry.hash]
+	for i, h := range bucket {
+		if h == handle {
+			bucket = append(bucket[:i], bucket[i+1:]...)
+			break
+		}
+	}
+	if len(bucket) == 0 {
+		delete(cg.buckets, entry.hash)
+		return
+	}
+	cg.buckets[entry.hash] = bucket
+}
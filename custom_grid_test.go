@@ -0,0 +1,141 @@
+package lattice_test
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/mosaic"
+)
+
+type shape struct {
+	name   string
+	points []int
+}
+
+func Test_custom_grid(t *testing.T) {
+	equal := func(a, b shape) bool {
+		return a.name == b.name && slices.Equal(a.points, b.points)
+	}
+	// every value collides so lookups rely on equal
+	hash := func(shape) uint64 { return 7 }
+	square := func(x, y float64) mosaic.Rectangle {
+		return mosaic.NewRectangle(mosaic.NewVector(x, y), 8, 8)
+	}
+
+	_, err := lattice.NewCustomGrid[shape](4, 4, 32, nil, hash)
+	if !errors.Is(err, lattice.ErrInvalidOption) {
+		t.Error(fmt.Errorf("lattice.NewCustomGrid() want: %+v, got: %+v\n", lattice.ErrInvalidOption, err))
+	}
+
+	cg, err := lattice.NewCustomGrid(4, 4, 32, equal, hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := shape{"a", []int{1, 2}}
+	b := shape{"b", []int{3}}
+	cg.Insert(lattice.CustomItem[shape]{Value: a, Bounds: square(16, 16), Multiplier: 1})
+	cg.Insert(lattice.CustomItem[shape]{Value: b, Bounds: square(16, 16), Multiplier: 1})
+	cg.Update(
+		lattice.CustomItem[shape]{Value: shape{"a", []int{1, 2}}, Bounds: square(80, 80), Multiplier: 1},
+		square(16, 16),
+	)
+
+	tests := []struct {
+		name   string
+		bounds mosaic.Rectangle
+		want   []shape
+	}{
+		{name: "left behind", bounds: square(16, 16), want: []shape{b}},
+		{name: "moved by an equal copy", bounds: square(80, 80), want: []shape{a}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := cg.FindNear(tt.bounds)
+			if !slices.EqualFunc(tt.want, got, equal) {
+				t.Error(fmt.Errorf("customGrid.FindNear() want: %+v, got: %+v\n", tt.want, got))
+			}
+		})
+	}
+
+	cg.Delete(shape{"b", []int{3}}, square(16, 16))
+	if cg.Grid().Size() != 1 {
+		t.Error(fmt.Errorf("customGrid.Delete() want: %+v, got: %+v\n", 1, cg.Grid().Size()))
+	}
+	handles := cg.Grid().FindNear(square(80, 80))
+	got, ok := cg.Value(handles[0])
+	if !ok || !equal(a, got) {
+		t.Error(fmt.Errorf("customGrid.Value() want: %+v, got: %+v\n", a, got))
+	}
+}
+
+func Test_custom_grid_Update_rejected(t *testing.T) {
+	equal := func(a, b shape) bool {
+		return a.name == b.name && slices.Equal(a.points, b.points)
+	}
+	hash := func(shape) uint64 { return 7 }
+	square := func(x, y float64) mosaic.Rectangle {
+		return mosaic.NewRectangle(mosaic.NewVector(x, y), 8, 8)
+	}
+
+	cg, err := lattice.NewCustomGrid(4, 4, 32, equal, hash, lattice.WithStrictBounds())
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = cg.Update(
+		lattice.CustomItem[shape]{Value: shape{"a", []int{1}}, Bounds: square(400, 400), Multiplier: 1},
+		square(16, 16),
+	)
+	if !errors.Is(err, lattice.ErrOutOfBounds) {
+		t.Error(fmt.Errorf("customGrid.Update() want: %+v, got: %+v\n", lattice.ErrOutOfBounds, err))
+	}
+	if got, ok := cg.Value(1); ok {
+		t.Error(fmt.Errorf("customGrid.Update() want the handle released, got: %+v\n", got))
+	}
+}
+
+func Test_custom_grid_Delete(t *testing.T) {
+	equal := func(a, b shape) bool {
+		return a.name == b.name && slices.Equal(a.points, b.points)
+	}
+	hash := func(shape) uint64 { return 7 }
+	square := func(x, y float64) mosaic.Rectangle {
+		return mosaic.NewRectangle(mosaic.NewVector(x, y), 8, 8)
+	}
+	a := shape{"a", []int{1}}
+	b := shape{"b", []int{2}}
+
+	tests := []struct {
+		name     string
+		delete   shape
+		bounds   mosaic.Rectangle
+		err      error
+		resolved bool
+	}{
+		{name: "stale bounds", delete: a, bounds: square(80, 80), err: lattice.ErrItemNotFound, resolved: true},
+		{name: "evicted", delete: a, bounds: square(16, 16), err: lattice.ErrItemNotFound, resolved: false},
+		{name: "unknown", delete: shape{"c", nil}, bounds: square(16, 16), err: lattice.ErrItemNotFound, resolved: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cg, err := lattice.NewCustomGrid(4, 4, 32, equal, hash, lattice.WithCellCap(1, lattice.OverflowEvict))
+			if err != nil {
+				t.Fatal(err)
+			}
+			cg.Insert(lattice.CustomItem[shape]{Value: a, Bounds: square(16, 16), Multiplier: 1})
+			if tt.name == "evicted" {
+				cg.Insert(lattice.CustomItem[shape]{Value: b, Bounds: square(16, 16), Multiplier: 2})
+			}
+
+			err = cg.Delete(tt.delete, tt.bounds)
+			if !errors.Is(err, tt.err) {
+				t.Error(fmt.Errorf("customGrid.Delete() want: %+v, got: %+v\n", tt.err, err))
+			}
+			if _, ok := cg.Value(1); ok != tt.resolved {
+				t.Error(fmt.Errorf("customGrid.Value() want resolved: %+v, got: %+v\n", tt.resolved, ok))
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package lattice
+
+import "errors"
+
+type CellDiff[T comparable] struct {
+	X           int
+	Y           int
+	Added       []T
+	Removed     []T
+	WeightDelta float64
+}
+
+var (
+	ErrGridMismatch = errors.New("grids do not share the same dimensions")
+)
+
+func (sg *SpatialGrid[T]) Diff(other *SpatialGrid[T]) ([]CellDiff[T], error) {
+	if sg == other {
+		return []CellDiff[T]{}, nil
+	}
+
+	sg, other, release := rlockPair(sg, other)
+	defer release()
+
+	if !sg.sameShape(other) {
+		return nil, ErrGridMismatch
+	}
+
+	diffs := []CellDiff[T]{}
+	for x := 0; x < sg.SizeX; x++ {
+		for y := 0; y < sg.SizeY; y++ {
+			diff, changed := sg.Nodes[x][y].diff(other.Nodes[x][y])
+			if changed {
+				diffs = append(diffs, diff)
+			}
+		}
+	}
+
+	return diffs, nil
+}
+
+func (sg *SpatialGrid[T]) sameShape(other *SpatialGrid[T]) bool {
+	return sg.SizeX == other.SizeX && sg.SizeY == other.SizeY && sg.ChunkSize == other.ChunkSize &&
+		sg.origin == other.origin
+}
+
+func (sgn spatialGridNode[T]) diff(other spatialGridNode[T]) (CellDiff[T], bool) {
+	diff := CellDiff[T]{X: sgn.x, Y: sgn.y}
+	if sgn.weight != other.weight {
+		diff.WeightDelta = other.weight - sgn.weight
+	}
+
+	counts := map[T]int{}
+	for i := 0; i < len(sgn.Items); i++ {
+		counts[sgn.Items[i].value]--
+	}
+	for i := 0; i < len(other.Items); i++ {
+		counts[other.Items[i].value]++
+	}
+
+	for i := 0; i < len(other.Items); i++ {
+		value := other.Items[i].value
+		if counts[value] > 0 {
+			diff.Added = append(diff.Added, value)
+			counts[value]--
+		}
+	}
+	for i := 0; i < len(sgn.Items); i++ {
+		value := sgn.Items[i].value
+		if counts[value] < 0 {
+			diff.Removed = append(diff.Removed, value)
+			counts[value]++
+		}
+	}
+
+	changed := sgn.weight != other.weight || len(diff.Added) > 0 || len(diff.Removed) > 0
+	return diff, changed
+}
@@ -0,0 +1,115 @@
+package lattice_test
+
+import (
+	"fmt"
+	"runtime"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/mosaic"
+)
+
+func Test_spatial_grid_Diff(t *testing.T) {
+	type params struct {
+		item   int
+		bounds mosaic.Rectangle
+	}
+	tests := []struct {
+		name   string
+		before []params
+		after  []params
+		want   []lattice.CellDiff[int]
+	}{
+		{
+			name: "identical",
+			before: []params{
+				{item: 1, bounds: mosaic.NewRectangle(mosaic.Vector{X: 4, Y: 4}, 2, 2)},
+			},
+			after: []params{
+				{item: 1, bounds: mosaic.NewRectangle(mosaic.Vector{X: 4, Y: 4}, 2, 2)},
+			},
+			want: []lattice.CellDiff[int]{},
+		},
+		{
+			name: "moved and added",
+			before: []params{
+				{item: 1, bounds: mosaic.NewRectangle(mosaic.Vector{X: 4, Y: 4}, 2, 2)},
+				{item: 2, bounds: mosaic.NewRectangle(mosaic.Vector{X: 12, Y: 12}, 2, 2)},
+			},
+			after: []params{
+				{item: 1, bounds: mosaic.NewRectangle(mosaic.Vector{X: 4, Y: 12}, 2, 2)},
+				{item: 2, bounds: mosaic.NewRectangle(mosaic.Vector{X: 12, Y: 12}, 2, 2)},
+				{item: 2, bounds: mosaic.NewRectangle(mosaic.Vector{X: 12, Y: 12}, 2, 2)},
+			},
+			want: []lattice.CellDiff[int]{
+				{X: 0, Y: 0, Removed: []int{1}, WeightDelta: -4},
+				{X: 0, Y: 1, Added: []int{1}, WeightDelta: 4},
+				{X: 1, Y: 1, Added: []int{2}, WeightDelta: 4},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := lattice.NewSpatialGrid[int](4, 4, 8)
+			for _, param := range tt.before {
+				before.Insert(lattice.Item[int]{param.item, param.bounds, 1.0})
+			}
+			after := lattice.NewSpatialGrid[int](4, 4, 8)
+			for _, param := range tt.after {
+				after.Insert(lattice.Item[int]{param.item, param.bounds, 1.0})
+			}
+
+			got, err := before.Diff(after)
+			if err != nil {
+				t.Fatal(fmt.Errorf("spatialGrid.Diff() error: %+v\n", err))
+			}
+
+			equal := slices.EqualFunc(tt.want, got, func(a, b lattice.CellDiff[int]) bool {
+				return a.X == b.X && a.Y == b.Y &&
+					slices.Equal(a.Added, b.Added) &&
+					slices.Equal(a.Removed, b.Removed) &&
+					a.WeightDelta == b.WeightDelta
+			})
+			if !equal {
+				t.Error(fmt.Errorf("spatialGrid.Diff() want: %+v, got: %+v\n", tt.want, got))
+			}
+		})
+	}
+
+	_, err := lattice.NewSpatialGrid[int](4, 4, 8).Diff(lattice.NewSpatialGrid[int](4, 5, 8))
+	if err != lattice.ErrGridMismatch {
+		t.Error(fmt.Errorf("spatialGrid.Diff() want error: %+v, got error: %+v\n", lattice.ErrGridMismatch, err))
+	}
+}
+
+func Test_spatial_grid_Diff_crossed(t *testing.T) {
+	a, err := lattice.NewSpatialGridOpts[int](2, 2, 8, lattice.WithLocking(lattice.LockingExclusive))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := lattice.NewSpatialGridOpts[int](2, 2, 8, lattice.WithLocking(lattice.LockingExclusive))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// each side holding one grid while waiting on the other deadlocks, which
+	// takes more than one thread to show
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+	var wg sync.WaitGroup
+	for _, pair := range [][2]*lattice.SpatialGrid[int]{{a, b}, {b, a}} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 10000; i++ {
+				_, err := pair[0].Diff(pair[1])
+				if err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,107 @@
+package lattice
+
+import "math"
+
+// edtFar stands in for infinity inside the transform, whose parabola
+// intersections need finite values
+const edtFar = 1e20
+
+// ObstacleDistanceField returns, indexed like Nodes, the Euclidean distance
+// in cells from every cell center to the nearest blocked cell center. Blocked
+// cells are 0 and every cell is +Inf when nothing is blocked. A nil blocked
+// uses the grid's own blocked flags.
+func (sg *SpatialGrid[T]) ObstacleDistanceField(blocked func(weight float64) bool) [][]float64 {
+	sg = sg.rlock()
+	defer sg.runlock()
+
+	flat := sg.distanceField(blocked)
+	field := make([][]float64, sg.SizeX)
+	for x := range field {
+		field[x] = make([]float64, sg.SizeY)
+		for y := range field[x] {
+			field[x][y] = flat[sg.index(x, y)]
+		}
+	}
+
+	return field
+}
+
+// distanceField runs the exact two pass transform of Felzenszwalb and
+// Huttenlocher over flat cell indices
+func (sg *SpatialGrid[T]) distanceField(blocked func(weight float64) bool) []float64 {
+	field := make([]float64, sg.SizeX*sg.SizeY)
+	for x := 0; x < sg.SizeX; x++ {
+		for y := 0; y < sg.SizeY; y++ {
+			i := sg.index(x, y)
+			wall := sg.blocked.get(i)
+			if blocked != nil {
+				wall = blocked(sg.Nodes[x][y].weight)
+			}
+			if !wall {
+				field[i] = edtFar
+			}
+		}
+	}
+
+	n := max(sg.SizeX, sg.SizeY)
+	line := make([]float64, n)
+	out := make([]float64, n)
+	hull := make([]int, n)
+	bounds := make([]float64, n+1)
+	for x := 0; x < sg.SizeX; x++ {
+		for y := 0; y < sg.SizeY; y++ {
+			line[y] = field[sg.index(x, y)]
+		}
+		transform(line[:sg.SizeY], out, hull, bounds)
+		for y := 0; y < sg.SizeY; y++ {
+			field[sg.index(x, y)] = out[y]
+		}
+	}
+	for y := 0; y < sg.SizeY; y++ {
+		row := field[y*sg.SizeX : (y+1)*sg.SizeX]
+		copy(line, row)
+		transform(line[:sg.SizeX], out, hull, bounds)
+		copy(row, out[:sg.SizeX])
+	}
+
+	for i, d := range field {
+		if d >= edtFar {
+			field[i] = math.Inf(1)
+			continue
+		}
+		field[i] = math.Sqrt(d)
+	}
+
+	return field
+}
+
+// transform writes the squared distance transform of f into out, using hull
+// and bounds as scratch for the lower envelope of parabolas
+func transform(f, out []float64, hull []int, bounds []float64) {
+	k := 0
+	hull[0] = 0
+	bounds[0], bounds[1] = math.Inf(-1), math.Inf(1)
+	for q := 1; q < len(f); q++ {
+		s := intersect(f, q, hull[k])
+		for s <= bounds[k] {
+			k--
+			s = intersect(f, q, hull[k])
+		}
+		k++
+		hull[k] = q
+		bounds[k], bounds[k+1] = s, math.Inf(1)
+	}
+
+	k = 0
+	for q := range f {
+		for bounds[k+1] < float64(q) {
+			k++
+		}
+		d := float64(q - hull[k])
+		out[q] = d*d + f[hull[k]]
+	}
+}
+
+func intersect(f []float64, q, p int) float64 {
+	return ((f[q] + float64(q*q)) - (f[p] + float64(p*p))) / float64(2*q-2*p)
+}
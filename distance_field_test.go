@@ -0,0 +1,109 @@
+package lattice_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+)
+
+func Test_spatial_grid_ObstacleDistanceField(t *testing.T) {
+	type want struct {
+		field [][]float64
+	}
+	tests := []struct {
+		name    string
+		builder Builder
+		blocked func(weight float64) bool
+		want    want
+	}{
+		{
+			name:    "no obstacles",
+			builder: Builder{x: 2, y: 2, size: 16, layout: "0000"},
+			want:    want{field: [][]float64{{math.Inf(1), math.Inf(1)}, {math.Inf(1), math.Inf(1)}}},
+		},
+		{
+			name: "single wall",
+			builder: Builder{
+				x: 3, y: 3, size: 16,
+				layout: "" +
+					"x00" +
+					"000" +
+					"000",
+			},
+			want: want{field: [][]float64{
+				{0, 1, 2},
+				{1, math.Sqrt2, math.Sqrt(5)},
+				{2, math.Sqrt(5), math.Sqrt(8)},
+			}},
+		},
+		{
+			name: "custom threshold",
+			builder: Builder{
+				x: 3, y: 3, size: 16,
+				layout: "" +
+					"000" +
+					"010" +
+					"000",
+			},
+			blocked: func(weight float64) bool { return weight > 0 },
+			want: want{field: [][]float64{
+				{math.Sqrt2, 1, math.Sqrt2},
+				{1, 0, 1},
+				{math.Sqrt2, 1, math.Sqrt2},
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg := lattice.NewSpatialGrid[int](tt.builder.x, tt.builder.y, float64(tt.builder.size))
+			setup_grid(sg, tt.builder)
+
+			got := sg.ObstacleDistanceField(tt.blocked)
+			for x := range tt.want.field {
+				for y := range tt.want.field[x] {
+					if math.Abs(got[x][y]-tt.want.field[x][y]) > 1e-9 && got[x][y] != tt.want.field[x][y] {
+						t.Error(fmt.Errorf("spatialGrid.ObstacleDistanceField() want: %+v, got: %+v\n", tt.want.field, got))
+						return
+					}
+				}
+			}
+		})
+	}
+}
+
+func Test_spatial_grid_ObstacleDistanceField_bruteForce(t *testing.T) {
+	b := Builder{
+		x: 9, y: 9, size: 16,
+		layout: "" +
+			"000000000" +
+			"0x0000000" +
+			"000000x00" +
+			"000000000" +
+			"00x000000" +
+			"000000000" +
+			"000000000" +
+			"000000000" +
+			"00000000x",
+	}
+	sg := lattice.NewSpatialGrid[int](b.x, b.y, float64(b.size))
+	setup_grid(sg, b)
+
+	got := sg.ObstacleDistanceField(nil)
+	for x := 0; x < b.x; x++ {
+		for y := 0; y < b.y; y++ {
+			want := math.Inf(1)
+			for ox := 0; ox < b.x; ox++ {
+				for oy := 0; oy < b.y; oy++ {
+					if sg.Blocked(ox, oy) {
+						want = min(want, math.Hypot(float64(x-ox), float64(y-oy)))
+					}
+				}
+			}
+			if math.Abs(got[x][y]-want) > 1e-9 {
+				t.Error(fmt.Errorf("spatialGrid.ObstacleDistanceField() at %d, %d want: %+v, got: %+v\n", x, y, want, got[x][y]))
+			}
+		}
+	}
+}
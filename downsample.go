@@ -0,0 +1,64 @@
+package lattice
+
+import "math"
+
+// Downsample returns a grid factor times coarser over the same world area,
+// for strategic reasoning or for planning a route before handing it to
+// RefinePath. Each coarse cell's terrain is aggregate applied to the weights
+// of the fine cells it covers, in index order. A nil aggregate takes the mean
+// weight of the unblocked cells, or infinity when all of them are blocked.
+// Items are not carried over.
+func (sg *SpatialGrid[T]) Downsample(factor int, aggregate func(weights []float64) float64) (*SpatialGrid[T], error) {
+	if factor < 1 {
+		return nil, ErrInvalidOption
+	}
+
+	sg = sg.rlock()
+	defer sg.runlock()
+
+	cfg := sg.config
+	if cfg.locking == lockingSnapshot {
+		cfg.locking = LockingReadOptimized
+	}
+	cfg.origin = sg.origin
+	sizeX, sizeY := (sg.SizeX+factor-1)/factor, (sg.SizeY+factor-1)/factor
+	coarse := newSpatialGrid[T](sizeX, sizeY, sg.ChunkSize*float64(factor), cfg)
+	if aggregate == nil {
+		aggregate = sg.meanOpenWeight
+	}
+
+	coarse.lock()
+	defer coarse.unlock()
+
+	coarse.blockedAt = sg.blockedAt
+	weights := make([]float64, 0, factor*factor)
+	for x := 0; x < sizeX; x++ {
+		for y := 0; y < sizeY; y++ {
+			weights = weights[:0]
+			for fineY := y * factor; fineY < min((y+1)*factor, sg.SizeY); fineY++ {
+				for fineX := x * factor; fineX < min((x+1)*factor, sg.SizeX); fineX++ {
+					weights = append(weights, sg.Nodes[fineX][fineY].weight)
+				}
+			}
+			coarse.setTerrain(x, y, aggregate(weights))
+		}
+	}
+
+	return coarse, nil
+}
+
+func (sg *SpatialGrid[T]) meanOpenWeight(weights []float64) float64 {
+	sum, open := 0.0, 0
+	for _, weight := range weights {
+		if weight >= sg.blockedAt {
+			continue
+		}
+		sum += weight
+		open++
+	}
+	if open == 0 {
+		return math.Inf(1)
+	}
+
+	return sum / float64(open)
+}
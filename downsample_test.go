@@ -0,0 +1,99 @@
+package lattice_test
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+)
+
+func Test_spatial_grid_Downsample(t *testing.T) {
+	tests := []struct {
+		name      string
+		builder   Builder
+		factor    int
+		aggregate func(weights []float64) float64
+		want      [][]float64
+		err       error
+	}{
+		{
+			name: "mean of open cells",
+			builder: Builder{x: 4, y: 4, size: 8, layout: "" +
+				"1100" +
+				"0x00" +
+				"xxxx" +
+				"xx01"},
+			factor: 2,
+			want:   [][]float64{{128.0 / 3, math.Inf(1)}, {0, 32}},
+		},
+		{
+			name: "uneven blocks",
+			builder: Builder{x: 3, y: 3, size: 8, layout: "" +
+				"001" +
+				"000" +
+				"111"},
+			factor: 2,
+			want:   [][]float64{{0, 64}, {32, 64}},
+		},
+		{
+			name: "worst cell",
+			builder: Builder{x: 4, y: 4, size: 8, layout: "" +
+				"1100" +
+				"0x00" +
+				"xxxx" +
+				"xx01"},
+			factor:    2,
+			aggregate: func(weights []float64) float64 { return slices.Max(weights) },
+			want:      [][]float64{{math.Inf(1), math.Inf(1)}, {0, math.Inf(1)}},
+		},
+		{
+			name: "block order",
+			builder: Builder{x: 2, y: 2, size: 8, layout: "" +
+				"10" +
+				"01"},
+			factor: 2,
+			aggregate: func(weights []float64) float64 {
+				return weights[0] + 2*weights[1] + 4*weights[2] + 8*weights[3]
+			},
+			want: [][]float64{{64 + 8*64}},
+		},
+		{
+			name:    "invalid factor",
+			builder: Builder{x: 2, y: 2, size: 8, layout: "0000"},
+			factor:  0,
+			err:     lattice.ErrInvalidOption,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg := lattice.NewSpatialGrid[int](tt.builder.x, tt.builder.y, float64(tt.builder.size))
+			setup_grid(sg, tt.builder)
+
+			coarse, err := sg.Downsample(tt.factor, tt.aggregate)
+			if !errors.Is(err, tt.err) {
+				t.Fatal(fmt.Errorf("spatialGrid.Downsample() want: %+v, got: %+v\n", tt.err, err))
+			}
+			if err != nil {
+				return
+			}
+
+			if coarse.ChunkSize != sg.ChunkSize*float64(tt.factor) {
+				t.Error(fmt.Errorf("spatialGrid.Downsample() want: %+v, got: %+v\n", sg.ChunkSize*float64(tt.factor), coarse.ChunkSize))
+			}
+			got := make([][]float64, coarse.SizeX)
+			for x := range got {
+				got[x] = make([]float64, coarse.SizeY)
+				for y := range got[x] {
+					got[x][y] = coarse.GetLocationWeight(x, y)
+				}
+			}
+			if !reflect.DeepEqual(tt.want, got) {
+				t.Error(fmt.Errorf("spatialGrid.Downsample() want: %+v, got: %+v\n", tt.want, got))
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package lattice
+
+import "github.com/maladroitthief/mosaic"
+
+// DropWhere removes every item, spilled and pinned ones included, that match
+// reports true for and returns how many were removed. match runs under the
+// write lock and must not call back into the grid.
+func (sg *SpatialGrid[T]) DropWhere(match func(val T, bounds mosaic.Rectangle) bool) int {
+	sg.lock()
+	defer sg.unlock()
+
+	removed := 0
+	for x := range sg.Nodes {
+		for y := range sg.Nodes[x] {
+			node := sg.Nodes[x][y]
+			count := 0
+			// walking down keeps every item swapped into i already visited
+			for i := len(node.Items) - 1; i >= 0; i-- {
+				item := node.Items[i]
+				if !match(item.value, item.bounds) {
+					continue
+				}
+				node = sg.record(node.removeAt(i), CellDeleted, item.value, item.bounds)
+				delete(sg.expiries, item.value)
+				sg.track(item.value, item.bounds, false)
+				count++
+			}
+			if count == 0 {
+				continue
+			}
+
+			node.weight = node.itemWeights()
+			sg.Nodes[x][y] = node
+			sg.updateBlocked(x, y)
+			removed += count
+		}
+	}
+
+	kept := sg.overflow[:0]
+	for _, item := range sg.overflow {
+		if !match(item.Value, item.Bounds) {
+			kept = append(kept, item)
+			continue
+		}
+		delete(sg.expiries, item.Value)
+		sg.track(item.Value, item.Bounds, false)
+		removed++
+	}
+	clear(sg.overflow[len(kept):])
+	sg.overflow = kept
+	sg.itemCount -= removed
+
+	return removed
+}
@@ -0,0 +1,123 @@
+package lattice_test
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/mosaic"
+)
+
+func Test_spatial_grid_DropWhere(t *testing.T) {
+	type params struct {
+		items  []lattice.Item[int]
+		static []lattice.Item[int]
+		pinned []lattice.Item[int]
+		opts   []lattice.Option
+	}
+	type want struct {
+		removed int
+		items   []int
+		weight  float64
+		pinned  int
+	}
+	even := func(v int, _ mosaic.Rectangle) bool {
+		return v%2 == 0
+	}
+	tests := []struct {
+		name   string
+		params params
+		want   want
+	}{
+		{
+			name: "empty",
+			want: want{items: []int{}},
+		},
+		{
+			name: "every partition",
+			params: params{
+				items: []lattice.Item[int]{
+					{1, mosaic.NewRectangle(mosaic.NewVector(16, 16), 8, 8), 1},
+					{2, mosaic.NewRectangle(mosaic.NewVector(16, 16), 8, 8), 1},
+					{4, mosaic.NewRectangle(mosaic.NewVector(16, 16), 8, 8), 1},
+				},
+				static: []lattice.Item[int]{
+					{6, mosaic.NewRectangle(mosaic.NewVector(16, 16), 4, 4), 1},
+					{7, mosaic.NewRectangle(mosaic.NewVector(16, 16), 4, 4), 1},
+				},
+				pinned: []lattice.Item[int]{
+					{8, mosaic.NewRectangle(mosaic.NewVector(16, 16), 2, 2), 1},
+					{9, mosaic.NewRectangle(mosaic.NewVector(16, 16), 2, 2), 1},
+				},
+			},
+			want: want{removed: 4, items: []int{1, 7, 9}, weight: 64 + 16 + 4, pinned: 1},
+		},
+		{
+			name: "blocking item",
+			params: params{
+				items: []lattice.Item[int]{
+					{1, mosaic.NewRectangle(mosaic.NewVector(16, 16), 8, 8), 1},
+					{2, mosaic.NewRectangle(mosaic.NewVector(16, 16), 8, 8), math.Inf(1)},
+				},
+			},
+			want: want{removed: 1, items: []int{1}, weight: 64},
+		},
+		{
+			name: "spilled items",
+			params: params{
+				items: []lattice.Item[int]{
+					{1, mosaic.NewRectangle(mosaic.NewVector(16, 16), 8, 8), 1},
+					{2, mosaic.NewRectangle(mosaic.NewVector(16, 16), 8, 8), 1},
+					{3, mosaic.NewRectangle(mosaic.NewVector(16, 16), 8, 8), 1},
+				},
+				opts: []lattice.Option{lattice.WithCellCap(1, lattice.OverflowSpill)},
+			},
+			want: want{removed: 1, items: []int{1, 3}, weight: 64},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg, err := lattice.NewSpatialGridOpts[int](4, 4, 32, tt.params.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, item := range tt.params.pinned {
+				sg.InsertPinned(item)
+			}
+			for _, item := range tt.params.static {
+				sg.InsertStatic(item)
+			}
+			for _, item := range tt.params.items {
+				sg.Insert(item)
+			}
+
+			removed := sg.DropWhere(even)
+			if removed != tt.want.removed {
+				t.Error(fmt.Errorf("spatialGrid.DropWhere() want: %+v, got: %+v\n", tt.want.removed, removed))
+			}
+			got := sg.CountBy(func(v int) string { return fmt.Sprint(v) })
+			items := []int{}
+			for _, v := range []int{1, 2, 3, 4, 5, 6, 7, 8, 9} {
+				if got[fmt.Sprint(v)] > 0 {
+					items = append(items, v)
+				}
+			}
+			if !slices.Equal(tt.want.items, items) {
+				t.Error(fmt.Errorf("spatialGrid.DropWhere() want: %+v, got: %+v\n", tt.want.items, items))
+			}
+			if sg.Size() != len(tt.want.items) {
+				t.Error(fmt.Errorf("spatialGrid.Size() want: %+v, got: %+v\n", len(tt.want.items), sg.Size()))
+			}
+			if weight := sg.GetLocationWeight(0, 0); weight != tt.want.weight {
+				t.Error(fmt.Errorf("spatialGrid.GetLocationWeight() want: %+v, got: %+v\n", tt.want.weight, weight))
+			}
+
+			sg.Drop()
+			if sg.Size() != tt.want.pinned {
+				t.Error(fmt.Errorf("spatialGrid.Drop() want: %+v, got: %+v\n", tt.want.pinned, sg.Size()))
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package ecs
+
+import (
+	"errors"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/mosaic"
+)
+
+type (
+	Entity[T comparable] struct {
+		ID         T
+		Bounds     mosaic.Rectangle
+		Multiplier float64
+	}
+
+	// Entities is a push iterator, yield returns false to stop early
+	Entities[T comparable] func(yield func(Entity[T]) bool)
+
+	SyncStats struct {
+		Inserted int
+		Updated  int
+		Deleted  int
+	}
+
+	// System owns the contents of its grid, reconciling them against the
+	// entity set it is handed each Sync.
+	System[T comparable] struct {
+		grid    *lattice.SpatialGrid[T]
+		known   map[T]entry
+		seen    map[T]struct{}
+		retuned []lattice.BoundsUpdate[T]
+	}
+
+	entry struct {
+		bounds     mosaic.Rectangle
+		multiplier float64
+	}
+)
+
+func NewSystem[T comparable](grid *lattice.SpatialGrid[T]) *System[T] {
+	return &System[T]{
+		grid:  grid,
+		known: map[T]entry{},
+		seen:  map[T]struct{}{},
+	}
+}
+
+func FromSlice[T comparable](entities []Entity[T]) Entities[T] {
+	return func(yield func(Entity[T]) bool) {
+		for _, entity := range entities {
+			if !yield(entity) {
+				return
+			}
+		}
+	}
+}
+
+func (s *System[T]) Grid() *lattice.SpatialGrid[T] {
+	return s.grid
+}
+
+// Sync returns the last error the grid reported, entities the grid rejected
+// are retried on the next Sync. Multiplier changes stay in their cell, so
+// they are applied together in one UpdateBatch after the moves.
+func (s *System[T]) Sync(entities Entities[T]) (SyncStats, error) {
+	stats := SyncStats{}
+	clear(s.seen)
+	s.retuned = s.retuned[:0]
+
+	var err error
+
+	entities(func(entity Entity[T]) bool {
+		s.seen[entity.ID] = struct{}{}
+		item := lattice.Item[T]{
+			Value:      entity.ID,
+			Bounds:     entity.Bounds,
+			Multiplier: entity.Multiplier,
+		}
+
+		old, ok := s.known[entity.ID]
+		switch {
+		case !ok:
+			insertErr := s.grid.Insert(item)
+			if insertErr != nil {
+				err = insertErr
+				return true
+			}
+			stats.Inserted++
+		case old.bounds != entity.Bounds:
+			updateErr := s.grid.Update(item, old.bounds)
+			if updateErr != nil {
+				err = updateErr
+				return true
+			}
+			stats.Updated++
+		case old.multiplier != entity.Multiplier:
+			s.retuned = append(s.retuned, lattice.BoundsUpdate[T]{
+				Value:      entity.ID,
+				OldBounds:  old.bounds,
+				NewBounds:  entity.Bounds,
+				Multiplier: entity.Multiplier,
+			})
+			return true
+		default:
+			return true
+		}
+
+		s.known[entity.ID] = entry{bounds: entity.Bounds, multiplier: entity.Multiplier}
+		return true
+	})
+
+	// rewriting an item in place is safe to repeat, so a failed batch is
+	// simply retried in full next Sync
+	if len(s.retuned) > 0 {
+		batchErr := s.grid.UpdateBatch(s.retuned)
+		if batchErr != nil {
+			err = batchErr
+		} else {
+			for _, update := range s.retuned {
+				s.known[update.Value] = entry{bounds: update.NewBounds, multiplier: update.Multiplier}
+			}
+			stats.Updated += len(s.retuned)
+		}
+	}
+
+	for id, old := range s.known {
+		if _, ok := s.seen[id]; ok {
+			continue
+		}
+		// an entity evicted from a full cell is already gone
+		deleteErr := s.grid.Delete(id, old.bounds)
+		if deleteErr != nil && !errors.Is(deleteErr, lattice.ErrItemNotFound) {
+			err = deleteErr
+			continue
+		}
+		delete(s.known, id)
+		stats.Deleted++
+	}
+
+	return stats, err
+}
@@ -0,0 +1,94 @@
+package ecs_test
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/lattice/ecs"
+	"github.com/maladroitthief/mosaic"
+)
+
+func Test_system_Sync(t *testing.T) {
+	at := func(id int, x, y float64) ecs.Entity[int] {
+		return ecs.Entity[int]{ID: id, Bounds: mosaic.NewRectangle(mosaic.Vector{X: x, Y: y}, 2, 2), Multiplier: 1}
+	}
+	tests := []struct {
+		name   string
+		frames [][]ecs.Entity[int]
+		want   ecs.SyncStats
+		items  []int
+	}{
+		{
+			name:   "first frame inserts",
+			frames: [][]ecs.Entity[int]{{at(1, 4, 4), at(2, 12, 4)}},
+			want:   ecs.SyncStats{Inserted: 2},
+			items:  []int{1, 2},
+		},
+		{
+			name: "moved and despawned",
+			frames: [][]ecs.Entity[int]{
+				{at(1, 4, 4), at(2, 12, 4), at(3, 20, 4)},
+				{at(1, 28, 28), at(2, 12, 4), at(4, 4, 4)},
+			},
+			want:  ecs.SyncStats{Inserted: 1, Updated: 1, Deleted: 1},
+			items: []int{1, 2, 4},
+		},
+		{
+			name: "multiplier only",
+			frames: [][]ecs.Entity[int]{
+				{at(1, 4, 4)},
+				{{ID: 1, Bounds: at(1, 4, 4).Bounds, Multiplier: 3}},
+			},
+			want:  ecs.SyncStats{Updated: 1},
+			items: []int{1},
+		},
+		{
+			name: "multipliers beside a move",
+			frames: [][]ecs.Entity[int]{
+				{at(1, 4, 4), at(2, 12, 4), at(3, 20, 4)},
+				{
+					{ID: 1, Bounds: at(1, 4, 4).Bounds, Multiplier: 3},
+					at(2, 12, 28),
+					{ID: 3, Bounds: at(3, 20, 4).Bounds, Multiplier: 0.5},
+				},
+			},
+			want:  ecs.SyncStats{Updated: 3},
+			items: []int{1, 2, 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg := lattice.NewSpatialGrid[int](4, 4, 8)
+			system := ecs.NewSystem(sg)
+
+			var got ecs.SyncStats
+			for _, frame := range tt.frames {
+				stats, err := system.Sync(ecs.FromSlice(frame))
+				if err != nil {
+					t.Fatal(err)
+				}
+				got = stats
+			}
+			if got != tt.want {
+				t.Error(fmt.Errorf("system.Sync() want: %+v, got: %+v\n", tt.want, got))
+			}
+
+			items := sg.FindNear(mosaic.NewRectangle(mosaic.Vector{X: 16, Y: 16}, 32, 32))
+			slices.Sort(items)
+			if !slices.Equal(tt.items, items) || sg.Size() != len(tt.items) {
+				t.Error(fmt.Errorf("system.Sync() want items: %+v, got: %+v\n", tt.items, items))
+			}
+
+			last := tt.frames[len(tt.frames)-1]
+			for _, entity := range last {
+				x, y := sg.Location(entity.Bounds.Position.X, entity.Bounds.Position.Y)
+				want := entity.Bounds.Area() * entity.Multiplier
+				if weight, _ := sg.ItemWeightAt(entity.ID, x, y); weight != want {
+					t.Error(fmt.Errorf("system.Sync() want weight: %+v, got: %+v\n", want, weight))
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package lattice
+
+import "maps"
+
+type (
+	// TraversalProfile describes the searching agent to edge rules, e.g. which
+	// keys it carries or whether it can climb
+	TraversalProfile struct {
+		Flags uint64
+	}
+
+	// EdgeRule reports whether an agent with profile may take the edge
+	EdgeRule func(profile TraversalProfile) bool
+
+	edgeKey struct {
+		from int
+		dx   int
+		dy   int
+	}
+)
+
+var (
+	Impassable EdgeRule = func(TraversalProfile) bool { return false }
+)
+
+func RequireFlags(flags uint64) EdgeRule {
+	return func(profile TraversalProfile) bool {
+		return profile.Flags&flags == flags
+	}
+}
+
+// SetEdgeRule gates the move from x, y by direction. A one-way drop is an
+// Impassable rule on the way back up; a nil rule removes the gate.
+func (sg *SpatialGrid[T]) SetEdgeRule(x, y int, direction Offset, rule EdgeRule) error {
+	sg.lock()
+	defer sg.unlock()
+
+	if !sg.inBounds(x, y) {
+		return ErrOutOfBounds
+	}
+
+	// landmark tables follow edge rules as well as weights
+	sg.shape++
+	key := edgeKey{from: sg.index(x, y), dx: direction.X, dy: direction.Y}
+	if rule == nil {
+		delete(sg.edgeRules, key)
+		return nil
+	}
+
+	if sg.edgeRules == nil {
+		sg.edgeRules = map[edgeKey]EdgeRule{}
+	}
+	sg.edgeRules[key] = rule
+
+	return nil
+}
+
+func (sg *SpatialGrid[T]) edgeAllowed(from, dx, dy int, profile TraversalProfile) bool {
+	if len(sg.edgeRules) == 0 {
+		return true
+	}
+
+	rule, ok := sg.edgeRules[edgeKey{from: from, dx: dx, dy: dy}]
+	return !ok || rule(profile)
+}
+
+func (sg *SpatialGrid[T]) cloneEdgeRules() map[edgeKey]EdgeRule {
+	return maps.Clone(sg.edgeRules)
+}
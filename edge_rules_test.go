@@ -0,0 +1,81 @@
+package lattice_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/mosaic"
+)
+
+func Test_spatial_grid_SetEdgeRule(t *testing.T) {
+	const key = 1 << 2
+	left, right := mosaic.Vector{X: 4, Y: 4}, mosaic.Vector{X: 20, Y: 4}
+	type rule struct {
+		x, y      int
+		direction lattice.Offset
+		rule      lattice.EdgeRule
+	}
+	tests := []struct {
+		name    string
+		rules   []rule
+		start   mosaic.Vector
+		end     mosaic.Vector
+		profile lattice.TraversalProfile
+		want    error
+	}{
+		{
+			name:  "locked door",
+			rules: []rule{{x: 1, y: 0, direction: lattice.Offset{X: 1}, rule: lattice.RequireFlags(key)}},
+			start: left,
+			end:   right,
+			want:  lattice.ErrPathNotFound,
+		},
+		{
+			name:    "carrying the key",
+			rules:   []rule{{x: 1, y: 0, direction: lattice.Offset{X: 1}, rule: lattice.RequireFlags(key)}},
+			start:   left,
+			end:     right,
+			profile: lattice.TraversalProfile{Flags: key | 1},
+		},
+		{
+			name:  "drop down",
+			rules: []rule{{x: 1, y: 0, direction: lattice.Offset{X: -1}, rule: lattice.Impassable}},
+			start: left,
+			end:   right,
+		},
+		{
+			name:  "climb back up",
+			rules: []rule{{x: 1, y: 0, direction: lattice.Offset{X: -1}, rule: lattice.Impassable}},
+			start: right,
+			end:   left,
+			want:  lattice.ErrPathNotFound,
+		},
+		{
+			name: "rule removed",
+			rules: []rule{
+				{x: 1, y: 0, direction: lattice.Offset{X: -1}, rule: lattice.Impassable},
+				{x: 1, y: 0, direction: lattice.Offset{X: -1}},
+			},
+			start: right,
+			end:   left,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg := lattice.NewSpatialGrid[int](3, 1, 8)
+			for _, r := range tt.rules {
+				err := sg.SetEdgeRule(r.x, r.y, r.direction, r.rule)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			_, err := sg.FindPath(tt.start, tt.end, lattice.PathOptions{Profile: tt.profile})
+			if !errors.Is(err, tt.want) {
+				t.Error(fmt.Errorf("spatialGrid.FindPath() want error: %+v, got error: %+v\n", tt.want, err))
+			}
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package lattice
+
+import (
+	"math"
+	"reflect"
+	"unsafe"
+)
+
+func (sg *SpatialGrid[T]) Equal(other *SpatialGrid[T]) bool {
+	if sg == other {
+		return true
+	}
+
+	sg, other, release := rlockPair(sg, other)
+	defer release()
+
+	if !sg.sameShape(other) || sg.itemCount != other.itemCount {
+		return false
+	}
+
+	for x := 0; x < sg.SizeX; x++ {
+		for y := 0; y < sg.SizeY; y++ {
+			if !sg.Nodes[x][y].equal(other.Nodes[x][y]) {
+				return false
+			}
+		}
+	}
+
+	// spilled items sit in no cell, but are still part of the grid
+	if len(sg.overflow) != len(other.overflow) {
+		return false
+	}
+	counts := map[spilled[T]]int{}
+	for _, item := range sg.overflow {
+		counts[item]++
+	}
+	for _, item := range other.overflow {
+		counts[item]--
+		if counts[item] < 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Hash digests what Equal compares so peers can check each tick that they
+// agree. Values are only hashed when T is of integer, float, bool or string
+// kind, named types included; any other T, such as pointers or structs, is
+// left out of the digest and needs HashWith instead.
+func (sg *SpatialGrid[T]) Hash() uint64 {
+	return sg.HashWith(scalarHash[T])
+}
+
+// HashWith is Hash with every value digested by value, which must depend only
+// on what the value means and never on an address for peers to agree
+func (sg *SpatialGrid[T]) HashWith(value func(T) uint64) uint64 {
+	sg = sg.rlock()
+	defer sg.runlock()
+
+	hash := mix64(uint64(sg.SizeX)<<32 | uint64(uint32(sg.SizeY)))
+	hash = mix64(hash ^ math.Float64bits(sg.ChunkSize))
+	for x := 0; x < sg.SizeX; x++ {
+		for y := 0; y < sg.SizeY; y++ {
+			hash = mix64(hash ^ sg.Nodes[x][y].hash(value))
+		}
+	}
+	spills := uint64(0)
+	for _, item := range sg.overflow {
+		stored := newSpatialGridNodeItem(item.Value, item.Bounds, 0, item.Multiplier)
+		spills += mix64(stored.hash(value) ^ uint64(item.partition))
+	}
+
+	return mix64(hash ^ spills)
+}
+
+func (sgn spatialGridNode[T]) equal(other spatialGridNode[T]) bool {
+	if len(sgn.Items) != len(other.Items) || sgn.terrain != other.terrain || sgn.paint != other.paint ||
+		sgn.restored != other.restored {
+		return false
+	}
+
+	counts := map[spatialGridNodeItem[T]]int{}
+	for i := 0; i < len(sgn.Items); i++ {
+		counts[sgn.Items[i]]++
+	}
+	for i := 0; i < len(other.Items); i++ {
+		counts[other.Items[i]]--
+		if counts[other.Items[i]] < 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// weights are derived from the items, terrain, paint and restored weight and
+// summed in insertion order, so only those are compared and hashed
+func (sgn spatialGridNode[T]) hash(value func(T) uint64) uint64 {
+	sum := mix64(uint64(sgn.x)<<32 | uint64(uint32(sgn.y)))
+	if sgn.terrain != 0 {
+		sum = mix64(sum ^ math.Float64bits(sgn.terrain))
+	}
+	if sgn.paint != 0 {
+		sum = mix64(sum ^ math.Float64bits(sgn.paint)<<1)
+	}
+	if sgn.restored != 0 {
+		sum = mix64(sum ^ math.Float64bits(sgn.restored)<<2)
+	}
+	for i := 0; i < len(sgn.Items); i++ {
+		sum += sgn.Items[i].hash(value)
+	}
+
+	return sum
+}
+
+func (sgni spatialGridNodeItem[T]) hash(value func(T) uint64) uint64 {
+	h := mix64(value(sgni.value))
+	for _, f := range [...]float64{
+		sgni.bounds.Position.X,
+		sgni.bounds.Position.Y,
+		sgni.bounds.Width,
+		sgni.bounds.Height,
+		sgni.multiplier,
+	} {
+		h = mix64(h ^ math.Float64bits(f))
+	}
+
+	return h
+}
+
+// scalarHash hashes value by the kind of T, so named types such as
+// type ID int hash like the built-in type under them. Kinds whose bits do
+// not mean the same on every peer hash to 0.
+func scalarHash[T comparable](value T) uint64 {
+	p := unsafe.Pointer(&value)
+	switch reflect.TypeOf((*T)(nil)).Elem().Kind() {
+	case reflect.Int:
+		return uint64(*(*int)(p))
+	case reflect.Int8:
+		return uint64(*(*int8)(p))
+	case reflect.Int16:
+		return uint64(*(*int16)(p))
+	case reflect.Int32:
+		return uint64(*(*int32)(p))
+	case reflect.Int64:
+		return uint64(*(*int64)(p))
+	case reflect.Uint:
+		return uint64(*(*uint)(p))
+	case reflect.Uint8:
+		return uint64(*(*uint8)(p))
+	case reflect.Uint16:
+		return uint64(*(*uint16)(p))
+	case reflect.Uint32:
+		return uint64(*(*uint32)(p))
+	case reflect.Uint64:
+		return *(*uint64)(p)
+	case reflect.Float32:
+		return math.Float64bits(float64(*(*float32)(p)))
+	case reflect.Float64:
+		return math.Float64bits(*(*float64)(p))
+	case reflect.Bool:
+		if *(*bool)(p) {
+			return 1
+		}
+		return 0
+	case reflect.String:
+		return fnv1a(*(*string)(p))
+	default:
+		return 0
+	}
+}
+
+func fnv1a(s string) uint64 {
+	h := uint64(14695981039346656037)
+	for i := 0; i < len(s); i++ {
+		h ^= uint64(s[i])
+		h *= 1099511628211
+	}
+
+	return h
+}
+
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+
+	return x
+}
@@ -0,0 +1,206 @@
+package lattice_test
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/mosaic"
+)
+
+func Test_spatial_grid_Equal(t *testing.T) {
+	type params struct {
+		item   int
+		bounds mosaic.Rectangle
+	}
+	tests := []struct {
+		name  string
+		a     []params
+		b     []params
+		equal bool
+	}{
+		{
+			name: "insertion order does not matter",
+			a: []params{
+				{item: 1, bounds: mosaic.NewRectangle(mosaic.Vector{X: 4, Y: 4}, 2, 2)},
+				{item: 2, bounds: mosaic.NewRectangle(mosaic.Vector{X: 5, Y: 5}, 2, 2)},
+				{item: 3, bounds: mosaic.NewRectangle(mosaic.Vector{X: 20, Y: 20}, 2, 2)},
+			},
+			b: []params{
+				{item: 3, bounds: mosaic.NewRectangle(mosaic.Vector{X: 20, Y: 20}, 2, 2)},
+				{item: 2, bounds: mosaic.NewRectangle(mosaic.Vector{X: 5, Y: 5}, 2, 2)},
+				{item: 1, bounds: mosaic.NewRectangle(mosaic.Vector{X: 4, Y: 4}, 2, 2)},
+			},
+			equal: true,
+		},
+		{
+			name: "bounds differ within a cell",
+			a: []params{
+				{item: 1, bounds: mosaic.NewRectangle(mosaic.Vector{X: 4, Y: 4}, 2, 2)},
+			},
+			b: []params{
+				{item: 1, bounds: mosaic.NewRectangle(mosaic.Vector{X: 5, Y: 4}, 2, 2)},
+			},
+			equal: false,
+		},
+		{
+			name: "values swapped between cells",
+			a: []params{
+				{item: 1, bounds: mosaic.NewRectangle(mosaic.Vector{X: 4, Y: 4}, 2, 2)},
+				{item: 2, bounds: mosaic.NewRectangle(mosaic.Vector{X: 20, Y: 20}, 2, 2)},
+			},
+			b: []params{
+				{item: 2, bounds: mosaic.NewRectangle(mosaic.Vector{X: 4, Y: 4}, 2, 2)},
+				{item: 1, bounds: mosaic.NewRectangle(mosaic.Vector{X: 20, Y: 20}, 2, 2)},
+			},
+			equal: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := lattice.NewSpatialGrid[int](4, 4, 8)
+			for _, param := range tt.a {
+				a.Insert(lattice.Item[int]{param.item, param.bounds, 1.0})
+			}
+			b := lattice.NewSpatialGrid[int](4, 4, 8)
+			for _, param := range tt.b {
+				b.Insert(lattice.Item[int]{param.item, param.bounds, 1.0})
+			}
+
+			got := a.Equal(b)
+			if got != tt.equal {
+				t.Error(fmt.Errorf("spatialGrid.Equal() want: %+v, got: %+v\n", tt.equal, got))
+			}
+
+			got = a.Hash() == b.Hash()
+			if got != tt.equal {
+				t.Error(fmt.Errorf("spatialGrid.Hash() equal want: %+v, got: %+v\n", tt.equal, got))
+			}
+		})
+	}
+}
+
+func Test_spatial_grid_HashWith(t *testing.T) {
+	type unit struct {
+		id int
+	}
+	byID := func(u *unit) uint64 { return uint64(u.id) }
+	tests := []struct {
+		name  string
+		a     []int
+		b     []int
+		equal bool
+	}{
+		{
+			name:  "same ids behind different pointers",
+			a:     []int{1, 2},
+			b:     []int{1, 2},
+			equal: true,
+		},
+		{
+			name:  "ids differ",
+			a:     []int{1, 2},
+			b:     []int{1, 3},
+			equal: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			build := func(ids []int) *lattice.SpatialGrid[*unit] {
+				sg := lattice.NewSpatialGrid[*unit](4, 4, 8)
+				for i, id := range ids {
+					bounds := mosaic.NewRectangle(mosaic.Vector{X: 4 + 8*float64(i), Y: 4}, 2, 2)
+					sg.Insert(lattice.Item[*unit]{&unit{id: id}, bounds, 1.0})
+				}
+				return sg
+			}
+			a, b := build(tt.a), build(tt.b)
+
+			got := a.HashWith(byID) == b.HashWith(byID)
+			if got != tt.equal {
+				t.Error(fmt.Errorf("spatialGrid.HashWith() equal want: %+v, got: %+v\n", tt.equal, got))
+			}
+		})
+	}
+}
+
+func Test_spatial_grid_Hash_allocations(t *testing.T) {
+	sg := lattice.NewSpatialGrid[int](4, 4, 8)
+	for i := 0; i < 16; i++ {
+		sg.Insert(lattice.Item[int]{i, mosaic.NewRectangle(mosaic.Vector{X: float64(2 * i), Y: 4}, 2, 2), 1.0})
+	}
+
+	allocs := testing.AllocsPerRun(10, func() { sg.Hash() })
+	if allocs != 0 {
+		t.Error(fmt.Errorf("spatialGrid.Hash() want: %+v allocations, got: %+v\n", 0, allocs))
+	}
+}
+
+func Test_spatial_grid_Hash_named(t *testing.T) {
+	type id int
+	build := func(first, second id) *lattice.SpatialGrid[id] {
+		sg := lattice.NewSpatialGrid[id](4, 4, 8)
+		sg.Insert(lattice.Item[id]{first, mosaic.NewRectangle(mosaic.Vector{X: 4, Y: 4}, 2, 2), 1.0})
+		sg.Insert(lattice.Item[id]{second, mosaic.NewRectangle(mosaic.Vector{X: 20, Y: 20}, 2, 2), 1.0})
+		return sg
+	}
+
+	if build(1, 2).Hash() == build(2, 1).Hash() {
+		t.Error(fmt.Errorf("spatialGrid.Hash() want swapped named values to differ\n"))
+	}
+	if build(1, 2).Hash() != build(1, 2).Hash() {
+		t.Error(fmt.Errorf("spatialGrid.Hash() want equal named values to agree\n"))
+	}
+}
+
+func Test_spatial_grid_Equal_overflow(t *testing.T) {
+	cell := mosaic.NewRectangle(mosaic.Vector{X: 4, Y: 4}, 2, 2)
+	build := func(spilled int) *lattice.SpatialGrid[int] {
+		sg, err := lattice.NewSpatialGridOpts[int](4, 4, 8, lattice.WithCellCap(1, lattice.OverflowSpill))
+		if err != nil {
+			t.Fatal(err)
+		}
+		sg.Insert(lattice.Item[int]{1, cell, 1.0})
+		sg.Insert(lattice.Item[int]{spilled, cell, 1.0})
+		return sg
+	}
+	a, b := build(2), build(3)
+
+	if a.Equal(b) {
+		t.Error(fmt.Errorf("spatialGrid.Equal() want: %+v, got: %+v\n", false, true))
+	}
+	if a.Hash() == b.Hash() {
+		t.Error(fmt.Errorf("spatialGrid.Hash() want different spilled items to differ\n"))
+	}
+	if !a.Equal(build(2)) || a.Hash() != build(2).Hash() {
+		t.Error(fmt.Errorf("spatialGrid.Equal() want the same spilled items to agree\n"))
+	}
+}
+
+func Test_spatial_grid_Equal_crossed(t *testing.T) {
+	a, err := lattice.NewSpatialGridOpts[int](2, 2, 8, lattice.WithLocking(lattice.LockingExclusive))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := lattice.NewSpatialGridOpts[int](2, 2, 8, lattice.WithLocking(lattice.LockingExclusive))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// each side holding one grid while waiting on the other deadlocks, which
+	// takes more than one thread to show
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+	var wg sync.WaitGroup
+	for _, pair := range [][2]*lattice.SpatialGrid[int]{{a, b}, {b, a}} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 10000; i++ {
+				pair[0].Equal(pair[1])
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,161 @@
+package lattice
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// Formats understood by ExportGraph
+const (
+	GraphDOT  = "dot"
+	GraphJSON = "json"
+)
+
+type (
+	// graphDocument is the JSON adjacency format. Node ids are CellIndex
+	// values; weights and costs that are not finite are null.
+	graphDocument struct {
+		Width     int         `json:"width"`
+		Height    int         `json:"height"`
+		ChunkSize float64     `json:"chunkSize"`
+		Nodes     []graphNode `json:"nodes"`
+		Edges     []graphEdge `json:"edges"`
+	}
+
+	graphNode struct {
+		ID      int      `json:"id"`
+		X       int      `json:"x"`
+		Y       int      `json:"y"`
+		Weight  *float64 `json:"weight"`
+		Blocked bool     `json:"blocked"`
+		Label   string   `json:"label,omitempty"`
+	}
+
+	graphEdge struct {
+		From   int      `json:"from"`
+		To     int      `json:"to"`
+		Cost   *float64 `json:"cost"`
+		Portal bool     `json:"portal,omitempty"`
+		Gated  bool     `json:"gated,omitempty"`
+	}
+)
+
+var (
+	ErrUnknownGraphFormat = errors.New("unknown graph export format")
+)
+
+// ExportGraph writes the navigation graph searches walk, in GraphDOT or
+// GraphJSON. Every cell is a node, and every neighbor step or portal into an
+// unblocked cell is an edge costing what a search would pay to take it.
+// Edge rules are functions, so gated edges are flagged rather than
+// evaluated.
+func (sg *SpatialGrid[T]) ExportGraph(w io.Writer, format string) error {
+	if format != GraphDOT && format != GraphJSON {
+		return ErrUnknownGraphFormat
+	}
+
+	sg = sg.rlock()
+	defer sg.runlock()
+
+	graph := sg.graph()
+	if format == GraphJSON {
+		return json.NewEncoder(w).Encode(graph)
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "digraph lattice {")
+	for _, node := range graph.Nodes {
+		fmt.Fprintf(bw, "\t%d [label=\"%d,%d\\n%s\"", node.ID, node.X, node.Y, dotNumber(node.Weight))
+		if node.Label != "" {
+			fmt.Fprintf(bw, " group=%q", node.Label)
+		}
+		if node.Blocked {
+			fmt.Fprint(bw, " style=filled fillcolor=gray")
+		}
+		fmt.Fprintln(bw, "];")
+	}
+	for _, edge := range graph.Edges {
+		fmt.Fprintf(bw, "\t%d -> %d [label=\"%s\"", edge.From, edge.To, dotNumber(edge.Cost))
+		if edge.Portal {
+			fmt.Fprint(bw, " style=dashed")
+		}
+		if edge.Gated {
+			fmt.Fprint(bw, " color=red")
+		}
+		fmt.Fprintln(bw, "];")
+	}
+	fmt.Fprintln(bw, "}")
+
+	return bw.Flush()
+}
+
+func (sg *SpatialGrid[T]) graph() graphDocument {
+	graph := graphDocument{
+		Width:     sg.SizeX,
+		Height:    sg.SizeY,
+		ChunkSize: sg.ChunkSize,
+		Nodes:     make([]graphNode, 0, sg.SizeX*sg.SizeY),
+		Edges:     []graphEdge{},
+	}
+
+	for y := 0; y < sg.SizeY; y++ {
+		for x := 0; x < sg.SizeX; x++ {
+			from := sg.index(x, y)
+			node := sg.Nodes[x][y]
+			graph.Nodes = append(graph.Nodes, graphNode{
+				ID:      from,
+				X:       x,
+				Y:       y,
+				Weight:  finite(node.weight),
+				Blocked: sg.blocked.get(from),
+				Label:   node.label,
+			})
+
+			for _, direction := range sg.config.neighbors {
+				toX, toY := x+direction[0], y+direction[1]
+				if !sg.inBounds(toX, toY) || sg.blocked.get(sg.index(toX, toY)) {
+					continue
+				}
+				_, gated := sg.edgeRules[edgeKey{from: from, dx: direction[0], dy: direction[1]}]
+				graph.Edges = append(graph.Edges, graphEdge{
+					From:  from,
+					To:    sg.index(toX, toY),
+					Cost:  finite(sg.Nodes[toX][toY].weight),
+					Gated: gated,
+				})
+			}
+			for _, p := range sg.portals[from] {
+				if sg.blocked.get(p.to) {
+					continue
+				}
+				graph.Edges = append(graph.Edges, graphEdge{
+					From:   from,
+					To:     p.to,
+					Cost:   finite(p.cost + sg.Nodes[p.to%sg.SizeX][p.to/sg.SizeX].weight),
+					Portal: true,
+				})
+			}
+		}
+	}
+
+	return graph
+}
+
+// finite is nil for values JSON cannot hold
+func finite(v float64) *float64 {
+	if math.IsInf(v, 0) || math.IsNaN(v) {
+		return nil
+	}
+	return &v
+}
+
+func dotNumber(v *float64) string {
+	if v == nil {
+		return "inf"
+	}
+	return fmt.Sprint(*v)
+}
@@ -0,0 +1,130 @@
+package lattice_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/mosaic"
+)
+
+func graphGrid(t *testing.T) *lattice.SpatialGrid[int] {
+	sg := lattice.NewSpatialGrid[int](2, 2, 8)
+	err := errors.Join(
+		sg.SetTerrain(1, 1, math.Inf(1)),
+		sg.SetTerrain(1, 0, 3),
+		sg.AddPortal(1, 0, 0, 1, 2),
+		sg.SetEdgeRule(0, 0, lattice.Offset{X: 1, Y: 0}, lattice.Impassable),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sg.LabelCells(mosaic.NewRectangle(mosaic.NewVector(4, 12), 6, 6), "road")
+
+	return sg
+}
+
+func Test_spatial_grid_ExportGraph_json(t *testing.T) {
+	type (
+		node struct {
+			ID      int
+			X       int
+			Y       int
+			Weight  *float64
+			Blocked bool
+			Label   string
+		}
+		edge struct {
+			From   int
+			To     int
+			Cost   *float64
+			Portal bool
+			Gated  bool
+		}
+	)
+	var graph struct {
+		Width     int
+		Height    int
+		ChunkSize float64
+		Nodes     []node
+		Edges     []edge
+	}
+
+	var buf bytes.Buffer
+	err := graphGrid(t).ExportGraph(&buf, lattice.GraphJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = json.Unmarshal(buf.Bytes(), &graph)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if graph.Width != 2 || graph.Height != 2 || graph.ChunkSize != 8 || len(graph.Nodes) != 4 {
+		t.Fatal(fmt.Errorf("spatialGrid.ExportGraph() want: 2x2 grid, got: %+v\n", graph))
+	}
+	blocked := graph.Nodes[3]
+	if !blocked.Blocked || blocked.Weight != nil || graph.Nodes[0].Blocked {
+		t.Error(fmt.Errorf("spatialGrid.ExportGraph() want: only cell 3 blocked, got: %+v\n", graph.Nodes))
+	}
+	if graph.Nodes[2].Label != "road" || graph.Nodes[0].Label != "" {
+		t.Error(fmt.Errorf("spatialGrid.ExportGraph() want: cell 2 labeled, got: %+v\n", graph.Nodes))
+	}
+
+	type want struct {
+		cost          float64
+		portal, gated bool
+	}
+	wants := map[[2]int]want{
+		{0, 1}: {cost: 3, gated: true},
+		{0, 2}: {cost: 0},
+		{1, 0}: {cost: 0},
+		{1, 2}: {cost: 2, portal: true},
+		{2, 0}: {cost: 0},
+		{3, 1}: {cost: 3},
+		{3, 2}: {cost: 0},
+	}
+	if len(graph.Edges) != len(wants) {
+		t.Error(fmt.Errorf("spatialGrid.ExportGraph() want: %+v edges, got: %+v\n", len(wants), graph.Edges))
+	}
+	for _, e := range graph.Edges {
+		w, ok := wants[[2]int{e.From, e.To}]
+		if !ok || e.Cost == nil || *e.Cost != w.cost || e.Portal != w.portal || e.Gated != w.gated {
+			t.Error(fmt.Errorf("spatialGrid.ExportGraph() want: %+v, got: %+v\n", w, e))
+		}
+	}
+}
+
+func Test_spatial_grid_ExportGraph_dot(t *testing.T) {
+	var buf bytes.Buffer
+	err := graphGrid(t).ExportGraph(&buf, lattice.GraphDOT)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := buf.String()
+	for _, want := range []string{
+		"digraph lattice {",
+		"\t3 [label=\"1,1\\ninf\" style=filled fillcolor=gray];",
+		"\t2 [label=\"0,1\\n0\" group=\"road\"];",
+		"\t0 -> 1 [label=\"3\" color=red];",
+		"\t1 -> 2 [label=\"2\" style=dashed];",
+	} {
+		if !strings.Contains(got, want) {
+			t.Error(fmt.Errorf("spatialGrid.ExportGraph() want: %q in, got: %s\n", want, got))
+		}
+	}
+	if strings.Contains(got, "-> 3 ") {
+		t.Error(fmt.Errorf("spatialGrid.ExportGraph() want: no edges into the blocked cell, got: %s\n", got))
+	}
+
+	err = graphGrid(t).ExportGraph(&buf, "graphml")
+	if !errors.Is(err, lattice.ErrUnknownGraphFormat) {
+		t.Error(fmt.Errorf("spatialGrid.ExportGraph() want: %+v, got: %+v\n", lattice.ErrUnknownGraphFormat, err))
+	}
+}
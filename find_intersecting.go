@@ -0,0 +1,34 @@
+package lattice
+
+import "github.com/maladroitthief/mosaic"
+
+func (sg *SpatialGrid[T]) FindIntersecting(bounds mosaic.Rectangle) []T {
+	sg = sg.rlock()
+	defer sg.runlock()
+
+	return sg.findIntersecting(bounds)
+}
+
+func (sg *SpatialGrid[T]) findIntersecting(bounds mosaic.Rectangle) []T {
+	set := sg.newValueSet()
+	xMinIndex, yMinIndex, xMaxIndex, yMaxIndex, ok := sg.cellRange(bounds)
+	if !ok {
+		return set.values()
+	}
+
+	var mask []uint8
+	for x := xMinIndex; x <= xMaxIndex; x++ {
+		for y := yMinIndex; y <= yMaxIndex; y++ {
+			node := sg.Nodes[x][y]
+			mask = node.packed.intersect(bounds, mask)
+			for i, hit := range mask {
+				if hit == 0 {
+					continue
+				}
+				set.add(node.Items[i].value)
+			}
+		}
+	}
+
+	return set.values()
+}
@@ -0,0 +1,76 @@
+package lattice_test
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/mosaic"
+)
+
+func Test_spatial_grid_FindIntersecting(t *testing.T) {
+	type params struct {
+		item   int
+		bounds mosaic.Rectangle
+	}
+	tests := []struct {
+		name   string
+		params []params
+		query  mosaic.Rectangle
+		want   []int
+	}{
+		{
+			name: "same cell different bounds",
+			params: []params{
+				{item: 1, bounds: mosaic.NewRectangle(mosaic.Vector{X: 2, Y: 2}, 2, 2)},
+				{item: 2, bounds: mosaic.NewRectangle(mosaic.Vector{X: 6, Y: 6}, 2, 2)},
+				{item: 3, bounds: mosaic.NewRectangle(mosaic.Vector{X: 12, Y: 12}, 4, 4)},
+				{item: 4, bounds: mosaic.NewRectangle(mosaic.Vector{X: 28, Y: 28}, 2, 2)},
+			},
+			query: mosaic.NewRectangle(mosaic.Vector{X: 8, Y: 8}, 8, 8),
+			want:  []int{2, 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg := lattice.NewSpatialGrid[int](4, 4, 8)
+			for _, param := range tt.params {
+				sg.Insert(lattice.Item[int]{param.item, param.bounds, 1.0})
+			}
+
+			got := sg.FindIntersecting(tt.query)
+			slices.Sort(got)
+			if !slices.Equal(tt.want, got) {
+				t.Error(fmt.Errorf("spatialGrid.FindIntersecting() want: %+v, got: %+v\n", tt.want, got))
+			}
+		})
+	}
+}
+
+func BenchmarkSpatialGridFindIntersecting(b *testing.B) {
+	sg := lattice.NewSpatialGrid[int](GridX, GridY, GridSize)
+	entities := []mosaic.Rectangle{}
+
+	for i := 0; i < ContainerSize; i++ {
+		x0 := float64(rand.Intn(GridX) * rand.Intn(int(GridSize)))
+		y0 := float64(rand.Intn(GridY) * rand.Intn(int(GridSize)))
+		sizeX := GridSize * rand.Float64()
+		sizeY := GridSize * rand.Float64()
+		bounds := mosaic.NewRectangle(mosaic.Vector{X: x0, Y: y0}, sizeX, sizeY)
+		entities = append(entities, bounds)
+
+		sg.Insert(
+			lattice.Item[int]{
+				rand.Int(),
+				bounds,
+				rand.Float64(),
+			},
+		)
+	}
+
+	for n := 0; n < b.N; n++ {
+		sg.FindIntersecting(entities[n%len(entities)])
+	}
+}
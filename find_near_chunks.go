@@ -0,0 +1,55 @@
+package lattice
+
+import "github.com/maladroitthief/mosaic"
+
+// FindNearChunks streams what FindNear would return to process in batches of
+// at most chunkSize values, stopping at the first error process returns.
+// The batch slice is reused, so process must copy anything it keeps. Values
+// are not deduplicated across batches, a value inserted more than once
+// arrives once per copy. process runs under the read lock and must not write
+// to the grid.
+func (sg *SpatialGrid[T]) FindNearChunks(bounds mosaic.Rectangle, chunkSize int, process func([]T) error) error {
+	if chunkSize <= 0 {
+		return ErrInvalidOption
+	}
+
+	sg = sg.rlock()
+	defer sg.runlock()
+
+	xMinIndex, yMinIndex, xMaxIndex, yMaxIndex, ok := sg.cellRange(bounds)
+	if !ok {
+		return nil
+	}
+
+	batch := make([]T, 0, chunkSize)
+	var mask []uint8
+	for x := xMinIndex; x <= xMaxIndex; x++ {
+		for y := yMinIndex; y <= yMaxIndex; y++ {
+			node := sg.Nodes[x][y]
+			if sg.config.precise {
+				mask = node.packed.intersect(bounds, mask)
+			}
+			for i, item := range node.Items {
+				if sg.config.precise && mask[i] == 0 {
+					continue
+				}
+
+				batch = append(batch, item.value)
+				if len(batch) < chunkSize {
+					continue
+				}
+				err := process(batch)
+				if err != nil {
+					return err
+				}
+				batch = batch[:0]
+			}
+		}
+	}
+
+	if len(batch) == 0 {
+		return nil
+	}
+
+	return process(batch)
+}
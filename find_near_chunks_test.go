@@ -0,0 +1,67 @@
+package lattice_test
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/mosaic"
+)
+
+func Test_spatial_grid_FindNearChunks(t *testing.T) {
+	errStop := errors.New("stop")
+	type want struct {
+		batches []int
+		err     error
+	}
+	tests := []struct {
+		name      string
+		chunkSize int
+		stopAfter int
+		want      want
+	}{
+		{name: "even batches", chunkSize: 5, want: want{batches: []int{5, 5}}},
+		{name: "remainder", chunkSize: 4, want: want{batches: []int{4, 4, 2}}},
+		{name: "single batch", chunkSize: 64, want: want{batches: []int{10}}},
+		{name: "stops on error", chunkSize: 3, stopAfter: 2, want: want{batches: []int{3, 3}, err: errStop}},
+		{name: "invalid chunk size", chunkSize: 0, want: want{batches: []int{}, err: lattice.ErrInvalidOption}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg := lattice.NewSpatialGrid[int](8, 8, 16)
+			for i := 0; i < 10; i++ {
+				sg.Insert(lattice.Item[int]{i, mosaic.NewRectangle(mosaic.NewVector(float64(i*12+4), 20), 4, 4), 1})
+			}
+			bounds := mosaic.NewRectangle(mosaic.NewVector(64, 64), 128, 128)
+
+			batches := []int{}
+			values := []int{}
+			err := sg.FindNearChunks(bounds, tt.chunkSize, func(batch []int) error {
+				batches = append(batches, len(batch))
+				values = append(values, batch...)
+				if tt.stopAfter > 0 && len(batches) == tt.stopAfter {
+					return errStop
+				}
+				return nil
+			})
+			if !errors.Is(err, tt.want.err) {
+				t.Fatal(fmt.Errorf("spatialGrid.FindNearChunks() want: %+v, got: %+v\n", tt.want.err, err))
+			}
+			if !slices.Equal(tt.want.batches, batches) {
+				t.Error(fmt.Errorf("spatialGrid.FindNearChunks() want: %+v, got: %+v\n", tt.want.batches, batches))
+			}
+			if err != nil {
+				return
+			}
+
+			want := sg.FindNear(bounds)
+			slices.Sort(want)
+			slices.Sort(values)
+			if !slices.Equal(want, values) {
+				t.Error(fmt.Errorf("spatialGrid.FindNearChunks() want: %+v, got: %+v\n", want, values))
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package lattice
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/maladroitthief/mosaic"
+)
+
+// FindNearSorted returns the same items as FindNear ordered by the distance
+// from the query center to each item's center, nearest first.
+func (sg *SpatialGrid[T]) FindNearSorted(bounds mosaic.Rectangle) []T {
+	sg = sg.rlock()
+	defer sg.runlock()
+
+	type candidate struct {
+		value    T
+		distance float64
+	}
+
+	candidates := []candidate{}
+	seen := map[T]int{}
+	xMinIndex, yMinIndex, xMaxIndex, yMaxIndex, ok := sg.cellRange(bounds)
+	if !ok {
+		return []T{}
+	}
+
+	for x := xMinIndex; x <= xMaxIndex; x++ {
+		for y := yMinIndex; y <= yMaxIndex; y++ {
+			for _, item := range sg.Nodes[x][y].Items {
+				if sg.config.precise && !bounds.Intersects(item.bounds) {
+					continue
+				}
+				distance := bounds.Position.Distance(item.bounds.Position)
+				i, ok := seen[item.value]
+				if !ok {
+					seen[item.value] = len(candidates)
+					candidates = append(candidates, candidate{item.value, distance})
+					continue
+				}
+				candidates[i].distance = min(candidates[i].distance, distance)
+			}
+		}
+	}
+
+	slices.SortStableFunc(candidates, func(a, b candidate) int {
+		return cmp.Compare(a.distance, b.distance)
+	})
+
+	values := make([]T, len(candidates))
+	for i := range candidates {
+		values[i] = candidates[i].value
+	}
+
+	return values
+}
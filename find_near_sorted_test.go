@@ -0,0 +1,63 @@
+package lattice_test
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/mosaic"
+)
+
+func Test_spatial_grid_FindNearSorted(t *testing.T) {
+	type params struct {
+		item   int
+		bounds mosaic.Rectangle
+	}
+	tests := []struct {
+		name   string
+		params []params
+		query  mosaic.Rectangle
+		want   []int
+	}{
+		{
+			name: "nearest first",
+			params: []params{
+				{item: 1, bounds: mosaic.NewRectangle(mosaic.Vector{X: 30, Y: 30}, 2, 2)},
+				{item: 2, bounds: mosaic.NewRectangle(mosaic.Vector{X: 17, Y: 15}, 2, 2)},
+				{item: 3, bounds: mosaic.NewRectangle(mosaic.Vector{X: 4, Y: 4}, 2, 2)},
+				{item: 4, bounds: mosaic.NewRectangle(mosaic.Vector{X: 20, Y: 20}, 2, 2)},
+			},
+			query: mosaic.NewRectangle(mosaic.Vector{X: 16, Y: 16}, 32, 32),
+			want:  []int{2, 4, 3, 1},
+		},
+		{
+			name: "outside the query cells",
+			params: []params{
+				{item: 1, bounds: mosaic.NewRectangle(mosaic.Vector{X: 2, Y: 2}, 2, 2)},
+				{item: 2, bounds: mosaic.NewRectangle(mosaic.Vector{X: 30, Y: 30}, 2, 2)},
+			},
+			query: mosaic.NewRectangle(mosaic.Vector{X: 4, Y: 4}, 4, 4),
+			want:  []int{1},
+		},
+		{
+			name:   "empty",
+			params: []params{},
+			query:  mosaic.NewRectangle(mosaic.Vector{X: 4, Y: 4}, 4, 4),
+			want:   []int{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg := lattice.NewSpatialGrid[int](4, 4, 8)
+			for _, param := range tt.params {
+				sg.Insert(lattice.Item[int]{param.item, param.bounds, 1.0})
+			}
+
+			got := sg.FindNearSorted(tt.query)
+			if !slices.Equal(tt.want, got) {
+				t.Error(fmt.Errorf("spatialGrid.FindNearSorted() want: %+v, got: %+v\n", tt.want, got))
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package lattice
+
+import (
+	"errors"
+	"math"
+
+	"github.com/maladroitthief/mosaic"
+)
+
+var (
+	ErrItemNotFound = errors.New("no matching item was found")
+)
+
+func (sg *SpatialGrid[T]) FindNearest(from mosaic.Vector, match func(T) bool, maxDepth int) (T, mosaic.Vector, error) {
+	sg = sg.rlock()
+	defer sg.runlock()
+
+	var (
+		best         T
+		bestPosition mosaic.Vector
+		found        bool
+	)
+	bestDistance := math.Inf(1)
+
+	startX, startY, err := sg.cell(from.X, from.Y)
+	if err != nil {
+		return best, bestPosition, err
+	}
+
+	visit := func(_ int, sgn spatialGridNode[T]) error {
+		for i := 0; i < len(sgn.Items); i++ {
+			if !match(sgn.Items[i].value) {
+				continue
+			}
+
+			distance := from.Distance(sgn.Items[i].bounds.Position)
+			if distance < bestDistance {
+				best = sgn.Items[i].value
+				bestPosition = sgn.Items[i].bounds.Position
+				bestDistance = distance
+				found = true
+			}
+		}
+		return nil
+	}
+
+	// cells one ring further out are at least this far away, so once the best
+	// match beats it the remaining frontier can be skipped
+	levelDone := func(depth int) error {
+		next := depth + 1
+		minDistance := float64((next+1)/2-1) * sg.ChunkSize
+		if found && minDistance >= bestDistance {
+			return ErrStopSearch
+		}
+		return nil
+	}
+
+	_, err = sg.search([]spatialGridNode[T]{sg.Nodes[startX][startY]}, maxDepth, visit, levelDone, nil)
+	switch {
+	case found && (err == nil || errors.Is(err, ErrStopSearch) || errors.Is(err, ErrMaxDepthReached)):
+		return best, bestPosition, nil
+	case err != nil && !errors.Is(err, ErrStopSearch):
+		return best, bestPosition, err
+	default:
+		return best, bestPosition, ErrItemNotFound
+	}
+}
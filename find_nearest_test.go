@@ -0,0 +1,94 @@
+package lattice_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/mosaic"
+)
+
+func Test_spatial_grid_FindNearest(t *testing.T) {
+	type params struct {
+		item   int
+		bounds mosaic.Rectangle
+	}
+	type want struct {
+		item     int
+		position mosaic.Vector
+		err      error
+	}
+	tests := []struct {
+		name   string
+		params []params
+		from   mosaic.Vector
+		match  func(int) bool
+		depth  int
+		want   want
+	}{
+		{
+			name: "closest within ring",
+			params: []params{
+				{item: 1, bounds: mosaic.NewRectangle(mosaic.Vector{X: 17, Y: 1}, 1, 1)},
+				{item: 2, bounds: mosaic.NewRectangle(mosaic.Vector{X: 7, Y: 7}, 1, 1)},
+				{item: 3, bounds: mosaic.NewRectangle(mosaic.Vector{X: 12, Y: 9}, 1, 1)},
+			},
+			from:  mosaic.Vector{X: 12, Y: 4},
+			match: func(v int) bool { return v > 0 },
+			depth: 8,
+			want:  want{item: 3, position: mosaic.Vector{X: 12, Y: 9}},
+		},
+		{
+			name: "deeper ring is closer",
+			params: []params{
+				{item: 1, bounds: mosaic.NewRectangle(mosaic.Vector{X: 1, Y: 4}, 1, 1)},
+				{item: 2, bounds: mosaic.NewRectangle(mosaic.Vector{X: 17, Y: 9}, 1, 1)},
+			},
+			from:  mosaic.Vector{X: 15, Y: 4},
+			match: func(v int) bool { return v > 0 },
+			depth: 8,
+			want:  want{item: 2, position: mosaic.Vector{X: 17, Y: 9}},
+		},
+		{
+			name: "predicate",
+			params: []params{
+				{item: 1, bounds: mosaic.NewRectangle(mosaic.Vector{X: 4, Y: 4}, 1, 1)},
+				{item: 2, bounds: mosaic.NewRectangle(mosaic.Vector{X: 28, Y: 28}, 1, 1)},
+			},
+			from:  mosaic.Vector{X: 4, Y: 4},
+			match: func(v int) bool { return v == 2 },
+			depth: 8,
+			want:  want{item: 2, position: mosaic.Vector{X: 28, Y: 28}},
+		},
+		{
+			name: "not found",
+			params: []params{
+				{item: 1, bounds: mosaic.NewRectangle(mosaic.Vector{X: 4, Y: 4}, 1, 1)},
+			},
+			from:  mosaic.Vector{X: 4, Y: 4},
+			match: func(v int) bool { return v == 2 },
+			depth: 8,
+			want:  want{err: lattice.ErrItemNotFound},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg := lattice.NewSpatialGrid[int](4, 4, 8)
+			for _, param := range tt.params {
+				sg.Insert(lattice.Item[int]{param.item, param.bounds, 1.0})
+			}
+
+			got, position, err := sg.FindNearest(tt.from, tt.match, tt.depth)
+			if !errors.Is(err, tt.want.err) {
+				t.Error(fmt.Errorf("spatialGrid.FindNearest() want error: %+v, got error: %+v\n", tt.want.err, err))
+			}
+			if err != nil {
+				return
+			}
+			if got != tt.want.item || position != tt.want.position {
+				t.Error(fmt.Errorf("spatialGrid.FindNearest() want: %+v %+v, got: %+v %+v\n", tt.want.item, tt.want.position, got, position))
+			}
+		})
+	}
+}
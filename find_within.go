@@ -0,0 +1,75 @@
+package lattice
+
+import (
+	"cmp"
+	"math"
+	"slices"
+
+	"github.com/maladroitthief/mosaic"
+)
+
+// FindWithin returns the items whose centers lie within radius of center,
+// nearest first. Cells whose closest point is already out of reach are
+// skipped without looking at their items.
+func (sg *SpatialGrid[T]) FindWithin(center mosaic.Vector, radius float64) []T {
+	if !(radius >= 0) {
+		return []T{}
+	}
+
+	sg = sg.rlock()
+	defer sg.runlock()
+
+	type candidate struct {
+		value    T
+		distance float64
+	}
+
+	candidates := []candidate{}
+	seen := map[T]int{}
+	xMinIndex, yMinIndex, xMaxIndex, yMaxIndex, ok := sg.cellRange(mosaic.NewRectangle(center, 2*radius, 2*radius))
+	if !ok {
+		return []T{}
+	}
+
+	for x := xMinIndex; x <= xMaxIndex; x++ {
+		for y := yMinIndex; y <= yMaxIndex; y++ {
+			node := sg.Nodes[x][y]
+			if len(node.Items) == 0 || reach(node.bounds, center) > radius {
+				continue
+			}
+			for _, item := range node.Items {
+				distance := center.Distance(item.bounds.Position)
+				if distance > radius {
+					continue
+				}
+				i, ok := seen[item.value]
+				if !ok {
+					seen[item.value] = len(candidates)
+					candidates = append(candidates, candidate{item.value, distance})
+					continue
+				}
+				candidates[i].distance = min(candidates[i].distance, distance)
+			}
+		}
+	}
+
+	slices.SortStableFunc(candidates, func(a, b candidate) int {
+		return cmp.Compare(a.distance, b.distance)
+	})
+
+	values := make([]T, len(candidates))
+	for i := range candidates {
+		values[i] = candidates[i].value
+	}
+
+	return values
+}
+
+// reach is the distance from point to the closest point of bounds
+func reach(bounds mosaic.Rectangle, point mosaic.Vector) float64 {
+	minPoint, maxPoint := bounds.MinPoint(), bounds.MaxPoint()
+	dx := max(minPoint.X-point.X, 0, point.X-maxPoint.X)
+	dy := max(minPoint.Y-point.Y, 0, point.Y-maxPoint.Y)
+
+	return math.Hypot(dx, dy)
+}
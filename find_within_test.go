@@ -0,0 +1,80 @@
+package lattice_test
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/mosaic"
+)
+
+func Test_spatial_grid_FindWithin(t *testing.T) {
+	items := []struct {
+		item     int
+		position mosaic.Vector
+	}{
+		{item: 1, position: mosaic.Vector{X: 30, Y: 30}},
+		{item: 2, position: mosaic.Vector{X: 17, Y: 15}},
+		{item: 3, position: mosaic.Vector{X: 4, Y: 4}},
+		{item: 4, position: mosaic.Vector{X: 20, Y: 20}},
+		{item: 5, position: mosaic.Vector{X: 21, Y: 21}},
+		{item: 6, position: mosaic.Vector{X: 2, Y: 3}},
+	}
+	tests := []struct {
+		name   string
+		center mosaic.Vector
+		radius float64
+		want   []int
+	}{
+		{
+			name:   "nearest first",
+			center: mosaic.Vector{X: 16, Y: 16},
+			radius: 6,
+			want:   []int{2, 4},
+		},
+		{
+			name:   "corner of the square is out of range",
+			center: mosaic.Vector{X: 16, Y: 16},
+			radius: 7,
+			want:   []int{2, 4},
+		},
+		{
+			name:   "whole grid",
+			center: mosaic.Vector{X: 16, Y: 16},
+			radius: 100,
+			want:   []int{2, 4, 5, 3, 6, 1},
+		},
+		{
+			name:   "center outside the grid",
+			center: mosaic.Vector{X: -10, Y: -10},
+			radius: 20,
+			want:   []int{6, 3},
+		},
+		{
+			name:   "zero radius",
+			center: mosaic.Vector{X: 17, Y: 15},
+			radius: 0,
+			want:   []int{2},
+		},
+		{
+			name:   "negative radius",
+			center: mosaic.Vector{X: 16, Y: 16},
+			radius: -1,
+			want:   []int{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg := lattice.NewSpatialGrid[int](4, 4, 8)
+			for _, item := range items {
+				sg.Insert(lattice.Item[int]{item.item, mosaic.NewRectangle(item.position, 2, 2), 1.0})
+			}
+
+			got := sg.FindWithin(tt.center, tt.radius)
+			if !slices.Equal(tt.want, got) {
+				t.Error(fmt.Errorf("spatialGrid.FindWithin() want: %+v, got: %+v\n", tt.want, got))
+			}
+		})
+	}
+}
@@ -3,7 +3,6 @@ module github.com/maladroitthief/lattice
 go 1.22.0
 
 require (
-	github.com/maladroitthief/caravan v1.4.3
 	github.com/maladroitthief/mosaic v1.4.0
 	golang.org/x/exp v0.0.0-20240529005216-23cca8864a10
 )
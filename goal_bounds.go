@@ -0,0 +1,188 @@
+package lattice
+
+import "math"
+
+type (
+	// goalBounds holds, for every cell and neighbor direction, the box of
+	// cells that some cheapest route starting with that step leads to. It is
+	// only trusted while no write has happened since it was built.
+	goalBounds struct {
+		boxes []goalBox
+		at    uint64
+	}
+
+	goalBox struct {
+		minX, minY, maxX, maxY int32
+	}
+)
+
+// PrecomputeGoalBounds runs a search from every cell so that later searches
+// can skip steps that cannot lead toward the goal on a cheapest route. Any
+// write to the grid discards the bounds, so call it once a static map is
+// loaded. Cheapest means the lowest cost and then the fewest steps. Pruning
+// is skipped for searches with a Profile, a TurnPenalty or a MaxPathLength,
+// since those change what is cheapest.
+//
+// The work is one full search per cell and the memory 16 bytes per cell and
+// direction.
+func (sg *SpatialGrid[T]) PrecomputeGoalBounds() error {
+	sg.lock()
+	defer sg.unlock()
+
+	directions := len(sg.config.neighbors)
+	if directions >= 64 {
+		return ErrInvalidOption
+	}
+
+	cells := sg.SizeX * sg.SizeY
+	boxes := make([]goalBox, cells*directions)
+	for i := range boxes {
+		boxes[i] = goalBox{math.MaxInt32, math.MaxInt32, math.MinInt32, math.MinInt32}
+	}
+
+	w := sg.newGoalWalker(cells)
+	for source := 0; source < cells; source++ {
+		// blocked cells still get bounds, a search may start on one
+		w.settle(int32(source))
+		masks := w.firstSteps(int32(source))
+		for target, mask := range masks {
+			if mask == 0 {
+				continue
+			}
+			x, y := int32(target%sg.SizeX), int32(target/sg.SizeX)
+			for d := 0; d < directions; d++ {
+				if mask&(1<<d) != 0 {
+					boxes[source*directions+d] = boxes[source*directions+d].grow(x, y)
+				}
+			}
+		}
+	}
+
+	sg.goalBounds = goalBounds{boxes: boxes, at: sg.writes}
+	return nil
+}
+
+// prunes reports whether a search with opts may use the goal bounds
+func (sg *SpatialGrid[T]) prunes(opts PathOptions) bool {
+	return sg.goalBounds.boxes != nil && sg.goalBounds.at == sg.writes &&
+		opts.Profile == (TraversalProfile{}) && opts.TurnPenalty == 0 && opts.MaxPathLength == 0 &&
+		sg.clearance(opts) == nil && len(opts.Layers) == 0 && len(opts.AllowedLabels) == 0
+}
+
+func (sg *SpatialGrid[T]) towardGoal(cell, direction int, end Cell) bool {
+	return sg.goalBounds.boxes[cell*len(sg.config.neighbors)+direction].contains(int32(end.X), int32(end.Y))
+}
+
+func (b goalBox) grow(x, y int32) goalBox {
+	return goalBox{min(b.minX, x), min(b.minY, y), max(b.maxX, x), max(b.maxY, y)}
+}
+
+func (b goalBox) contains(x, y int32) bool {
+	return x >= b.minX && x <= b.maxX && y >= b.minY && y <= b.maxY
+}
+
+// goalWalker is the scratch space for one source at a time
+type goalWalker[T comparable] struct {
+	grid    *SpatialGrid[T]
+	profile TraversalProfile
+	costs   []float64
+	steps   []int32
+	masks   []uint64
+	heap    minHeap
+	queue   []int32
+	reverse map[int32][]portal
+}
+
+func (sg *SpatialGrid[T]) newGoalWalker(cells int) *goalWalker[T] {
+	return &goalWalker[T]{
+		grid:  sg,
+		costs: make([]float64, cells),
+		steps: make([]int32, cells),
+		masks: make([]uint64, cells),
+	}
+}
+
+// goalEdges calls visit for every step out of cell, with the step's direction or
+// len(neighbors) for a portal
+func (sg *SpatialGrid[T]) goalEdges(cell int32, profile TraversalProfile, visit func(next int32, direction int, cost float64)) {
+	x, y := int(cell)%sg.SizeX, int(cell)/sg.SizeX
+	for d, direction := range sg.config.neighbors {
+		nextX, nextY := x+direction[0], y+direction[1]
+		if !sg.inBounds(nextX, nextY) {
+			continue
+		}
+		next := sg.index(nextX, nextY)
+		if sg.blocked.get(next) || !sg.edgeAllowed(int(cell), direction[0], direction[1], profile) {
+			continue
+		}
+		visit(int32(next), d, sg.Nodes[nextX][nextY].weight)
+	}
+	for _, p := range sg.portals[int(cell)] {
+		if sg.blocked.get(p.to) {
+			continue
+		}
+		visit(int32(p.to), len(sg.config.neighbors), p.cost+sg.Nodes[p.to%sg.SizeX][p.to/sg.SizeX].weight)
+	}
+}
+
+// settle finds the cheapest cost from source to every cell
+func (w *goalWalker[T]) settle(source int32) {
+	sg := w.grid
+	for i := range w.costs {
+		w.costs[i] = math.Inf(1)
+	}
+	w.costs[source] = 0
+	w.heap = w.heap.Push(source, 0)
+	for w.heap.Len() > 0 {
+		priority := w.heap[0].priority
+		var current int32
+		current, w.heap = w.heap.Pop()
+		if priority > w.costs[current] {
+			continue
+		}
+
+		sg.goalEdges(current, w.profile, func(next int32, _ int, cost float64) {
+			if w.costs[current]+cost < w.costs[next] {
+				w.costs[next] = w.costs[current] + cost
+				w.heap = w.heap.Push(next, w.costs[next])
+			}
+		})
+	}
+}
+
+// firstSteps walks the steps that keep a route at its cheapest cost, level
+// by level so every cell is reached in the fewest such steps, and collects
+// which first steps out of source each cell can be reached through
+func (w *goalWalker[T]) firstSteps(source int32) []uint64 {
+	sg := w.grid
+	clear(w.masks)
+	for i := range w.steps {
+		w.steps[i] = -1
+	}
+
+	w.steps[source] = 0
+	w.queue = append(w.queue[:0], source)
+	for head := 0; head < len(w.queue); head++ {
+		current := w.queue[head]
+		sg.goalEdges(current, w.profile, func(next int32, direction int, cost float64) {
+			if w.costs[current]+cost != w.costs[next] {
+				return
+			}
+			if w.steps[next] >= 0 && w.steps[next] != w.steps[current]+1 {
+				return
+			}
+
+			mask := w.masks[current]
+			if current == source {
+				mask = 1 << direction
+			}
+			w.masks[next] |= mask
+			if w.steps[next] < 0 {
+				w.steps[next] = w.steps[current] + 1
+				w.queue = append(w.queue, next)
+			}
+		})
+	}
+
+	return w.masks
+}
@@ -0,0 +1,78 @@
+package lattice_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/lattice/latticegen"
+	"github.com/maladroitthief/mosaic"
+)
+
+func Test_spatial_grid_PrecomputeGoalBounds(t *testing.T) {
+	tests := []struct {
+		name   string
+		layout latticegen.Layout
+	}{
+		{name: "maze", layout: latticegen.Maze(15, 15, 2)},
+		{name: "obstacles", layout: latticegen.Obstacles(16, 16, 0.25, 4)},
+		{name: "rooms", layout: latticegen.Rooms(16, 16, 4, 9)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg := lattice.NewSpatialGrid[int](tt.layout.Width, tt.layout.Height, 8)
+			sg.Reset(latticegen.Items(tt.layout, sg, func(x, y int) int { return -1 }))
+			open := tt.layout.OpenCells()
+			for i, cell := range open {
+				if i%3 == 0 {
+					sg.Insert(lattice.Item[int]{i, sg.CellBounds(cell.X, cell.Y), float64(i % 5)})
+				}
+			}
+
+			start := sg.CellCenter(open[0].X, open[0].Y)
+			goals := []mosaic.Vector{}
+			for _, cell := range open[len(open)/2:] {
+				goals = append(goals, sg.CellCenter(cell.X, cell.Y))
+			}
+			plain := make([]int, len(goals))
+			for i, goal := range goals {
+				_, trace, _ := sg.FindPathTrace(start, goal, lattice.PathOptions{}, 0)
+				plain[i] = len(trace.Expanded)
+			}
+
+			err := sg.PrecomputeGoalBounds()
+			if err != nil {
+				t.Fatal(err)
+			}
+			paths, _ := sg.PathsFrom(start, goals)
+			pruned, total := 0, 0
+			for i, goal := range goals {
+				got, trace, err := sg.FindPathTrace(start, goal, lattice.PathOptions{}, 0)
+				reachable := !math.IsInf(paths[i].Cost, 1)
+				if reachable != (err == nil) {
+					t.Fatal(fmt.Errorf("spatialGrid.FindPath() want reachable: %+v, got error: %+v\n", reachable, err))
+				}
+				if err == nil && got.Cost < paths[i].Cost {
+					t.Error(fmt.Errorf("spatialGrid.FindPath() cost below the cheapest route: %+v < %+v\n", got.Cost, paths[i].Cost))
+				}
+				pruned += len(trace.Expanded)
+				total += plain[i]
+			}
+			if pruned >= total {
+				t.Error(fmt.Errorf("spatialGrid.PrecomputeGoalBounds() want fewer than: %+v expansions, got: %+v\n", total, pruned))
+			}
+
+			// any write throws the bounds away
+			sg.Insert(lattice.Item[int]{-2, sg.CellBounds(open[1].X, open[1].Y), 0})
+			after := 0
+			for _, goal := range goals {
+				_, trace, _ := sg.FindPathTrace(start, goal, lattice.PathOptions{}, 0)
+				after += len(trace.Expanded)
+			}
+			if after != total {
+				t.Error(fmt.Errorf("spatialGrid.Insert() want stale bounds ignored: %+v expansions, got: %+v\n", total, after))
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package lattice
+
+import "github.com/maladroitthief/mosaic"
+
+const autoGrowStep = 16
+
+// Origin is the world position of the minimum corner of cell 0, 0
+func (sg *SpatialGrid[T]) Origin() mosaic.Vector {
+	sg = sg.rlock()
+	defer sg.runlock()
+
+	return sg.origin
+}
+
+func (sg *SpatialGrid[T]) growToFit(position mosaic.Vector) {
+	if !sg.config.autoGrow {
+		return
+	}
+
+	x, y, ok := sg.WorldToCell(position)
+	if ok {
+		return
+	}
+
+	steps := func(missing int) int {
+		if missing <= 0 {
+			return 0
+		}
+		return (missing + autoGrowStep - 1) / autoGrowStep * autoGrowStep
+	}
+	sg.grow(steps(-x), steps(-y), steps(x-sg.SizeX+1), steps(y-sg.SizeY+1))
+}
+
+// grow adds cells on each side and remaps everything keyed by cell index
+func (sg *SpatialGrid[T]) grow(left, top, right, bottom int) {
+	sizeX, sizeY := sg.SizeX+left+right, sg.SizeY+top+bottom
+	remap := func(index int) int {
+		x, y := index%sg.SizeX, index/sg.SizeX
+		return (y+top)*sizeX + x + left
+	}
+
+	portals := map[int][]portal{}
+	for from, edges := range sg.portals {
+		moved := make([]portal, len(edges))
+		for i, p := range edges {
+			moved[i] = portal{to: remap(p.to), cost: p.cost}
+		}
+		portals[remap(from)] = moved
+	}
+	rules := map[edgeKey]EdgeRule{}
+	for key, rule := range sg.edgeRules {
+		key.from = remap(key.from)
+		rules[key] = rule
+	}
+
+	old := sg.Nodes
+	sg.origin = mosaic.NewVector(
+		sg.origin.X-float64(left)*sg.ChunkSize,
+		sg.origin.Y-float64(top)*sg.ChunkSize,
+	)
+	sg.SizeX, sg.SizeY = sizeX, sizeY
+	sg.portals, sg.edgeRules = portals, rules
+	sg.blocked = newBitset(sizeX * sizeY)
+
+	sg.Nodes = make([][]spatialGridNode[T], sizeX)
+	for x := range sg.Nodes {
+		sg.Nodes[x] = make([]spatialGridNode[T], sizeY)
+		for y := range sizeY {
+			oldX, oldY := x-left, y-top
+			if oldX < 0 || oldX >= len(old) || oldY < 0 || oldY >= len(old[oldX]) {
+				sg.Nodes[x][y] = newSpatialGridNode[T](x, y, sg.CellBounds(x, y), sg.config.capacity)
+				continue
+			}
+
+			node := old[oldX][oldY]
+			node.x, node.y = x, y
+			sg.Nodes[x][y] = node
+			sg.updateBlocked(x, y)
+		}
+	}
+}
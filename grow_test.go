@@ -0,0 +1,85 @@
+package lattice_test
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/mosaic"
+)
+
+func Test_spatial_grid_WithAutoGrow(t *testing.T) {
+	type want struct {
+		sizeX, sizeY int
+		origin       mosaic.Vector
+		blockedX     int
+		blockedY     int
+	}
+	tests := []struct {
+		name     string
+		position mosaic.Vector
+		want     want
+	}{
+		{
+			name:     "inside the grid",
+			position: mosaic.NewVector(12, 12),
+			want:     want{sizeX: 4, sizeY: 4, blockedX: 1, blockedY: 1},
+		},
+		{
+			name:     "past the far edge",
+			position: mosaic.NewVector(44, 12),
+			want:     want{sizeX: 20, sizeY: 4, blockedX: 1, blockedY: 1},
+		},
+		{
+			name:     "before the origin",
+			position: mosaic.NewVector(-4, -140),
+			want: want{
+				sizeX:    20,
+				sizeY:    36,
+				origin:   mosaic.NewVector(-128, -256),
+				blockedX: 17,
+				blockedY: 33,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg, err := lattice.NewSpatialGridOpts[int](4, 4, 8, lattice.WithAutoGrow())
+			if err != nil {
+				t.Fatal(err)
+			}
+			wall := mosaic.NewRectangle(mosaic.NewVector(12, 12), 2, 2)
+			sg.Insert(lattice.Item[int]{1, wall, math.Inf(1)})
+
+			item := mosaic.NewRectangle(tt.position, 2, 2)
+			err = sg.Insert(lattice.Item[int]{2, item, 1})
+			if err != nil {
+				t.Error(fmt.Errorf("spatialGrid.Insert() want error: %+v, got error: %+v\n", nil, err))
+			}
+
+			if sg.SizeX != tt.want.sizeX || sg.SizeY != tt.want.sizeY {
+				t.Error(fmt.Errorf("spatialGrid.Insert() want size: %+v, got: %+v\n", tt.want, sg))
+			}
+			if sg.Origin() != tt.want.origin {
+				t.Error(fmt.Errorf("spatialGrid.Origin() want: %+v, got: %+v\n", tt.want.origin, sg.Origin()))
+			}
+			if !sg.Blocked(tt.want.blockedX, tt.want.blockedY) {
+				t.Error(fmt.Errorf("spatialGrid.Blocked() want: %+v, got: %+v\n", true, false))
+			}
+			got := sg.FindNear(item)
+			if !slices.Contains(got, 2) {
+				t.Error(fmt.Errorf("spatialGrid.FindNear() want: %+v, got: %+v\n", 2, got))
+			}
+			got = sg.FindNear(wall)
+			if !slices.Contains(got, 1) {
+				t.Error(fmt.Errorf("spatialGrid.FindNear() want: %+v, got: %+v\n", 1, got))
+			}
+			x, y := sg.Location(tt.position.X, tt.position.Y)
+			if sg.CellBounds(x, y).MinPoint().X > tt.position.X || sg.CellBounds(x, y).MaxPoint().X < tt.position.X {
+				t.Error(fmt.Errorf("spatialGrid.CellBounds() want to contain: %+v, got: %+v\n", tt.position, sg.CellBounds(x, y)))
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package lattice
+
+type (
+	heapEntry struct {
+		index    int32
+		priority float64
+	}
+
+	minHeap []heapEntry
+)
+
+func (h minHeap) Len() int {
+	return len(h)
+}
+
+func (h minHeap) Push(index int32, priority float64) minHeap {
+	h = append(h, heapEntry{index: index, priority: priority})
+	return h.up(len(h) - 1)
+}
+
+func (h minHeap) Pop() (int32, minHeap) {
+	n := len(h) - 1
+	h[0], h[n] = h[n], h[0]
+	h = h.down(0, n)
+
+	entry := h[n]
+	return entry.index, h[:n]
+}
+
+func (h minHeap) up(j int) minHeap {
+	for {
+		i := (j - 1) / 2
+		if i == j || !(h[j].priority < h[i].priority) {
+			break
+		}
+		h[i], h[j] = h[j], h[i]
+		j = i
+	}
+
+	return h
+}
+
+func (h minHeap) down(i, n int) minHeap {
+	for {
+		j1 := 2*i + 1
+		if j1 >= n || j1 < 0 {
+			break
+		}
+
+		j := j1
+		j2 := j1 + 1
+		if j2 < n && h[j2].priority < h[j1].priority {
+			j = j2
+		}
+
+		if !(h[j].priority < h[i].priority) {
+			break
+		}
+		h[i], h[j] = h[j], h[i]
+		i = j
+	}
+
+	return h
+}
+
+// indexedHeap holds every search state at most once, so a cheaper route to
+// a queued state moves its entry up instead of queueing a stale copy. pos is
+// never cleared between searches, an entry only counts as queued while the
+// slot it points at still holds it.
+type indexedHeap struct {
+	entries []heapEntry
+	pos     []int32
+}
+
+func (h *indexedHeap) reset(states int) {
+	h.entries = h.entries[:0]
+	if len(h.pos) < states {
+		h.pos = make([]int32, states)
+	}
+}
+
+func (h *indexedHeap) Len() int {
+	return len(h.entries)
+}
+
+func (h *indexedHeap) queued(index int32) bool {
+	i := h.pos[index]
+	return i >= 0 && int(i) < len(h.entries) && h.entries[i].index == index
+}
+
+// Push queues index, or lowers its priority if it is already queued
+func (h *indexedHeap) Push(index int32, priority float64) {
+	if h.queued(index) {
+		i := int(h.pos[index])
+		if priority < h.entries[i].priority {
+			h.entries[i].priority = priority
+			h.up(i)
+		}
+		return
+	}
+
+	h.entries = append(h.entries, heapEntry{index: index, priority: priority})
+	h.pos[index] = int32(len(h.entries) - 1)
+	h.up(len(h.entries) - 1)
+}
+
+func (h *indexedHeap) Pop() int32 {
+	n := len(h.entries) - 1
+	h.swap(0, n)
+	entry := h.entries[n]
+	h.entries = h.entries[:n]
+	h.pos[entry.index] = -1
+	h.down(0)
+
+	return entry.index
+}
+
+func (h *indexedHeap) swap(i, j int) {
+	h.entries[i], h.entries[j] = h.entries[j], h.entries[i]
+	h.pos[h.entries[i].index] = int32(i)
+	h.pos[h.entries[j].index] = int32(j)
+}
+
+func (h *indexedHeap) up(j int) {
+	for j > 0 {
+		i := (j - 1) / 2
+		if !(h.entries[j].priority < h.entries[i].priority) {
+			break
+		}
+		h.swap(i, j)
+		j = i
+	}
+}
+
+func (h *indexedHeap) down(i int) {
+	n := len(h.entries)
+	for {
+		j := 2*i + 1
+		if j >= n {
+			break
+		}
+		if j+1 < n && h.entries[j+1].priority < h.entries[j].priority {
+			j++
+		}
+		if !(h.entries[j].priority < h.entries[i].priority) {
+			break
+		}
+		h.swap(i, j)
+		i = j
+	}
+}
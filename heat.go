@@ -0,0 +1,91 @@
+package lattice
+
+import (
+	"math"
+
+	"github.com/maladroitthief/mosaic"
+)
+
+type HeatFalloff int
+
+const (
+	LinearFalloff HeatFalloff = iota
+	GaussianFalloff
+)
+
+func (sg *SpatialGrid[T]) SetHeatFalloff(falloff HeatFalloff) {
+	sg.lock()
+	defer sg.unlock()
+
+	sg.heatCurve = falloff
+}
+
+func (sg *SpatialGrid[T]) SetHeatCooling(rate float64) {
+	sg.lock()
+	defer sg.unlock()
+
+	sg.heatCool = rate
+}
+
+// AddHeat deposits amount in the cell holding x, y and spreads it over the
+// cells whose centers lie within radius, scaled by the falloff
+func (sg *SpatialGrid[T]) AddHeat(x, y, amount, radius float64) {
+	sg.lock()
+	defer sg.unlock()
+
+	center := mosaic.NewVector(x, y)
+	xIndex, yIndex := sg.Location(x, y)
+	sg.Nodes[xIndex][yIndex].heat += amount
+	if radius <= 0 {
+		return
+	}
+
+	xMinIndex, yMinIndex := sg.Location(x-radius, y-radius)
+	xMaxIndex, yMaxIndex := sg.Location(x+radius, y+radius)
+	for iX := xMinIndex; iX <= xMaxIndex; iX++ {
+		for iY := yMinIndex; iY <= yMaxIndex; iY++ {
+			if iX == xIndex && iY == yIndex {
+				continue
+			}
+			distance := sg.Nodes[iX][iY].bounds.Position.Distance(center)
+			if distance > radius {
+				continue
+			}
+			sg.Nodes[iX][iY].heat += amount * sg.heatCurve.scale(distance, radius)
+		}
+	}
+}
+
+func (sg *SpatialGrid[T]) Heat(x, y float64) float64 {
+	sg = sg.rlock()
+	defer sg.runlock()
+
+	xIndex, yIndex := sg.Location(x, y)
+	return sg.Nodes[xIndex][yIndex].heat
+}
+
+func (sg *SpatialGrid[T]) Cool(dt float64) {
+	sg.lock()
+	defer sg.unlock()
+
+	cooling := sg.heatCool * dt
+	for x := range sg.Nodes {
+		for y := range sg.Nodes[x] {
+			sg.Nodes[x][y].heat = max(sg.Nodes[x][y].heat-cooling, 0)
+		}
+	}
+}
+
+func (sgn spatialGridNode[T]) Heat() float64 {
+	return sgn.heat
+}
+
+func (hf HeatFalloff) scale(distance, radius float64) float64 {
+	switch hf {
+	case GaussianFalloff:
+		sigma := radius / 2
+		return math.Exp(-(distance * distance) / (2 * sigma * sigma))
+	default:
+		return 1 - distance/radius
+	}
+}
@@ -0,0 +1,83 @@
+package lattice_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+)
+
+func Test_spatial_grid_AddHeat(t *testing.T) {
+	type params struct {
+		falloff lattice.HeatFalloff
+		amount  float64
+		radius  float64
+		cooling float64
+		dt      float64
+	}
+	type want struct {
+		x    float64
+		y    float64
+		heat float64
+	}
+	tests := []struct {
+		name   string
+		params params
+		wants  []want
+	}{
+		{
+			name:   "linear",
+			params: params{falloff: lattice.LinearFalloff, amount: 10, radius: 16},
+			wants: []want{
+				{x: 20, y: 20, heat: 10},
+				{x: 28, y: 20, heat: 5},
+				{x: 4, y: 20, heat: 0},
+			},
+		},
+		{
+			name:   "gaussian",
+			params: params{falloff: lattice.GaussianFalloff, amount: 10, radius: 16},
+			wants: []want{
+				{x: 20, y: 20, heat: 10},
+				{x: 28, y: 20, heat: 10 * math.Exp(-0.5)},
+				{x: 4, y: 20, heat: 10 * math.Exp(-2)},
+			},
+		},
+		{
+			name:   "point with cooling",
+			params: params{falloff: lattice.LinearFalloff, amount: 10, radius: 0, cooling: 3, dt: 2},
+			wants: []want{
+				{x: 20, y: 20, heat: 4},
+				{x: 28, y: 20, heat: 0},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg := lattice.NewSpatialGrid[int](5, 5, 8)
+			sg.SetHeatFalloff(tt.params.falloff)
+			sg.SetHeatCooling(tt.params.cooling)
+			sg.AddHeat(20, 20, tt.params.amount, tt.params.radius)
+			sg.Cool(tt.params.dt)
+
+			for _, want := range tt.wants {
+				got := sg.Heat(want.x, want.y)
+				if math.Abs(want.heat-got) > 1e-9 {
+					t.Error(fmt.Errorf("spatialGrid.Heat(%v, %v) want: %+v, got: %+v\n", want.x, want.y, want.heat, got))
+				}
+			}
+		})
+	}
+}
+
+// a radius smaller than the distance to the cell center still heats the
+// cell the deposit lands in
+func Test_spatial_grid_AddHeat_off_center(t *testing.T) {
+	sg := lattice.NewSpatialGrid[int](4, 4, 32)
+	sg.AddHeat(5, 5, 10, 1)
+
+	if got := sg.Heat(5, 5); got != 10 {
+		t.Error(fmt.Errorf("spatialGrid.Heat(5, 5) want: %+v, got: %+v\n", 10, got))
+	}
+}
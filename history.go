@@ -0,0 +1,123 @@
+package lattice
+
+import (
+	"slices"
+
+	"github.com/maladroitthief/mosaic"
+)
+
+type (
+	CellChange int
+
+	// CellEvent is one recorded mutation of a cell, stamped with the tick
+	// set by SetHistoryTick when it happened
+	CellEvent[T comparable] struct {
+		Tick   uint64
+		Change CellChange
+		Value  T
+		Bounds mosaic.Rectangle
+	}
+
+	// cellHistory is a ring of the last len(events) mutations of one cell,
+	// next is where the following event goes
+	cellHistory[T comparable] struct {
+		events []CellEvent[T]
+		next   int
+		full   bool
+	}
+)
+
+const (
+	CellInserted CellChange = iota
+	CellDeleted
+)
+
+// WithHistory keeps the last depth inserts and deletes of every cell for
+// History. Buffers are allocated the first time a cell changes. Spilled items
+// never reach a cell and are not recorded.
+func WithHistory(depth int) Option {
+	return func(c *config) error {
+		if depth <= 0 {
+			return ErrInvalidOption
+		}
+
+		c.history = depth
+		return nil
+	}
+}
+
+// SetHistoryTick stamps every mutation recorded from now on with tick
+func (sg *SpatialGrid[T]) SetHistoryTick(tick uint64) {
+	sg.lock()
+	defer sg.unlock()
+
+	sg.stamp = tick
+}
+
+// History returns the recorded mutations of cell x, y stamped at or after
+// since, oldest first. Repartition starts every cell's history afresh.
+func (sg *SpatialGrid[T]) History(x, y int, since uint64) []CellEvent[T] {
+	sg = sg.rlock()
+	defer sg.runlock()
+
+	events := []CellEvent[T]{}
+	if !sg.inBounds(x, y) || sg.Nodes[x][y].history == nil {
+		return events
+	}
+
+	h := sg.Nodes[x][y].history
+	start, count := 0, h.next
+	if h.full {
+		start, count = h.next, len(h.events)
+	}
+	for i := 0; i < count; i++ {
+		event := h.events[(start+i)%len(h.events)]
+		if event.Tick >= since {
+			events = append(events, event)
+		}
+	}
+
+	return events
+}
+
+// record adds a mutation to the node's history
+func (sg *SpatialGrid[T]) record(sgn spatialGridNode[T], change CellChange, value T, bounds mosaic.Rectangle) spatialGridNode[T] {
+	if sg.config.history == 0 {
+		return sgn
+	}
+
+	if sgn.history == nil {
+		sgn.history = &cellHistory[T]{events: make([]CellEvent[T], sg.config.history)}
+	}
+	h := sgn.history
+	h.events[h.next] = CellEvent[T]{Tick: sg.stamp, Change: change, Value: value, Bounds: bounds}
+	h.next++
+	if h.next == len(h.events) {
+		h.next, h.full = 0, true
+	}
+
+	return sgn
+}
+
+// recordRemoved records a delete for every item of the node from index from on
+func (sg *SpatialGrid[T]) recordRemoved(sgn spatialGridNode[T], from int) spatialGridNode[T] {
+	if sg.config.history == 0 {
+		return sgn
+	}
+
+	for i := from; i < len(sgn.Items); i++ {
+		sgn = sg.record(sgn, CellDeleted, sgn.Items[i].value, sgn.Items[i].bounds)
+	}
+
+	return sgn
+}
+
+func (h *cellHistory[T]) clone() *cellHistory[T] {
+	if h == nil {
+		return nil
+	}
+
+	c := *h
+	c.events = slices.Clone(h.events)
+	return &c
+}
@@ -0,0 +1,146 @@
+package lattice_test
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/mosaic"
+)
+
+func Test_spatial_grid_History(t *testing.T) {
+	a := mosaic.NewRectangle(mosaic.NewVector(4, 4), 2, 2)
+	b := mosaic.NewRectangle(mosaic.NewVector(12, 4), 2, 2)
+	c := mosaic.NewRectangle(mosaic.NewVector(6, 5), 2, 2)
+	type want struct {
+		cell   lattice.Cell
+		since  uint64
+		events []lattice.CellEvent[int]
+	}
+	tests := []struct {
+		name   string
+		opts   []lattice.Option
+		mutate func(sg *lattice.SpatialGrid[int])
+		want   []want
+	}{
+		{
+			name: "insert move and delete",
+			opts: []lattice.Option{lattice.WithHistory(8)},
+			mutate: func(sg *lattice.SpatialGrid[int]) {
+				sg.SetHistoryTick(1)
+				sg.Insert(lattice.Item[int]{1, a, 1})
+				sg.SetHistoryTick(2)
+				sg.Update(lattice.Item[int]{1, b, 1}, a)
+				sg.SetHistoryTick(3)
+				sg.Delete(1, b)
+			},
+			want: []want{
+				{cell: lattice.Cell{X: 0, Y: 0}, events: []lattice.CellEvent[int]{
+					{Tick: 1, Change: lattice.CellInserted, Value: 1, Bounds: a},
+					{Tick: 2, Change: lattice.CellDeleted, Value: 1, Bounds: a},
+				}},
+				{cell: lattice.Cell{X: 1, Y: 0}, since: 3, events: []lattice.CellEvent[int]{
+					{Tick: 3, Change: lattice.CellDeleted, Value: 1, Bounds: b},
+				}},
+				{cell: lattice.Cell{X: 1, Y: 1}, events: []lattice.CellEvent[int]{}},
+			},
+		},
+		{
+			name: "ring keeps the latest",
+			opts: []lattice.Option{lattice.WithHistory(2)},
+			mutate: func(sg *lattice.SpatialGrid[int]) {
+				for i := 1; i <= 3; i++ {
+					sg.SetHistoryTick(uint64(i))
+					sg.Insert(lattice.Item[int]{i, a, 1})
+				}
+			},
+			want: []want{
+				{cell: lattice.Cell{X: 0, Y: 0}, events: []lattice.CellEvent[int]{
+					{Tick: 2, Change: lattice.CellInserted, Value: 2, Bounds: a},
+					{Tick: 3, Change: lattice.CellInserted, Value: 3, Bounds: a},
+				}},
+			},
+		},
+		{
+			name: "drop keeps pinned out",
+			opts: []lattice.Option{lattice.WithHistory(4), lattice.WithLocking(lattice.LockingReadOptimized)},
+			mutate: func(sg *lattice.SpatialGrid[int]) {
+				sg.InsertPinned(lattice.Item[int]{1, a, 1})
+				sg.Insert(lattice.Item[int]{2, a, 1})
+				sg.SetHistoryTick(5)
+				sg.Drop()
+			},
+			want: []want{
+				{cell: lattice.Cell{X: 0, Y: 0}, since: 5, events: []lattice.CellEvent[int]{
+					{Tick: 5, Change: lattice.CellDeleted, Value: 2, Bounds: a},
+				}},
+			},
+		},
+		{
+			name: "expire",
+			opts: []lattice.Option{lattice.WithHistory(4)},
+			mutate: func(sg *lattice.SpatialGrid[int]) {
+				now := time.Unix(0, 0)
+				sg.InsertWithTTL(lattice.Item[int]{1, a, 1}, now, time.Second)
+				sg.SetHistoryTick(7)
+				sg.Expire(now.Add(time.Minute))
+			},
+			want: []want{
+				{cell: lattice.Cell{X: 0, Y: 0}, since: 7, events: []lattice.CellEvent[int]{
+					{Tick: 7, Change: lattice.CellDeleted, Value: 1, Bounds: a},
+				}},
+			},
+		},
+		{
+			name: "batch move within a cell",
+			opts: []lattice.Option{lattice.WithHistory(8)},
+			mutate: func(sg *lattice.SpatialGrid[int]) {
+				sg.SetHistoryTick(1)
+				sg.Insert(lattice.Item[int]{1, a, 1})
+				sg.SetHistoryTick(2)
+				sg.UpdateBatch([]lattice.BoundsUpdate[int]{{Value: 1, OldBounds: a, NewBounds: c, Multiplier: 1}})
+			},
+			want: []want{
+				{cell: lattice.Cell{X: 0, Y: 0}, since: 2, events: []lattice.CellEvent[int]{
+					{Tick: 2, Change: lattice.CellDeleted, Value: 1, Bounds: a},
+					{Tick: 2, Change: lattice.CellInserted, Value: 1, Bounds: c},
+				}},
+			},
+		},
+		{
+			name: "off by default",
+			mutate: func(sg *lattice.SpatialGrid[int]) {
+				sg.Insert(lattice.Item[int]{1, a, 1})
+			},
+			want: []want{
+				{cell: lattice.Cell{X: 0, Y: 0}, events: []lattice.CellEvent[int]{}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg, err := lattice.NewSpatialGridOpts[int](2, 2, 8, tt.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.mutate(sg)
+
+			for _, w := range tt.want {
+				got := sg.History(w.cell.X, w.cell.Y, w.since)
+				if !slices.Equal(w.events, got) {
+					t.Error(fmt.Errorf("spatialGrid.History() want: %+v, got: %+v\n", w.events, got))
+				}
+			}
+		})
+	}
+}
+
+func Test_spatial_grid_WithHistory(t *testing.T) {
+	_, err := lattice.NewSpatialGridOpts[int](2, 2, 8, lattice.WithHistory(0))
+	if !errors.Is(err, lattice.ErrInvalidOption) {
+		t.Error(fmt.Errorf("lattice.WithHistory() want: %+v, got: %+v\n", lattice.ErrInvalidOption, err))
+	}
+}
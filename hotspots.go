@@ -0,0 +1,73 @@
+package lattice
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+)
+
+type (
+	// hotspotIndex ranks the occupied cells, rebuilt by the first read after
+	// a write. It has its own lock so concurrent readers can share one
+	// rebuild.
+	hotspotIndex struct {
+		mu       sync.Mutex
+		at       uint64
+		built    bool
+		byCount  []CellSummary
+		byWeight []CellSummary
+	}
+)
+
+// Hotspots returns up to n occupied cells with the most items, breaking ties
+// by weight and then index order, for overlays and picks like the most
+// crowded spot to target.
+func (sg *SpatialGrid[T]) Hotspots(n int) []CellSummary {
+	sg = sg.rlock()
+	defer sg.runlock()
+
+	byCount, _ := sg.hotspotRanks()
+	return slices.Clone(byCount[:min(max(n, 0), len(byCount))])
+}
+
+// HotspotsByWeight is Hotspots ranked by cell weight, then item count
+func (sg *SpatialGrid[T]) HotspotsByWeight(n int) []CellSummary {
+	sg = sg.rlock()
+	defer sg.runlock()
+
+	_, byWeight := sg.hotspotRanks()
+	return slices.Clone(byWeight[:min(max(n, 0), len(byWeight))])
+}
+
+// hotspotRanks returns both rankings, rebuilding them if the grid was
+// written since they were last built
+func (sg *SpatialGrid[T]) hotspotRanks() ([]CellSummary, []CellSummary) {
+	h := &sg.hotspots
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.built && h.at == sg.writes {
+		return h.byCount, h.byWeight
+	}
+
+	h.byCount = h.byCount[:0]
+	for y := 0; y < sg.SizeY; y++ {
+		for x := 0; x < sg.SizeX; x++ {
+			if len(sg.Nodes[x][y].Items) > 0 {
+				h.byCount = append(h.byCount, sg.Nodes[x][y].summary())
+			}
+		}
+	}
+	h.byWeight = append(h.byWeight[:0], h.byCount...)
+
+	// stable sorts keep index order among full ties
+	slices.SortStableFunc(h.byCount, func(a, b CellSummary) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), cmp.Compare(b.Weight, a.Weight))
+	})
+	slices.SortStableFunc(h.byWeight, func(a, b CellSummary) int {
+		return cmp.Or(cmp.Compare(b.Weight, a.Weight), cmp.Compare(b.Count, a.Count))
+	})
+	h.at, h.built = sg.writes, true
+
+	return h.byCount, h.byWeight
+}
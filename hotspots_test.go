@@ -0,0 +1,126 @@
+package lattice_test
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/mosaic"
+)
+
+func Test_spatial_grid_Hotspots(t *testing.T) {
+	type params struct {
+		item       int
+		position   mosaic.Vector
+		multiplier float64
+	}
+	items := []params{
+		{item: 1, position: mosaic.Vector{X: 12, Y: 12}, multiplier: 1},
+		{item: 2, position: mosaic.Vector{X: 10, Y: 13}, multiplier: 1},
+		{item: 3, position: mosaic.Vector{X: 13, Y: 10}, multiplier: 1},
+		{item: 4, position: mosaic.Vector{X: 20, Y: 4}, multiplier: 1},
+		{item: 5, position: mosaic.Vector{X: 21, Y: 5}, multiplier: 1},
+		{item: 6, position: mosaic.Vector{X: 4, Y: 28}, multiplier: 10},
+	}
+	tests := []struct {
+		name     string
+		opts     []lattice.Option
+		n        int
+		byCount  []lattice.CellSummary
+		byWeight []lattice.CellSummary
+	}{
+		{
+			name: "top two",
+			n:    2,
+			byCount: []lattice.CellSummary{
+				{X: 1, Y: 1, Count: 3, Weight: 12},
+				{X: 2, Y: 0, Count: 2, Weight: 8},
+			},
+			byWeight: []lattice.CellSummary{
+				{X: 0, Y: 3, Count: 1, Weight: 40},
+				{X: 1, Y: 1, Count: 3, Weight: 12},
+			},
+		},
+		{
+			name: "more than occupied",
+			n:    10,
+			byCount: []lattice.CellSummary{
+				{X: 1, Y: 1, Count: 3, Weight: 12},
+				{X: 2, Y: 0, Count: 2, Weight: 8},
+				{X: 0, Y: 3, Count: 1, Weight: 40},
+			},
+			byWeight: []lattice.CellSummary{
+				{X: 0, Y: 3, Count: 1, Weight: 40},
+				{X: 1, Y: 1, Count: 3, Weight: 12},
+				{X: 2, Y: 0, Count: 2, Weight: 8},
+			},
+		},
+		{
+			name: "read optimized",
+			opts: []lattice.Option{lattice.WithReadOptimized()},
+			n:    1,
+			byCount: []lattice.CellSummary{
+				{X: 1, Y: 1, Count: 3, Weight: 12},
+			},
+			byWeight: []lattice.CellSummary{
+				{X: 0, Y: 3, Count: 1, Weight: 40},
+			},
+		},
+		{
+			name:     "zero",
+			n:        0,
+			byCount:  []lattice.CellSummary{},
+			byWeight: []lattice.CellSummary{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg, err := lattice.NewSpatialGridOpts[int](4, 4, 8, tt.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, item := range items {
+				sg.Insert(lattice.Item[int]{item.item, mosaic.NewRectangle(item.position, 2, 2), item.multiplier})
+			}
+
+			got := sg.Hotspots(tt.n)
+			if !slices.Equal(tt.byCount, got) {
+				t.Error(fmt.Errorf("spatialGrid.Hotspots() want: %+v, got: %+v\n", tt.byCount, got))
+			}
+			got = sg.HotspotsByWeight(tt.n)
+			if !slices.Equal(tt.byWeight, got) {
+				t.Error(fmt.Errorf("spatialGrid.HotspotsByWeight() want: %+v, got: %+v\n", tt.byWeight, got))
+			}
+		})
+	}
+}
+
+func Test_spatial_grid_Hotspots_after_write(t *testing.T) {
+	sg := lattice.NewSpatialGrid[int](4, 4, 8)
+	sg.Insert(lattice.Item[int]{1, mosaic.NewRectangle(mosaic.Vector{X: 12, Y: 12}, 2, 2), 1})
+	sg.Insert(lattice.Item[int]{2, mosaic.NewRectangle(mosaic.Vector{X: 13, Y: 12}, 2, 2), 1})
+	sg.Insert(lattice.Item[int]{3, mosaic.NewRectangle(mosaic.Vector{X: 20, Y: 4}, 2, 2), 1})
+
+	want := []lattice.CellSummary{{X: 1, Y: 1, Count: 2, Weight: 8}}
+	if got := sg.Hotspots(1); !slices.Equal(want, got) {
+		t.Error(fmt.Errorf("spatialGrid.Hotspots() want: %+v, got: %+v\n", want, got))
+	}
+
+	sg.Insert(lattice.Item[int]{4, mosaic.NewRectangle(mosaic.Vector{X: 21, Y: 4}, 2, 2), 1})
+	sg.Insert(lattice.Item[int]{5, mosaic.NewRectangle(mosaic.Vector{X: 20, Y: 5}, 2, 2), 1})
+
+	want = []lattice.CellSummary{{X: 2, Y: 0, Count: 3, Weight: 12}}
+	if got := sg.Hotspots(1); !slices.Equal(want, got) {
+		t.Error(fmt.Errorf("spatialGrid.Hotspots() want: %+v, got: %+v\n", want, got))
+	}
+
+	sg.Delete(4, mosaic.NewRectangle(mosaic.Vector{X: 21, Y: 4}, 2, 2))
+	sg.Delete(5, mosaic.NewRectangle(mosaic.Vector{X: 20, Y: 5}, 2, 2))
+	sg.Delete(3, mosaic.NewRectangle(mosaic.Vector{X: 20, Y: 4}, 2, 2))
+
+	want = []lattice.CellSummary{{X: 1, Y: 1, Count: 2, Weight: 8}}
+	if got := sg.Hotspots(5); !slices.Equal(want, got) {
+		t.Error(fmt.Errorf("spatialGrid.Hotspots() want: %+v, got: %+v\n", want, got))
+	}
+}
@@ -0,0 +1,93 @@
+package lattice
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+type (
+	// InsertQueue stages inserts from many goroutines without touching the
+	// grid's write lock. Producers are spread over shards that each have
+	// their own mutex, so they rarely wait on one another, and Flush applies
+	// everything staged under a single write lock. Staged items are not
+	// visible to queries until then.
+	InsertQueue[T comparable] struct {
+		grid   *SpatialGrid[T]
+		shards []insertShard[T]
+		next   atomic.Uint32
+	}
+
+	insertShard[T comparable] struct {
+		mu    sync.Mutex
+		items []Item[T]
+		// keeps neighboring shards off the same cache line
+		_ [32]byte
+	}
+)
+
+// NewInsertQueue returns a queue with the given number of shards, one per
+// CPU when shards is below 1
+func (sg *SpatialGrid[T]) NewInsertQueue(shards int) *InsertQueue[T] {
+	if shards < 1 {
+		shards = runtime.GOMAXPROCS(0)
+	}
+
+	return &InsertQueue[T]{grid: sg, shards: make([]insertShard[T], shards)}
+}
+
+// Insert stages item for the next Flush and is safe to call concurrently
+func (q *InsertQueue[T]) Insert(item Item[T]) {
+	shard := &q.shards[int(q.next.Add(1))%len(q.shards)]
+	shard.mu.Lock()
+	shard.items = append(shard.items, item)
+	shard.mu.Unlock()
+}
+
+// Len is how many items are staged
+func (q *InsertQueue[T]) Len() int {
+	n := 0
+	for i := range q.shards {
+		q.shards[i].mu.Lock()
+		n += len(q.shards[i].items)
+		q.shards[i].mu.Unlock()
+	}
+
+	return n
+}
+
+// Flush inserts every staged item it can under one write lock and reports
+// the last insert error, like Reset. Inserts staged while Flush runs wait
+// for the next one.
+func (q *InsertQueue[T]) Flush() error {
+	batches := make([][]Item[T], len(q.shards))
+	staged := 0
+	for i := range q.shards {
+		q.shards[i].mu.Lock()
+		batches[i] = q.shards[i].items
+		q.shards[i].items = make([]Item[T], 0, len(batches[i]))
+		q.shards[i].mu.Unlock()
+		staged += len(batches[i])
+	}
+	if staged == 0 {
+		return nil
+	}
+
+	sg := q.grid
+	sg.lock()
+	defer sg.unlock()
+
+	var err error
+	for _, batch := range batches {
+		for _, item := range batch {
+			insertErr := sg.insert(item)
+			if insertErr != nil {
+				err = insertErr
+				continue
+			}
+			sg.track(item.Value, item.Bounds, true)
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,119 @@
+package lattice_test
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/mosaic"
+)
+
+func Test_spatial_grid_InsertQueue(t *testing.T) {
+	tests := []struct {
+		name      string
+		shards    int
+		producers int
+		each      int
+	}{
+		{name: "single shard", shards: 1, producers: 4, each: 250},
+		{name: "many shards", shards: 8, producers: 8, each: 250},
+		{name: "default shards", shards: 0, producers: 4, each: 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg := lattice.NewSpatialGrid[int](8, 8, 8)
+			q := sg.NewInsertQueue(tt.shards)
+
+			var wg sync.WaitGroup
+			for p := 0; p < tt.producers; p++ {
+				wg.Add(1)
+				go func(p int) {
+					defer wg.Done()
+					for i := 0; i < tt.each; i++ {
+						id := p*tt.each + i
+						position := mosaic.NewVector(float64(id%64), float64(id/64%64))
+						q.Insert(lattice.Item[int]{id, mosaic.NewRectangle(position, 1, 1), 1})
+					}
+				}(p)
+			}
+			wg.Wait()
+
+			want := tt.producers * tt.each
+			if got := q.Len(); got != want {
+				t.Error(fmt.Errorf("InsertQueue.Len() want: %+v, got: %+v\n", want, got))
+			}
+			if got := sg.Size(); got != 0 {
+				t.Error(fmt.Errorf("spatialGrid.Size() before Flush want: %+v, got: %+v\n", 0, got))
+			}
+
+			err := q.Flush()
+			if err != nil {
+				t.Fatal(fmt.Errorf("InsertQueue.Flush() want: %+v, got: %+v\n", nil, err))
+			}
+			if got := sg.Size(); got != want {
+				t.Error(fmt.Errorf("spatialGrid.Size() want: %+v, got: %+v\n", want, got))
+			}
+			if got := q.Len(); got != 0 {
+				t.Error(fmt.Errorf("InsertQueue.Len() after Flush want: %+v, got: %+v\n", 0, got))
+			}
+		})
+	}
+}
+
+func Test_spatial_grid_InsertQueue_strict(t *testing.T) {
+	sg, err := lattice.NewSpatialGridOpts[int](4, 4, 8, lattice.WithStrictBounds())
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := sg.NewInsertQueue(2)
+	q.Insert(lattice.Item[int]{1, mosaic.NewRectangle(mosaic.NewVector(4, 4), 2, 2), 1})
+	q.Insert(lattice.Item[int]{2, mosaic.NewRectangle(mosaic.NewVector(-40, 4), 2, 2), 1})
+	q.Insert(lattice.Item[int]{3, mosaic.NewRectangle(mosaic.NewVector(20, 20), 2, 2), 1})
+
+	err = q.Flush()
+	if !errors.Is(err, lattice.ErrOutOfBounds) {
+		t.Error(fmt.Errorf("InsertQueue.Flush() want: %+v, got: %+v\n", lattice.ErrOutOfBounds, err))
+	}
+	if got := sg.Size(); got != 2 {
+		t.Error(fmt.Errorf("spatialGrid.Size() want: %+v, got: %+v\n", 2, got))
+	}
+	if err := q.Flush(); err != nil {
+		t.Error(fmt.Errorf("InsertQueue.Flush() want: %+v, got: %+v\n", nil, err))
+	}
+}
+
+// BenchmarkSpatialGridInsertContention compares producers fighting over the
+// grid's write lock with producers staging into an InsertQueue, including
+// the final Flush
+func BenchmarkSpatialGridInsertContention(b *testing.B) {
+	item := func(r *rand.Rand, i int) lattice.Item[int] {
+		x := r.Float64() * GridX * GridSize
+		y := r.Float64() * GridY * GridSize
+		return lattice.Item[int]{i, mosaic.NewRectangle(mosaic.Vector{X: x, Y: y}, 4, 4), 1}
+	}
+
+	b.Run("write lock", func(b *testing.B) {
+		sg := lattice.NewSpatialGrid[int](GridX, GridY, GridSize)
+		b.RunParallel(func(pb *testing.PB) {
+			r := rand.New(rand.NewSource(rand.Int63()))
+			for i := 0; pb.Next(); i++ {
+				sg.Insert(item(r, i))
+			}
+		})
+	})
+
+	b.Run("insert queue", func(b *testing.B) {
+		sg := lattice.NewSpatialGrid[int](GridX, GridY, GridSize)
+		q := sg.NewInsertQueue(0)
+		b.RunParallel(func(pb *testing.PB) {
+			r := rand.New(rand.NewSource(rand.Int63()))
+			for i := 0; pb.Next(); i++ {
+				q.Insert(item(r, i))
+			}
+		})
+		q.Flush()
+	})
+}
@@ -0,0 +1,160 @@
+package lattice
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/maladroitthief/mosaic"
+)
+
+type (
+	// InterestManager tracks what each player can see through one region
+	// subscription per view and batches the changes into per tick diffs
+	InterestManager[T comparable] struct {
+		mu      sync.Mutex
+		grid    *SpatialGrid[T]
+		players map[int]*interest[T]
+	}
+
+	InterestDiff[T comparable] struct {
+		Player  int
+		Entered []T
+		Left    []T
+	}
+
+	interest[T comparable] struct {
+		subscription int
+		view         *interestView
+		members      map[T]struct{}
+		visible      map[T]struct{}
+		dirty        map[T]struct{}
+		touched      []T
+	}
+
+	// interestView tells events from a player's current subscription apart
+	// from ones still in flight for a view it replaced
+	interestView struct{}
+)
+
+func NewInterestManager[T comparable](sg *SpatialGrid[T]) *InterestManager[T] {
+	return &InterestManager[T]{
+		grid:    sg,
+		players: map[int]*interest[T]{},
+	}
+}
+
+// SetView moves player's view, adding the player on first use
+func (im *InterestManager[T]) SetView(player int, view mosaic.Rectangle) {
+	im.mu.Lock()
+	p, ok := im.players[player]
+	if !ok {
+		p = &interest[T]{
+			members: map[T]struct{}{},
+			visible: map[T]struct{}{},
+			dirty:   map[T]struct{}{},
+		}
+		im.players[player] = p
+	}
+	old := p.subscription
+	token := &interestView{}
+	p.view = token
+	// the new subscription re-enters whatever is still in view, so anything
+	// it leaves out goes out in the next diff
+	for value := range p.members {
+		p.change(value, false)
+	}
+	im.mu.Unlock()
+
+	if old != 0 {
+		im.grid.Unsubscribe(old)
+	}
+	id := im.grid.Subscribe(view, func(event RegionEvent[T]) {
+		im.mu.Lock()
+		defer im.mu.Unlock()
+
+		if p.view == token {
+			p.change(event.Value, event.Change == RegionEnter)
+		}
+	})
+
+	im.mu.Lock()
+	if p.view == token {
+		p.subscription = id
+	} else {
+		// a later SetView or RemovePlayer already replaced this view
+		defer im.grid.Unsubscribe(id)
+	}
+	im.mu.Unlock()
+}
+
+// RemovePlayer stops tracking player, whose pending changes are discarded
+func (im *InterestManager[T]) RemovePlayer(player int) {
+	im.mu.Lock()
+	p, ok := im.players[player]
+	if !ok {
+		im.mu.Unlock()
+		return
+	}
+	delete(im.players, player)
+	p.view = nil
+	id := p.subscription
+	im.mu.Unlock()
+
+	if id != 0 {
+		im.grid.Unsubscribe(id)
+	}
+}
+
+// Tick returns what entered and left each player's view since the last
+// Tick, ordered by player. Values that came and went in between are left
+// out, as are players with nothing to report.
+func (im *InterestManager[T]) Tick() []InterestDiff[T] {
+	im.mu.Lock()
+	defer im.mu.Unlock()
+
+	players := make([]int, 0, len(im.players))
+	for player := range im.players {
+		players = append(players, player)
+	}
+	slices.Sort(players)
+
+	diffs := []InterestDiff[T]{}
+	for _, player := range players {
+		p := im.players[player]
+		diff := InterestDiff[T]{Player: player}
+		for _, value := range p.touched {
+			_, now := p.members[value]
+			_, was := p.visible[value]
+			switch {
+			case now && !was:
+				p.visible[value] = struct{}{}
+				diff.Entered = append(diff.Entered, value)
+			case was && !now:
+				delete(p.visible, value)
+				diff.Left = append(diff.Left, value)
+			}
+		}
+		clear(p.dirty)
+		p.touched = p.touched[:0]
+
+		if len(diff.Entered) > 0 || len(diff.Left) > 0 {
+			diffs = append(diffs, diff)
+		}
+	}
+
+	return diffs
+}
+
+func (p *interest[T]) change(value T, inside bool) {
+	if inside {
+		p.members[value] = struct{}{}
+	} else {
+		delete(p.members, value)
+	}
+
+	_, ok := p.dirty[value]
+	if !ok {
+		p.dirty[value] = struct{}{}
+		p.touched = append(p.touched, value)
+	}
+}
@@ -0,0 +1,98 @@
+package lattice_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/mosaic"
+)
+
+func Test_interest_manager_Tick(t *testing.T) {
+	square := func(x, y float64) mosaic.Rectangle {
+		return mosaic.NewRectangle(mosaic.NewVector(x, y), 8, 8)
+	}
+	west := mosaic.NewRectangle(mosaic.NewVector(32, 64), 64, 128)
+	east := mosaic.NewRectangle(mosaic.NewVector(96, 64), 64, 128)
+	tests := []struct {
+		name  string
+		ticks []func(sg *lattice.SpatialGrid[int], im *lattice.InterestManager[int])
+		want  [][]lattice.InterestDiff[int]
+	}{
+		{
+			name: "initial view and inserts",
+			ticks: []func(sg *lattice.SpatialGrid[int], im *lattice.InterestManager[int]){
+				func(sg *lattice.SpatialGrid[int], im *lattice.InterestManager[int]) {
+					sg.Insert(lattice.Item[int]{1, square(16, 16), 1})
+					im.SetView(1, west)
+					im.SetView(2, east)
+				},
+				func(sg *lattice.SpatialGrid[int], im *lattice.InterestManager[int]) {
+					sg.Insert(lattice.Item[int]{2, square(112, 16), 1})
+				},
+				func(sg *lattice.SpatialGrid[int], im *lattice.InterestManager[int]) {},
+			},
+			want: [][]lattice.InterestDiff[int]{
+				{{Player: 1, Entered: []int{1}}},
+				{{Player: 2, Entered: []int{2}}},
+				{},
+			},
+		},
+		{
+			name: "changes within a tick cancel",
+			ticks: []func(sg *lattice.SpatialGrid[int], im *lattice.InterestManager[int]){
+				func(sg *lattice.SpatialGrid[int], im *lattice.InterestManager[int]) {
+					sg.Insert(lattice.Item[int]{1, square(16, 16), 1})
+					im.SetView(1, west)
+				},
+				func(sg *lattice.SpatialGrid[int], im *lattice.InterestManager[int]) {
+					sg.Update(lattice.Item[int]{1, square(112, 16), 1}, square(16, 16))
+					sg.Update(lattice.Item[int]{1, square(16, 16), 1}, square(112, 16))
+					sg.Insert(lattice.Item[int]{2, square(16, 48), 1})
+					sg.Delete(2, square(16, 48))
+				},
+			},
+			want: [][]lattice.InterestDiff[int]{
+				{{Player: 1, Entered: []int{1}}},
+				{},
+			},
+		},
+		{
+			name: "moving the view",
+			ticks: []func(sg *lattice.SpatialGrid[int], im *lattice.InterestManager[int]){
+				func(sg *lattice.SpatialGrid[int], im *lattice.InterestManager[int]) {
+					sg.Insert(lattice.Item[int]{1, square(16, 16), 1})
+					sg.Insert(lattice.Item[int]{2, square(64, 16), 1})
+					sg.Insert(lattice.Item[int]{3, square(112, 16), 1})
+					im.SetView(1, west)
+				},
+				func(sg *lattice.SpatialGrid[int], im *lattice.InterestManager[int]) {
+					im.SetView(1, east)
+				},
+				func(sg *lattice.SpatialGrid[int], im *lattice.InterestManager[int]) {
+					im.RemovePlayer(1)
+					sg.Delete(3, square(112, 16))
+				},
+			},
+			want: [][]lattice.InterestDiff[int]{
+				{{Player: 1, Entered: []int{1, 2}}},
+				{{Player: 1, Entered: []int{3}, Left: []int{1}}},
+				{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg := lattice.NewSpatialGrid[int](8, 8, 16)
+			im := lattice.NewInterestManager(sg)
+			for i, tick := range tt.ticks {
+				tick(sg, im)
+				got := im.Tick()
+				if !reflect.DeepEqual(tt.want[i], got) {
+					t.Error(fmt.Errorf("interestManager.Tick() want: %+v, got: %+v\n", tt.want[i], got))
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package lattice
+
+// ItemWeightAt reports how much val adds to the weight of cell x, y. Items
+// only live in the cell holding their center, so every other cell reports
+// false.
+func (sg *SpatialGrid[T]) ItemWeightAt(val T, x, y int) (float64, bool) {
+	sg = sg.rlock()
+	defer sg.runlock()
+
+	if !sg.inBounds(x, y) {
+		return 0, false
+	}
+
+	return sg.Nodes[x][y].itemWeight(val)
+}
+
+func (sgn spatialGridNode[T]) itemWeight(val T) (float64, bool) {
+	weight, found := 0.0, false
+	for i := 0; i < len(sgn.Items); i++ {
+		if sgn.Items[i].value != val {
+			continue
+		}
+		weight += sgn.Items[i].weight
+		found = true
+	}
+
+	return weight, found
+}
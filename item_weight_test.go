@@ -0,0 +1,60 @@
+package lattice_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/mosaic"
+)
+
+func Test_spatial_grid_ItemWeightAt(t *testing.T) {
+	type params struct {
+		item int
+		x    int
+		y    int
+	}
+	type want struct {
+		weight float64
+		ok     bool
+	}
+	tests := []struct {
+		name   string
+		params params
+		want   want
+	}{
+		{
+			name:   "fully inside",
+			params: params{item: 1, x: 0, y: 0},
+			want:   want{weight: 8, ok: true},
+		},
+		{
+			name:   "partial overlap",
+			params: params{item: 2, x: 1, y: 0},
+			want:   want{weight: 24 * 0.5, ok: true},
+		},
+		{
+			name:   "other item in the cell",
+			params: params{item: 1, x: 1, y: 0},
+			want:   want{weight: 0, ok: false},
+		},
+		{
+			name:   "out of bounds",
+			params: params{item: 1, x: 4, y: 0},
+			want:   want{weight: 0, ok: false},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg := lattice.NewSpatialGrid[int](4, 4, 8)
+			sg.Insert(lattice.Item[int]{1, mosaic.NewRectangle(mosaic.Vector{X: 4, Y: 4}, 2, 2), 2.0})
+			sg.Insert(lattice.Item[int]{2, mosaic.NewRectangle(mosaic.Vector{X: 10, Y: 4}, 8, 4), 0.5})
+
+			weight, ok := sg.ItemWeightAt(tt.params.item, tt.params.x, tt.params.y)
+			got := want{weight: weight, ok: ok}
+			if got != tt.want {
+				t.Error(fmt.Errorf("spatialGrid.ItemWeightAt() want: %+v, got: %+v\n", tt.want, got))
+			}
+		})
+	}
+}
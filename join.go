@@ -0,0 +1,69 @@
+package lattice
+
+import (
+	"errors"
+
+	"github.com/maladroitthief/mosaic"
+)
+
+// Join calls process for every pair of items, one from each grid, whose
+// bounds intersect, with the rectangle they share. Each cell of a is matched
+// against the cells of b its items can reach, so the grids may differ in
+// size and chunk size. Returning ErrStopSearch from process ends the join
+// early without an error.
+func Join[T, U comparable](a *SpatialGrid[T], b *SpatialGrid[U], process func(a T, b U, overlap mosaic.Rectangle) error) error {
+	a, b, release := rlockPair(a, b)
+	defer release()
+
+	// b's items are stored by center, so a query has to reach out by b's
+	// largest half extents to see every item that could touch it
+	var reachX, reachY float64
+	for x := range b.Nodes {
+		for _, node := range b.Nodes[x] {
+			for _, item := range node.Items {
+				reachX = max(reachX, item.bounds.Width/2)
+				reachY = max(reachY, item.bounds.Height/2)
+			}
+		}
+	}
+
+	for x := range a.Nodes {
+		for _, node := range a.Nodes[x] {
+			for _, item := range node.Items {
+				query := mosaic.NewRectangle(item.bounds.Position, item.bounds.Width+2*reachX, item.bounds.Height+2*reachY)
+				xMin, yMin, xMax, yMax, ok := b.cellRange(query)
+				if !ok {
+					continue
+				}
+				for bx := xMin; bx <= xMax; bx++ {
+					for by := yMin; by <= yMax; by++ {
+						for _, other := range b.Nodes[bx][by].Items {
+							if !item.bounds.Intersects(other.bounds) {
+								continue
+							}
+							err := process(item.value, other.value, overlap(item.bounds, other.bounds))
+							if errors.Is(err, ErrStopSearch) {
+								return nil
+							}
+							if err != nil {
+								return err
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// overlap is the rectangle shared by two intersecting rectangles
+func overlap(r, s mosaic.Rectangle) mosaic.Rectangle {
+	rMin, rMax := r.MinPoint(), r.MaxPoint()
+	sMin, sMax := s.MinPoint(), s.MaxPoint()
+	minX, minY := max(rMin.X, sMin.X), max(rMin.Y, sMin.Y)
+	maxX, maxY := min(rMax.X, sMax.X), min(rMax.Y, sMax.Y)
+
+	return mosaic.NewRectangle(mosaic.NewVector((minX+maxX)/2, (minY+maxY)/2), maxX-minX, maxY-minY)
+}
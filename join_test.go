@@ -0,0 +1,143 @@
+package lattice_test
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/mosaic"
+)
+
+func Test_Join(t *testing.T) {
+	type pair struct {
+		a       int
+		b       string
+		overlap mosaic.Rectangle
+	}
+	hitboxes := []lattice.Item[int]{
+		{1, mosaic.NewRectangle(mosaic.NewVector(17, 4), 2, 2), 1},
+		{2, mosaic.NewRectangle(mosaic.NewVector(4, 4), 4, 4), 1},
+		{3, mosaic.NewRectangle(mosaic.NewVector(28, 28), 2, 2), 1},
+	}
+	hurtboxes := []lattice.Item[string]{
+		// a wide item stored well away from the hitbox it touches
+		{"wall", mosaic.NewRectangle(mosaic.NewVector(28, 4), 24, 2), 1},
+		{"spike", mosaic.NewRectangle(mosaic.NewVector(6, 6), 4, 4), 1},
+		{"pit", mosaic.NewRectangle(mosaic.NewVector(20, 28), 2, 2), 1},
+	}
+	want := []pair{
+		{a: 1, b: "wall", overlap: mosaic.NewRectangle(mosaic.NewVector(17, 4), 2, 2)},
+		{a: 2, b: "spike", overlap: mosaic.NewRectangle(mosaic.NewVector(5, 5), 2, 2)},
+	}
+
+	tests := []struct {
+		name  string
+		sizeX int
+		sizeY int
+		size  float64
+	}{
+		{name: "matching grids", sizeX: 4, sizeY: 4, size: 8},
+		{name: "coarser grid", sizeX: 2, sizeY: 2, size: 16},
+		{name: "single cell", sizeX: 1, sizeY: 1, size: 32},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := lattice.NewSpatialGrid[int](4, 4, 8)
+			for _, item := range hitboxes {
+				a.Insert(item)
+			}
+			b := lattice.NewSpatialGrid[string](tt.sizeX, tt.sizeY, tt.size)
+			for _, item := range hurtboxes {
+				b.Insert(item)
+			}
+
+			got := []pair{}
+			err := lattice.Join(a, b, func(a int, b string, overlap mosaic.Rectangle) error {
+				got = append(got, pair{a, b, overlap})
+				return nil
+			})
+			if err != nil {
+				t.Fatal(fmt.Errorf("lattice.Join() want: %+v, got: %+v\n", nil, err))
+			}
+			slices.SortFunc(got, func(x, y pair) int { return x.a - y.a })
+			if !slices.Equal(want, got) {
+				t.Error(fmt.Errorf("lattice.Join() want: %+v, got: %+v\n", want, got))
+			}
+		})
+	}
+}
+
+func Test_Join_stop(t *testing.T) {
+	a := lattice.NewSpatialGrid[int](4, 4, 8)
+	b := lattice.NewSpatialGrid[int](4, 4, 8)
+	for i := 0; i < 3; i++ {
+		a.Insert(lattice.Item[int]{i, mosaic.NewRectangle(mosaic.NewVector(4, 4), 2, 2), 1})
+		b.Insert(lattice.Item[int]{i, mosaic.NewRectangle(mosaic.NewVector(4, 4), 2, 2), 1})
+	}
+
+	calls := 0
+	err := lattice.Join(a, b, func(int, int, mosaic.Rectangle) error {
+		calls++
+		return lattice.ErrStopSearch
+	})
+	if err != nil || calls != 1 {
+		t.Error(fmt.Errorf("lattice.Join() want: %+v, got: %+v, %+v\n", 1, calls, err))
+	}
+
+	failed := errors.New("failed")
+	err = lattice.Join(a, b, func(int, int, mosaic.Rectangle) error {
+		return failed
+	})
+	if !errors.Is(err, failed) {
+		t.Error(fmt.Errorf("lattice.Join() want: %+v, got: %+v\n", failed, err))
+	}
+
+	// a grid joined with itself is read locked once
+	calls = 0
+	exclusive, _ := lattice.NewSpatialGridOpts[int](4, 4, 8, lattice.WithLocking(lattice.LockingExclusive))
+	exclusive.Insert(lattice.Item[int]{1, mosaic.NewRectangle(mosaic.NewVector(4, 4), 2, 2), 1})
+	err = lattice.Join(exclusive, exclusive, func(int, int, mosaic.Rectangle) error {
+		calls++
+		return nil
+	})
+	if err != nil || calls != 1 {
+		t.Error(fmt.Errorf("lattice.Join() want: %+v, got: %+v, %+v\n", 1, calls, err))
+	}
+}
+
+func Test_Join_crossed(t *testing.T) {
+	a, err := lattice.NewSpatialGridOpts[int](2, 2, 8, lattice.WithLocking(lattice.LockingExclusive))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := lattice.NewSpatialGridOpts[int](2, 2, 8, lattice.WithLocking(lattice.LockingExclusive))
+	if err != nil {
+		t.Fatal(err)
+	}
+	a.Insert(lattice.Item[int]{1, mosaic.NewRectangle(mosaic.Vector{X: 4, Y: 4}, 2, 2), 1.0})
+	b.Insert(lattice.Item[int]{2, mosaic.NewRectangle(mosaic.Vector{X: 4, Y: 4}, 2, 2), 1.0})
+	count := func(int, int, mosaic.Rectangle) error { return nil }
+
+	// each side holding one grid while waiting on the other deadlocks, which
+	// takes more than one thread to show
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+	var wg sync.WaitGroup
+	for _, pair := range [][2]*lattice.SpatialGrid[int]{{a, b}, {b, a}} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 10000; i++ {
+				err := lattice.Join(pair[0], pair[1], count)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,43 @@
+package lattice
+
+import "github.com/maladroitthief/mosaic"
+
+// LabelCells tags every cell under rect with label, e.g. "road" or "water",
+// for PathOptions.AllowedLabels. An empty label clears the tag.
+func (sg *SpatialGrid[T]) LabelCells(rect mosaic.Rectangle, label string) {
+	sg.lock()
+	defer sg.unlock()
+
+	xMin, yMin, xMax, yMax, ok := sg.cellRange(rect)
+	if !ok {
+		return
+	}
+
+	for x := xMin; x <= xMax; x++ {
+		for y := yMin; y <= yMax; y++ {
+			sg.Nodes[x][y].label = label
+		}
+	}
+}
+
+func (sg *SpatialGrid[T]) Label(x, y int) string {
+	sg = sg.rlock()
+	defer sg.runlock()
+
+	return sg.Nodes[x][y].label
+}
+
+// allowedLabels is the set a search may step into, nil when every cell is
+// allowed
+func (sg *SpatialGrid[T]) allowedLabels(opts PathOptions) map[string]bool {
+	if len(opts.AllowedLabels) == 0 {
+		return nil
+	}
+
+	allowed := make(map[string]bool, len(opts.AllowedLabels))
+	for _, label := range opts.AllowedLabels {
+		allowed[label] = true
+	}
+
+	return allowed
+}
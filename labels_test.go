@@ -0,0 +1,83 @@
+package lattice_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/mosaic"
+)
+
+func Test_spatial_grid_FindPath_AllowedLabels(t *testing.T) {
+	// a road down the left column, along the bottom and up the right column
+	road := []mosaic.Rectangle{
+		mosaic.NewRectangle(mosaic.NewVector(8, 40), 14, 78),
+		mosaic.NewRectangle(mosaic.NewVector(40, 72), 78, 14),
+		mosaic.NewRectangle(mosaic.NewVector(72, 40), 14, 78),
+	}
+	start, end := mosaic.NewVector(8, 8), mosaic.NewVector(72, 8)
+
+	type want struct {
+		steps int
+		err   error
+	}
+	tests := []struct {
+		name    string
+		allowed []string
+		want    want
+	}{
+		{name: "no constraint", want: want{steps: 5}},
+		{name: "road only", allowed: []string{"road"}, want: want{steps: 13}},
+		{name: "road and unlabeled", allowed: []string{"road", ""}, want: want{steps: 5}},
+		{name: "no matching cells", allowed: []string{"water"}, want: want{err: lattice.ErrPathNotFound}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg := lattice.NewSpatialGrid[int](5, 5, 16)
+			for _, rect := range road {
+				sg.LabelCells(rect, "road")
+			}
+
+			path, err := sg.FindPath(start, end, lattice.PathOptions{AllowedLabels: tt.allowed})
+			if !errors.Is(err, tt.want.err) {
+				t.Fatal(fmt.Errorf("spatialGrid.FindPath() want: %+v, got: %+v\n", tt.want.err, err))
+			}
+			if err != nil {
+				return
+			}
+			if len(path.Waypoints) != tt.want.steps {
+				t.Error(fmt.Errorf("spatialGrid.FindPath() want: %+v, got: %+v\n", tt.want.steps, path.Waypoints))
+			}
+		})
+	}
+}
+
+func Test_spatial_grid_LabelCells(t *testing.T) {
+	sg := lattice.NewSpatialGrid[int](4, 4, 16)
+	sg.LabelCells(mosaic.NewRectangle(mosaic.NewVector(16, 16), 30, 30), "water")
+
+	tests := []struct {
+		x, y int
+		want string
+	}{
+		{x: 0, y: 0, want: "water"},
+		{x: 1, y: 1, want: "water"},
+		{x: 2, y: 1, want: ""},
+		{x: 3, y: 3, want: ""},
+	}
+	for _, tt := range tests {
+		got := sg.Label(tt.x, tt.y)
+		if got != tt.want {
+			t.Error(fmt.Errorf("spatialGrid.Label(%d, %d) want: %+v, got: %+v\n", tt.x, tt.y, tt.want, got))
+		}
+	}
+
+	sg.LabelCells(mosaic.NewRectangle(mosaic.NewVector(8, 8), 14, 14), "")
+	if got := sg.Label(0, 0); got != "" {
+		t.Error(fmt.Errorf("spatialGrid.Label(0, 0) want: %+v, got: %+v\n", "", got))
+	}
+	if got := sg.Label(1, 1); got != "water" {
+		t.Error(fmt.Errorf("spatialGrid.Label(1, 1) want: %+v, got: %+v\n", "water", got))
+	}
+}
@@ -0,0 +1,206 @@
+package lattice
+
+import "math"
+
+type (
+	// Landmarks are distance tables from and to a handful of cells, used by
+	// searches as a lower bound on the cost still to go. The triangle
+	// inequality turns them into estimates that see around obstacles and
+	// account for weights, unlike the default move count. They describe the grid as it was when built and
+	// a single traversal profile, a write afterwards that changes a weight,
+	// a blocked cell, an edge rule or a portal, or a search with another
+	// profile falls back to the default heuristic.
+	Landmarks struct {
+		cells   []int32
+		from    [][]float64
+		to      [][]float64
+		profile TraversalProfile
+		width   int
+		size    int
+		at      uint64
+	}
+)
+
+// PrecomputeLandmarks picks up to count landmarks spread across the
+// reachable map, each new one the cell the most steps away from those
+// already chosen, and runs a search to and from each of them
+func (sg *SpatialGrid[T]) PrecomputeLandmarks(count int, profile TraversalProfile) (*Landmarks, error) {
+	sg = sg.rlock()
+	defer sg.runlock()
+
+	if count <= 0 {
+		return nil, ErrInvalidOption
+	}
+
+	cells := sg.SizeX * sg.SizeY
+	lm := &Landmarks{profile: profile, width: sg.SizeX, size: cells, at: sg.shape}
+	w := sg.newGoalWalker(cells)
+	w.profile = profile
+
+	// steps from the nearest landmark chosen so far, seeded from the first
+	// open cell so the first landmark lands on the edge of the map
+	hops := make([]int32, cells)
+	for i := range hops {
+		hops[i] = math.MaxInt32
+	}
+	seed := -1
+	for i := 0; i < cells && seed < 0; i++ {
+		if !sg.blocked.get(i) {
+			seed = i
+		}
+	}
+	if seed < 0 {
+		return lm, nil
+	}
+	w.hops(int32(seed), hops, true)
+
+	for len(lm.cells) < count {
+		next, farthest := int32(-1), int32(-1)
+		for i, h := range hops {
+			if h != math.MaxInt32 && h > farthest && !sg.blocked.get(i) {
+				next, farthest = int32(i), h
+			}
+		}
+		// every candidate is already a landmark
+		if farthest <= 0 && len(lm.cells) > 0 {
+			break
+		}
+
+		w.hops(next, hops, len(lm.cells) == 0)
+		w.settle(next)
+		lm.cells = append(lm.cells, next)
+		lm.from = append(lm.from, append([]float64(nil), w.costs...))
+		w.settleReverse(next)
+		lm.to = append(lm.to, append([]float64(nil), w.costs...))
+	}
+
+	return lm, nil
+}
+
+func (lm *Landmarks) Cells() []Cell {
+	cells := make([]Cell, len(lm.cells))
+	for i, cell := range lm.cells {
+		cells[i] = Cell{int(cell) % lm.width, int(cell) / lm.width}
+	}
+
+	return cells
+}
+
+// estimate is the best triangle inequality bound over every landmark
+func (lm *Landmarks) estimate(from, to int32) float64 {
+	h := 0.0
+	for i := range lm.cells {
+		// L to from plus from to goal is at least L to goal
+		forward := lm.from[i][to] - lm.from[i][from]
+		// from to L is at most from to goal plus goal to L
+		backward := lm.to[i][from] - lm.to[i][to]
+		if forward > h {
+			h = forward
+		}
+		if backward > h {
+			h = backward
+		}
+	}
+
+	return h
+}
+
+// landmarks returns the tables opts asked for if they still describe sg
+func (sg *SpatialGrid[T]) landmarks(opts PathOptions) *Landmarks {
+	lm := opts.Landmarks
+	if lm == nil || len(lm.cells) == 0 || lm.size != sg.SizeX*sg.SizeY || lm.width != sg.SizeX ||
+		lm.at != sg.shape || lm.profile != opts.Profile {
+		return nil
+	}
+
+	return lm
+}
+
+// estimate is the heuristic from cell to end, dx and dy being their offset.
+// Landmarks only ever raise the move count, which still guides searches
+// across cells that cost nothing to enter.
+func (sg *SpatialGrid[T]) estimate(lm *Landmarks, cell, end int32, dx, dy int) float64 {
+	h := sg.heuristic(dx, dy)
+	if lm == nil {
+		return h
+	}
+
+	return max(h, lm.estimate(cell, end))
+}
+
+// hops runs a breadth first walk from source, lowering every cell's step
+// count to its distance from source. reset starts the counts over.
+func (w *goalWalker[T]) hops(source int32, hops []int32, reset bool) {
+	if reset {
+		for i := range hops {
+			hops[i] = math.MaxInt32
+		}
+	}
+
+	hops[source] = 0
+	w.queue = append(w.queue[:0], source)
+	for head := 0; head < len(w.queue); head++ {
+		current := w.queue[head]
+		w.grid.goalEdges(current, w.profile, func(next int32, _ int, _ float64) {
+			if hops[current]+1 >= hops[next] {
+				return
+			}
+			hops[next] = hops[current] + 1
+			w.queue = append(w.queue, next)
+		})
+	}
+}
+
+// settleReverse finds the cheapest cost from every cell to target
+func (w *goalWalker[T]) settleReverse(target int32) {
+	sg := w.grid
+	if w.reverse == nil {
+		w.reverse = map[int32][]portal{}
+		for from, portals := range sg.portals {
+			for _, p := range portals {
+				w.reverse[int32(p.to)] = append(w.reverse[int32(p.to)], portal{to: from, cost: p.cost})
+			}
+		}
+	}
+
+	for i := range w.costs {
+		w.costs[i] = math.Inf(1)
+	}
+	w.costs[target] = 0
+	w.heap = w.heap.Push(target, 0)
+	for w.heap.Len() > 0 {
+		priority := w.heap[0].priority
+		var current int32
+		current, w.heap = w.heap.Pop()
+		if priority > w.costs[current] {
+			continue
+		}
+		// stepping into current is what every edge into it costs
+		if sg.blocked.get(int(current)) {
+			continue
+		}
+
+		x, y := int(current)%sg.SizeX, int(current)/sg.SizeX
+		weight := sg.Nodes[x][y].weight
+		relax := func(previous int32, cost float64) {
+			if w.costs[current]+cost < w.costs[previous] {
+				w.costs[previous] = w.costs[current] + cost
+				w.heap = w.heap.Push(previous, w.costs[previous])
+			}
+		}
+		for _, direction := range sg.config.neighbors {
+			previousX, previousY := x-direction[0], y-direction[1]
+			if !sg.inBounds(previousX, previousY) {
+				continue
+			}
+			previous := sg.index(previousX, previousY)
+			if !sg.edgeAllowed(previous, direction[0], direction[1], w.profile) {
+				continue
+			}
+			relax(int32(previous), weight)
+		}
+		for _, p := range w.reverse[current] {
+			relax(int32(p.to), p.cost+weight)
+		}
+	}
+}
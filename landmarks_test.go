@@ -0,0 +1,132 @@
+package lattice_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/mosaic"
+)
+
+func Test_spatial_grid_PrecomputeLandmarks(t *testing.T) {
+	grid := Builder{
+		x:    8,
+		y:    8,
+		size: 8,
+		layout: "" +
+			"11111111" +
+			"11111111" +
+			"1xxxxxx1" +
+			"111111x1" +
+			"111111x1" +
+			"1xxxxxx1" +
+			"11111111" +
+			"11111111",
+	}
+	center := func(x, y int) mosaic.Vector {
+		return mosaic.NewVector(float64(x*grid.size)+4, float64(y*grid.size)+4)
+	}
+	tests := []struct {
+		name  string
+		count int
+		start mosaic.Vector
+		end   mosaic.Vector
+	}{
+		{name: "out of the pocket", count: 4, start: center(3, 3), end: center(7, 7)},
+		{name: "into the pocket", count: 4, start: center(0, 7), end: center(4, 4)},
+		{name: "single landmark", count: 1, start: center(0, 0), end: center(7, 4)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg := lattice.NewSpatialGrid[int](grid.x, grid.y, float64(grid.size))
+			setup_grid(sg, grid)
+
+			landmarks, err := sg.PrecomputeLandmarks(tt.count, lattice.TraversalProfile{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(landmarks.Cells()) != tt.count {
+				t.Error(fmt.Errorf("spatialGrid.PrecomputeLandmarks() want: %+v landmarks, got: %+v\n", tt.count, landmarks.Cells()))
+			}
+
+			plain, plainTrace, err := sg.FindPathTrace(tt.start, tt.end, lattice.PathOptions{}, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, trace, err := sg.FindPathTrace(tt.start, tt.end, lattice.PathOptions{Landmarks: landmarks}, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			cheapest, _ := sg.PathsFrom(tt.start, []mosaic.Vector{tt.end})
+
+			if got.Cost > plain.Cost || got.Cost < cheapest[0].Cost {
+				t.Error(fmt.Errorf("spatialGrid.FindPath() want cost: %+v, got: %+v\n", cheapest[0].Cost, got.Cost))
+			}
+			if len(trace.Expanded) >= len(plainTrace.Expanded) {
+				t.Error(fmt.Errorf("spatialGrid.FindPath() want fewer than: %+v expansions, got: %+v\n", len(plainTrace.Expanded), len(trace.Expanded)))
+			}
+
+			// writes that leave weights and edges alone keep the tables
+			sg.AddScent(4, 4, 1)
+			sg.SetCellData(0, 0, "corner")
+			sg.Tick(1)
+			_, kept, _ := sg.FindPathTrace(tt.start, tt.end, lattice.PathOptions{Landmarks: landmarks}, 0)
+			if len(kept.Expanded) != len(trace.Expanded) {
+				t.Error(fmt.Errorf("spatialGrid.FindPath() want landmarks kept: %+v, got: %+v\n", len(trace.Expanded), len(kept.Expanded)))
+			}
+
+			// a write leaves the tables behind and the default heuristic takes over
+			sg.Insert(lattice.Item[int]{2, sg.CellBounds(0, 0), 0})
+			_, stale, _ := sg.FindPathTrace(tt.start, tt.end, lattice.PathOptions{Landmarks: landmarks}, 0)
+			if len(stale.Expanded) != len(plainTrace.Expanded) {
+				t.Error(fmt.Errorf("spatialGrid.FindPath() want stale landmarks ignored: %+v, got: %+v\n", len(plainTrace.Expanded), len(stale.Expanded)))
+			}
+		})
+	}
+}
+
+func Test_spatial_grid_PrecomputeLandmarks_edges(t *testing.T) {
+	grid := Builder{
+		x:    8,
+		y:    8,
+		size: 8,
+		layout: "" +
+			"11111111" +
+			"11111111" +
+			"1xxxxxx1" +
+			"111111x1" +
+			"111111x1" +
+			"1xxxxxx1" +
+			"11111111" +
+			"11111111",
+	}
+	tests := []struct {
+		name  string
+		write func(sg *lattice.SpatialGrid[int])
+	}{
+		{name: "edge rule", write: func(sg *lattice.SpatialGrid[int]) {
+			sg.SetEdgeRule(0, 0, lattice.Offset{X: 1}, lattice.Impassable)
+		}},
+		{name: "portal", write: func(sg *lattice.SpatialGrid[int]) {
+			sg.AddPortal(0, 7, 7, 0, 1)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg := lattice.NewSpatialGrid[int](grid.x, grid.y, float64(grid.size))
+			setup_grid(sg, grid)
+			start, end := mosaic.NewVector(28, 28), mosaic.NewVector(60, 60)
+			landmarks, err := sg.PrecomputeLandmarks(4, lattice.TraversalProfile{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			tt.write(sg)
+			_, plain, _ := sg.FindPathTrace(start, end, lattice.PathOptions{}, 0)
+			_, stale, _ := sg.FindPathTrace(start, end, lattice.PathOptions{Landmarks: landmarks}, 0)
+			if len(stale.Expanded) != len(plain.Expanded) {
+				t.Error(fmt.Errorf("spatialGrid.FindPath() want stale landmarks ignored: %+v, got: %+v\n", len(plain.Expanded), len(stale.Expanded)))
+			}
+		})
+	}
+}
@@ -0,0 +1,212 @@
+// Package latticegen builds seeded obstacle layouts for tests, benchmarks
+// and demos. Every generator is deterministic for a given seed.
+package latticegen
+
+import (
+	"math"
+	"math/rand/v2"
+
+	"github.com/maladroitthief/lattice"
+)
+
+type (
+	// Layout marks blocked cells in index order, y*Width + x
+	Layout struct {
+		Width   int
+		Height  int
+		Blocked []bool
+	}
+
+	room struct {
+		x, y, width, height int
+	}
+)
+
+func newLayout(width, height int, blocked bool) Layout {
+	l := Layout{Width: width, Height: height, Blocked: make([]bool, width*height)}
+	if blocked {
+		for i := range l.Blocked {
+			l.Blocked[i] = true
+		}
+	}
+
+	return l
+}
+
+func newRand(seed uint64) *rand.Rand {
+	return rand.New(rand.NewPCG(seed, seed^0x9e3779b97f4a7c15))
+}
+
+func (l Layout) Open(x, y int) bool {
+	return x >= 0 && x < l.Width && y >= 0 && y < l.Height && !l.Blocked[y*l.Width+x]
+}
+
+func (l Layout) set(x, y int, blocked bool) {
+	l.Blocked[y*l.Width+x] = blocked
+}
+
+// OpenCells lists every open cell, row by row
+func (l Layout) OpenCells() []lattice.Cell {
+	cells := []lattice.Cell{}
+	for y := 0; y < l.Height; y++ {
+		for x := 0; x < l.Width; x++ {
+			if l.Open(x, y) {
+				cells = append(cells, lattice.Cell{X: x, Y: y})
+			}
+		}
+	}
+
+	return cells
+}
+
+// Obstacles blocks each cell independently with probability density
+func Obstacles(width, height int, density float64, seed uint64) Layout {
+	r := newRand(seed)
+	l := newLayout(width, height, false)
+	for i := range l.Blocked {
+		l.Blocked[i] = r.Float64() < density
+	}
+
+	return l
+}
+
+// Maze carves a perfect maze, exactly one route between any two open cells.
+// Passages run through odd coordinates, so odd dimensions leave no dead
+// border.
+func Maze(width, height int, seed uint64) Layout {
+	r := newRand(seed)
+	l := newLayout(width, height, true)
+	if width < 2 || height < 2 {
+		return l
+	}
+
+	steps := [][]int{{0, 2}, {0, -2}, {2, 0}, {-2, 0}}
+	l.set(1, 1, false)
+	stack := []lattice.Cell{{X: 1, Y: 1}}
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		r.Shuffle(len(steps), func(i, j int) { steps[i], steps[j] = steps[j], steps[i] })
+
+		carved := false
+		for _, step := range steps {
+			nextX, nextY := current.X+step[0], current.Y+step[1]
+			if nextX <= 0 || nextX >= width-1 || nextY <= 0 || nextY >= height-1 || l.Open(nextX, nextY) {
+				continue
+			}
+
+			l.set(current.X+step[0]/2, current.Y+step[1]/2, false)
+			l.set(nextX, nextY, false)
+			stack = append(stack, lattice.Cell{X: nextX, Y: nextY})
+			carved = true
+			break
+		}
+		if !carved {
+			stack = stack[:len(stack)-1]
+		}
+	}
+
+	return l
+}
+
+// Rooms places up to count non-overlapping rooms and joins each one to the
+// previous with an L shaped corridor, leaving the rest of the map solid
+func Rooms(width, height, count int, seed uint64) Layout {
+	r := newRand(seed)
+	l := newLayout(width, height, true)
+	if width < 5 || height < 5 {
+		return l
+	}
+
+	maxSize := max(3, min(width, height)/3)
+	rooms := []room{}
+	for attempt := 0; attempt < count*8 && len(rooms) < count; attempt++ {
+		w, h := 3+r.IntN(maxSize-2), 3+r.IntN(maxSize-2)
+		if w >= width-1 || h >= height-1 {
+			continue
+		}
+		candidate := room{x: 1 + r.IntN(width-w-1), y: 1 + r.IntN(height-h-1), width: w, height: h}
+
+		overlaps := false
+		for _, other := range rooms {
+			if candidate.x <= other.x+other.width && other.x <= candidate.x+candidate.width &&
+				candidate.y <= other.y+other.height && other.y <= candidate.y+candidate.height {
+				overlaps = true
+				break
+			}
+		}
+		if overlaps {
+			continue
+		}
+
+		for y := candidate.y; y < candidate.y+candidate.height; y++ {
+			for x := candidate.x; x < candidate.x+candidate.width; x++ {
+				l.set(x, y, false)
+			}
+		}
+		if len(rooms) > 0 {
+			l.corridor(rooms[len(rooms)-1].center(), candidate.center(), r.IntN(2) == 0)
+		}
+		rooms = append(rooms, candidate)
+	}
+
+	return l
+}
+
+func (rm room) center() lattice.Cell {
+	return lattice.Cell{X: rm.x + rm.width/2, Y: rm.y + rm.height/2}
+}
+
+func (l Layout) corridor(from, to lattice.Cell, horizontalFirst bool) {
+	corner := lattice.Cell{X: from.X, Y: to.Y}
+	if horizontalFirst {
+		corner = lattice.Cell{X: to.X, Y: from.Y}
+	}
+
+	for _, leg := range [][2]lattice.Cell{{from, corner}, {corner, to}} {
+		for x := min(leg[0].X, leg[1].X); x <= max(leg[0].X, leg[1].X); x++ {
+			for y := min(leg[0].Y, leg[1].Y); y <= max(leg[0].Y, leg[1].Y); y++ {
+				l.set(x, y, false)
+			}
+		}
+	}
+}
+
+// Items covers every blocked cell with an impassable item sized to the
+// matching cell of sg, value names the item for a cell
+func Items[T comparable](l Layout, sg *lattice.SpatialGrid[T], value func(x, y int) T) []lattice.Item[T] {
+	items := []lattice.Item[T]{}
+	for y := 0; y < min(l.Height, sg.SizeY); y++ {
+		for x := 0; x < min(l.Width, sg.SizeX); x++ {
+			if l.Open(x, y) {
+				continue
+			}
+
+			items = append(items, lattice.Item[T]{
+				Value:      value(x, y),
+				Bounds:     sg.CellBounds(x, y),
+				Multiplier: math.Inf(1),
+			})
+		}
+	}
+
+	return items
+}
+
+// Apply blocks the terrain of every blocked cell in sg, cells past the edge
+// of either are skipped
+func Apply[T comparable](l Layout, sg *lattice.SpatialGrid[T]) error {
+	for y := 0; y < min(l.Height, sg.SizeY); y++ {
+		for x := 0; x < min(l.Width, sg.SizeX); x++ {
+			if l.Open(x, y) {
+				continue
+			}
+
+			err := sg.SetTerrain(x, y, math.Inf(1))
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,142 @@
+package latticegen_test
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/lattice/latticegen"
+)
+
+// reachable counts the open cells connected to the first open cell
+func reachable(l latticegen.Layout) int {
+	open := l.OpenCells()
+	if len(open) == 0 {
+		return 0
+	}
+
+	seen := map[lattice.Cell]bool{open[0]: true}
+	queue := []lattice.Cell{open[0]}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, step := range lattice.FourWay {
+			next := lattice.Cell{X: current.X + step.X, Y: current.Y + step.Y}
+			if !l.Open(next.X, next.Y) || seen[next] {
+				continue
+			}
+			seen[next] = true
+			queue = append(queue, next)
+		}
+	}
+
+	return len(seen)
+}
+
+// passages counts the 4-way links between open cells
+func passages(l latticegen.Layout) int {
+	links := 0
+	for _, cell := range l.OpenCells() {
+		if l.Open(cell.X+1, cell.Y) {
+			links++
+		}
+		if l.Open(cell.X, cell.Y+1) {
+			links++
+		}
+	}
+
+	return links
+}
+
+func Test_Obstacles(t *testing.T) {
+	tests := []struct {
+		name    string
+		density float64
+		min     float64
+		max     float64
+	}{
+		{name: "empty", density: 0, min: 0, max: 0},
+		{name: "sparse", density: 0.2, min: 0.15, max: 0.25},
+		{name: "solid", density: 1, min: 1, max: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := latticegen.Obstacles(64, 64, tt.density, 7)
+			blocked := float64(64*64-len(l.OpenCells())) / (64 * 64)
+			if blocked < tt.min || blocked > tt.max {
+				t.Error(fmt.Errorf("latticegen.Obstacles() want density: %+v, got: %+v\n", tt.density, blocked))
+			}
+
+			again := latticegen.Obstacles(64, 64, tt.density, 7)
+			if !slices.Equal(l.Blocked, again.Blocked) {
+				t.Error(fmt.Errorf("latticegen.Obstacles() want the same layout for the same seed\n"))
+			}
+		})
+	}
+}
+
+func Test_Maze(t *testing.T) {
+	tests := []struct {
+		name          string
+		width, height int
+	}{
+		{name: "square", width: 21, height: 21},
+		{name: "wide", width: 41, height: 11},
+		{name: "even sides", width: 20, height: 12},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := latticegen.Maze(tt.width, tt.height, 3)
+			open := len(l.OpenCells())
+			if reachable(l) != open {
+				t.Error(fmt.Errorf("latticegen.Maze() want all %+v open cells connected, got: %+v\n", open, reachable(l)))
+			}
+			// a spanning tree has exactly one fewer link than it has cells
+			if passages(l) != open-1 {
+				t.Error(fmt.Errorf("latticegen.Maze() want: %+v passages, got: %+v\n", open-1, passages(l)))
+			}
+		})
+	}
+}
+
+func Test_Rooms(t *testing.T) {
+	l := latticegen.Rooms(48, 32, 6, 11)
+	open := len(l.OpenCells())
+	if open == 0 || reachable(l) != open {
+		t.Error(fmt.Errorf("latticegen.Rooms() want all %+v open cells connected, got: %+v\n", open, reachable(l)))
+	}
+}
+
+func Test_Apply(t *testing.T) {
+	l := latticegen.Maze(9, 9, 5)
+	items := lattice.NewSpatialGrid[int](9, 9, 8)
+	err := items.Reset(latticegen.Items(l, items, func(x, y int) int { return y*9 + x }))
+	if err != nil {
+		t.Fatal(err)
+	}
+	terrain := lattice.NewSpatialGrid[int](9, 9, 8)
+	err = latticegen.Apply(l, terrain)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for y := 0; y < 9; y++ {
+		for x := 0; x < 9; x++ {
+			want := !l.Open(x, y)
+			if items.Blocked(x, y) != want || terrain.Blocked(x, y) != want {
+				t.Error(fmt.Errorf("latticegen.Apply() cell %+v, %+v want blocked: %+v\n", x, y, want))
+			}
+		}
+	}
+	if !math.IsInf(terrain.Terrain(0, 0), 1) {
+		t.Error(fmt.Errorf("latticegen.Apply() want: %+v, got: %+v\n", math.Inf(1), terrain.Terrain(0, 0)))
+	}
+
+	start, end := l.OpenCells()[0], l.OpenCells()[len(l.OpenCells())-1]
+	_, err = items.FindPath(items.CellCenter(start.X, start.Y), items.CellCenter(end.X, end.Y), lattice.PathOptions{})
+	if err != nil {
+		t.Error(fmt.Errorf("spatialGrid.FindPath() want error: %+v, got error: %+v\n", nil, err))
+	}
+}
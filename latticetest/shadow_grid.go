@@ -0,0 +1,212 @@
+// Package latticetest checks a SpatialGrid against a naive reference so
+// integrations can be fuzzed for divergence.
+package latticetest
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/mosaic"
+)
+
+type (
+	// ShadowGrid mirrors every write into a plain slice of items and checks
+	// reads against it. The reference follows the grid's own placement rules,
+	// an item belongs to the cell holding its center, so it works for any
+	// grid configuration that keeps every item it accepts.
+	ShadowGrid[T comparable] struct {
+		grid  *lattice.SpatialGrid[T]
+		items []lattice.Item[T]
+	}
+)
+
+var (
+	ErrDivergence = errors.New("grid diverged from the reference")
+)
+
+// weightTolerance absorbs the different summation order of the reference
+const weightTolerance = 1e-9
+
+func NewShadowGrid[T comparable](grid *lattice.SpatialGrid[T]) *ShadowGrid[T] {
+	return &ShadowGrid[T]{grid: grid}
+}
+
+func (s *ShadowGrid[T]) Grid() *lattice.SpatialGrid[T] {
+	return s.grid
+}
+
+func (s *ShadowGrid[T]) Insert(item lattice.Item[T]) error {
+	err := s.grid.Insert(item)
+	if err != nil {
+		return err
+	}
+
+	s.items = append(s.items, item)
+	return s.checkSize()
+}
+
+func (s *ShadowGrid[T]) Update(item lattice.Item[T], oldBounds mosaic.Rectangle) error {
+	err := s.grid.Update(item, oldBounds)
+	if err != nil {
+		return err
+	}
+
+	s.remove(item.Value, oldBounds)
+	s.items = append(s.items, item)
+	return s.checkSize()
+}
+
+func (s *ShadowGrid[T]) Delete(val T, bounds mosaic.Rectangle) error {
+	err := s.grid.Delete(val, bounds)
+	if err != nil {
+		return err
+	}
+
+	s.remove(val, bounds)
+	return s.checkSize()
+}
+
+// remove drops every copy of val stored in the cell under bounds, matching
+// how the grid deletes
+func (s *ShadowGrid[T]) remove(val T, bounds mosaic.Rectangle) {
+	x, y := s.grid.Location(bounds.Position.X, bounds.Position.Y)
+	kept := s.items[:0]
+	for _, item := range s.items {
+		itemX, itemY := s.grid.Location(item.Bounds.Position.X, item.Bounds.Position.Y)
+		if item.Value == val && itemX == x && itemY == y {
+			continue
+		}
+		kept = append(kept, item)
+	}
+	s.items = kept
+}
+
+// FindNear passes with either query mode: the result must hold every item
+// that intersects bounds and nothing stored outside the cells bounds covers
+func (s *ShadowGrid[T]) FindNear(bounds mosaic.Rectangle) ([]T, error) {
+	got := s.grid.FindNear(bounds)
+	coarse, precise := s.reference(bounds)
+
+	found := set(got)
+	if len(found) != len(got) {
+		return got, fmt.Errorf("%w: FindNear returned duplicates %v", ErrDivergence, got)
+	}
+	for value := range found {
+		if !coarse[value] {
+			return got, fmt.Errorf("%w: FindNear returned %v from outside %v", ErrDivergence, value, bounds)
+		}
+	}
+	for value := range precise {
+		if !found[value] {
+			return got, fmt.Errorf("%w: FindNear missed %v in %v", ErrDivergence, value, bounds)
+		}
+	}
+
+	return got, nil
+}
+
+func (s *ShadowGrid[T]) FindIntersecting(bounds mosaic.Rectangle) ([]T, error) {
+	got := s.grid.FindIntersecting(bounds)
+	_, precise := s.reference(bounds)
+
+	found := set(got)
+	if len(found) != len(got) || len(found) != len(precise) {
+		return got, fmt.Errorf("%w: FindIntersecting want %v items, got %v", ErrDivergence, len(precise), got)
+	}
+	for value := range precise {
+		if !found[value] {
+			return got, fmt.Errorf("%w: FindIntersecting missed %v in %v", ErrDivergence, value, bounds)
+		}
+	}
+
+	return got, nil
+}
+
+// reference is the values stored in the cells under bounds, and the subset
+// of those whose bounds actually intersect it
+func (s *ShadowGrid[T]) reference(bounds mosaic.Rectangle) (coarse, precise map[T]bool) {
+	minPoint, maxPoint := bounds.MinPoint(), bounds.MaxPoint()
+	xMin, yMin := s.grid.Location(minPoint.X, minPoint.Y)
+	xMax, yMax := s.grid.Location(maxPoint.X, maxPoint.Y)
+
+	coarse, precise = map[T]bool{}, map[T]bool{}
+	for _, item := range s.items {
+		x, y := s.grid.Location(item.Bounds.Position.X, item.Bounds.Position.Y)
+		if x < xMin || x > xMax || y < yMin || y > yMax {
+			continue
+		}
+
+		coarse[item.Value] = true
+		if bounds.Intersects(item.Bounds) {
+			precise[item.Value] = true
+		}
+	}
+
+	return coarse, precise
+}
+
+// Check compares the size, every cell weight and every blocked flag with
+// the reference
+func (s *ShadowGrid[T]) Check() error {
+	err := s.checkSize()
+	if err != nil {
+		return err
+	}
+
+	weights := make([][]float64, s.grid.SizeX)
+	for x := range weights {
+		weights[x] = make([]float64, s.grid.SizeY)
+		for y := range weights[x] {
+			weights[x][y] = s.grid.Terrain(x, y)
+		}
+	}
+	for _, item := range s.items {
+		x, y := s.grid.Location(item.Bounds.Position.X, item.Bounds.Position.Y)
+		weights[x][y] += s.grid.CellBounds(x, y).AreaOfOverlap(item.Bounds) * item.Multiplier
+	}
+
+	threshold := s.grid.BlockedThreshold()
+	for x := range weights {
+		for y, want := range weights[x] {
+			got := s.grid.GetLocationWeight(x, y)
+			if !closeEnough(want, got) {
+				return fmt.Errorf("%w: cell %v, %v want weight %v, got %v", ErrDivergence, x, y, want, got)
+			}
+			if s.grid.Blocked(x, y) != (got >= threshold) {
+				return fmt.Errorf("%w: cell %v, %v blocked flag out of date", ErrDivergence, x, y)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *ShadowGrid[T]) checkSize() error {
+	if s.grid.Size() != len(s.items) {
+		return fmt.Errorf("%w: want size %v, got %v", ErrDivergence, len(s.items), s.grid.Size())
+	}
+
+	return nil
+}
+
+func closeEnough(want, got float64) bool {
+	if want == got || (math.IsNaN(want) && math.IsNaN(got)) {
+		return true
+	}
+	if math.IsInf(want, 0) || math.IsInf(got, 0) {
+		return false
+	}
+
+	return math.Abs(want-got) <= weightTolerance*max(1, math.Abs(want), math.Abs(got))
+}
+
+func set[T comparable](values []T) map[T]bool {
+	found := make(map[T]bool, len(values))
+	for _, value := range values {
+		found[value] = true
+	}
+
+	return found
+}
@@ -0,0 +1,117 @@
+package latticetest_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/lattice/latticetest"
+	"github.com/maladroitthief/mosaic"
+)
+
+func Test_ShadowGrid(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []lattice.Option
+	}{
+		{name: "default"},
+		{name: "precise", opts: []lattice.Option{lattice.WithPreciseQueries()}},
+		{name: "exclusive", opts: []lattice.Option{lattice.WithLocking(lattice.LockingExclusive)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg, err := lattice.NewSpatialGridOpts[int](4, 4, 8, tt.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			s := latticetest.NewShadowGrid(sg)
+
+			a := mosaic.NewRectangle(mosaic.NewVector(4, 4), 4, 4)
+			b := mosaic.NewRectangle(mosaic.NewVector(15, 4), 6, 2)
+			moved := mosaic.NewRectangle(mosaic.NewVector(20, 28), 2, 2)
+			steps := []error{
+				s.Insert(lattice.Item[int]{Value: 1, Bounds: a, Multiplier: 1}),
+				s.Insert(lattice.Item[int]{Value: 2, Bounds: b, Multiplier: 2}),
+				s.Update(lattice.Item[int]{Value: 1, Bounds: moved, Multiplier: 3}, a),
+				s.Check(),
+			}
+			_, nearErr := s.FindNear(mosaic.NewRectangle(mosaic.NewVector(12, 4), 2, 2))
+			_, intersectErr := s.FindIntersecting(mosaic.NewRectangle(mosaic.NewVector(20, 20), 16, 16))
+			steps = append(steps, nearErr, intersectErr, s.Delete(2, b), s.Check())
+			for i, err := range steps {
+				if err != nil {
+					t.Error(fmt.Errorf("shadowGrid step %+v want error: %+v, got error: %+v\n", i, nil, err))
+				}
+			}
+		})
+	}
+}
+
+func Test_ShadowGrid_divergence(t *testing.T) {
+	sg := lattice.NewSpatialGrid[int](4, 4, 8)
+	s := latticetest.NewShadowGrid(sg)
+	bounds := mosaic.NewRectangle(mosaic.NewVector(4, 4), 2, 2)
+	s.Insert(lattice.Item[int]{Value: 1, Bounds: bounds, Multiplier: 1})
+
+	// writing around the shadow leaves the reference behind
+	sg.Insert(lattice.Item[int]{Value: 2, Bounds: bounds, Multiplier: 1})
+	_, err := s.FindNear(bounds)
+	if !errors.Is(err, latticetest.ErrDivergence) {
+		t.Error(fmt.Errorf("shadowGrid.FindNear() want error: %+v, got error: %+v\n", latticetest.ErrDivergence, err))
+	}
+	err = s.Check()
+	if !errors.Is(err, latticetest.ErrDivergence) {
+		t.Error(fmt.Errorf("shadowGrid.Check() want error: %+v, got error: %+v\n", latticetest.ErrDivergence, err))
+	}
+}
+
+func FuzzShadowGrid(f *testing.F) {
+	f.Add([]byte{0, 10, 10, 4, 0, 40, 2, 9, 1, 0, 30, 3, 3, 12, 12, 20, 2, 0, 0, 0})
+	f.Add([]byte{0, 255, 255, 1, 0, 1, 1, 64, 3, 0, 0, 255})
+
+	f.Fuzz(func(t *testing.T, ops []byte) {
+		sg := lattice.NewSpatialGrid[int](8, 8, 8)
+		s := latticetest.NewShadowGrid(sg)
+		live := map[int]mosaic.Rectangle{}
+		next := 0
+
+		for len(ops) >= 4 {
+			op, x, y, size := ops[0]%4, float64(ops[1])/4, float64(ops[2])/4, float64(ops[3]%32)+1
+			ops = ops[4:]
+			bounds := mosaic.NewRectangle(mosaic.NewVector(x, y), size, size)
+
+			var err error
+			switch op {
+			case 0:
+				err = s.Insert(lattice.Item[int]{Value: next, Bounds: bounds, Multiplier: size / 8})
+				live[next] = bounds
+				next++
+			case 1, 2:
+				for value, old := range live {
+					if op == 1 {
+						err = s.Update(lattice.Item[int]{Value: value, Bounds: bounds, Multiplier: 1}, old)
+						live[value] = bounds
+					} else {
+						err = s.Delete(value, old)
+						delete(live, value)
+					}
+					break
+				}
+			case 3:
+				_, err = s.FindNear(bounds)
+				if err == nil {
+					_, err = s.FindIntersecting(bounds)
+				}
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		err := s.Check()
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+}
@@ -0,0 +1,91 @@
+package lattice
+
+import (
+	"math"
+
+	"github.com/maladroitthief/mosaic"
+)
+
+const traversalEpsilon = 1e-9
+
+func (sg *SpatialGrid[T]) HasLineOfSight(a, b mosaic.Vector, blocks func(weight float64) bool) bool {
+	sg = sg.rlock()
+	defer sg.runlock()
+
+	if blocks == nil {
+		return sg.traverse(a, b, func(x, y int) bool {
+			return !sg.blocked.get(sg.index(x, y))
+		})
+	}
+
+	return sg.traverse(a, b, func(x, y int) bool {
+		return !blocks(sg.Nodes[x][y].weight)
+	})
+}
+
+// traverse walks every cell touched by the segment from a to b, including
+// both neighbours when the segment passes exactly through a cell corner.
+func (sg *SpatialGrid[T]) traverse(a, b mosaic.Vector, visit func(x, y int) bool) bool {
+	x0, y0 := (a.X-sg.origin.X)/sg.ChunkSize, (a.Y-sg.origin.Y)/sg.ChunkSize
+	x1, y1 := (b.X-sg.origin.X)/sg.ChunkSize, (b.Y-sg.origin.Y)/sg.ChunkSize
+	cx, cy := int(math.Floor(x0)), int(math.Floor(y0))
+	ex, ey := int(math.Floor(x1)), int(math.Floor(y1))
+
+	axis := func(from, to float64, cell int) (step int, tMax, tDelta float64) {
+		delta := to - from
+		switch {
+		case delta > 0:
+			return 1, (float64(cell+1) - from) / delta, 1 / delta
+		case delta < 0:
+			return -1, (from - float64(cell)) / -delta, 1 / -delta
+		default:
+			return 0, math.Inf(1), math.Inf(1)
+		}
+	}
+	stepX, tMaxX, tDeltaX := axis(x0, x1, cx)
+	stepY, tMaxY, tDeltaY := axis(y0, y1, cy)
+
+	check := func(x, y int) bool {
+		if x < 0 || x >= sg.SizeX || y < 0 || y >= sg.SizeY {
+			return true
+		}
+		return visit(x, y)
+	}
+
+	remaining := abs(ex-cx) + abs(ey-cy)
+	if !check(cx, cy) {
+		return false
+	}
+	for ; remaining > 0; remaining-- {
+		switch {
+		case math.Abs(tMaxX-tMaxY) < traversalEpsilon:
+			if !check(cx+stepX, cy) || !check(cx, cy+stepY) {
+				return false
+			}
+			cx += stepX
+			cy += stepY
+			tMaxX += tDeltaX
+			tMaxY += tDeltaY
+			remaining--
+		case tMaxX < tMaxY:
+			cx += stepX
+			tMaxX += tDeltaX
+		default:
+			cy += stepY
+			tMaxY += tDeltaY
+		}
+
+		if !check(cx, cy) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
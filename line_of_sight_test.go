@@ -0,0 +1,109 @@
+package lattice_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/mosaic"
+)
+
+func Test_spatial_grid_HasLineOfSight(t *testing.T) {
+	type setup struct {
+		builder Builder
+	}
+	type params struct {
+		a mosaic.Vector
+		b mosaic.Vector
+	}
+	tests := []struct {
+		name   string
+		setup  setup
+		params params
+		want   bool
+	}{
+		{
+			name: "open row",
+			setup: setup{
+				builder: Builder{
+					x:    5,
+					y:    5,
+					size: 32,
+					layout: "" +
+						"00000" +
+						"00x00" +
+						"00x00" +
+						"00000" +
+						"00000",
+				},
+			},
+			params: params{a: mosaic.NewVector(0, 0), b: mosaic.NewVector(4, 0)},
+			want:   true,
+		},
+		{
+			name: "wall in the way",
+			setup: setup{
+				builder: Builder{
+					x:    5,
+					y:    5,
+					size: 32,
+					layout: "" +
+						"00000" +
+						"00x00" +
+						"00x00" +
+						"00000" +
+						"00000",
+				},
+			},
+			params: params{a: mosaic.NewVector(0, 2), b: mosaic.NewVector(4, 1)},
+			want:   false,
+		},
+		{
+			name: "diagonal corner gap",
+			setup: setup{
+				builder: Builder{
+					x:    5,
+					y:    5,
+					size: 32,
+					layout: "" +
+						"0x000" +
+						"00000" +
+						"00000" +
+						"00000" +
+						"00000",
+				},
+			},
+			params: params{a: mosaic.NewVector(0, 0), b: mosaic.NewVector(1, 1)},
+			want:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scale := func(b Builder, v float64) float64 {
+				return v*float64(b.size) + float64(b.size)/2
+			}
+			sg := lattice.NewSpatialGrid[int](
+				tt.setup.builder.x,
+				tt.setup.builder.y,
+				float64(tt.setup.builder.size),
+			)
+			setup_grid(sg, tt.setup.builder)
+			a := mosaic.NewVector(scale(tt.setup.builder, tt.params.a.X), scale(tt.setup.builder, tt.params.a.Y))
+			b := mosaic.NewVector(scale(tt.setup.builder, tt.params.b.X), scale(tt.setup.builder, tt.params.b.Y))
+			blocks := func(weight float64) bool {
+				return math.IsInf(weight, 1)
+			}
+
+			got := sg.HasLineOfSight(a, b, blocks)
+			if got != tt.want {
+				t.Error(fmt.Errorf("spatialGrid.HasLineOfSight() want: %+v, got: %+v\n", tt.want, got))
+			}
+
+			got = sg.HasLineOfSight(b, a, blocks)
+			if got != tt.want {
+				t.Error(fmt.Errorf("spatialGrid.HasLineOfSight() [reversed] want: %+v, got: %+v\n", tt.want, got))
+			}
+		})
+	}
+}
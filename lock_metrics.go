@@ -0,0 +1,150 @@
+package lattice
+
+import (
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+type (
+	// LockStats describes how one operation acquired the grid lock. An
+	// acquisition is contended when the lock was not free on the first try,
+	// and only contended acquisitions add to Wait.
+	LockStats struct {
+		Operation    string
+		Write        bool
+		Acquisitions int64
+		Contended    int64
+		Wait         time.Duration
+		MaxWait      time.Duration
+	}
+
+	lockMetrics struct {
+		mu    sync.Mutex
+		names map[uintptr]string
+		stats map[lockKey]*LockStats
+	}
+
+	lockKey struct {
+		operation string
+		write     bool
+	}
+)
+
+// WithLockMetrics records lock waits per operation for LockMetrics. It only
+// has an effect on the mutex based locking modes and costs a caller lookup
+// on every lock, so it is meant for profiling builds.
+func WithLockMetrics() Option {
+	return func(c *config) error {
+		c.lockMetrics = true
+		return nil
+	}
+}
+
+func newLockMetrics() *lockMetrics {
+	return &lockMetrics{
+		names: map[uintptr]string{},
+		stats: map[lockKey]*LockStats{},
+	}
+}
+
+// LockMetrics returns the stats recorded since the grid was created or the
+// last ResetLockMetrics, ordered by operation with reads first
+func (sg *SpatialGrid[T]) LockMetrics() []LockStats {
+	if sg.metrics == nil {
+		return []LockStats{}
+	}
+
+	m := sg.metrics
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stats := make([]LockStats, 0, len(m.stats))
+	for _, s := range m.stats {
+		stats = append(stats, *s)
+	}
+	slices.SortFunc(stats, func(a, b LockStats) int {
+		if c := strings.Compare(a.Operation, b.Operation); c != 0 {
+			return c
+		}
+		if a.Write == b.Write {
+			return 0
+		}
+		if b.Write {
+			return -1
+		}
+		return 1
+	})
+
+	return stats
+}
+
+func (sg *SpatialGrid[T]) ResetLockMetrics() {
+	if sg.metrics == nil {
+		return
+	}
+
+	sg.metrics.mu.Lock()
+	defer sg.metrics.mu.Unlock()
+
+	clear(sg.metrics.stats)
+}
+
+// acquire takes the lock through try or lock, charging any wait to the
+// method that called lock or rlock
+func (m *lockMetrics) acquire(try func() bool, lock func(), write bool) {
+	var pcs [1]uintptr
+	runtime.Callers(3, pcs[:])
+
+	contended := !try()
+	var wait time.Duration
+	if contended {
+		start := time.Now()
+		lock()
+		wait = time.Since(start)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := lockKey{operation: m.name(pcs[0]), write: write}
+	s, ok := m.stats[key]
+	if !ok {
+		s = &LockStats{Operation: key.operation, Write: write}
+		m.stats[key] = s
+	}
+	s.Acquisitions++
+	if contended {
+		s.Contended++
+		s.Wait += wait
+		s.MaxWait = max(s.MaxWait, wait)
+	}
+}
+
+// name turns a caller into its receiver and method, such as
+// "SpatialGrid.Insert"
+func (m *lockMetrics) name(pc uintptr) string {
+	name, ok := m.names[pc]
+	if ok {
+		return name
+	}
+
+	name = "unknown"
+	fn := runtime.FuncForPC(pc)
+	if fn != nil {
+		name = fn.Name()
+		name = name[strings.LastIndex(name, "/")+1:]
+		name = name[strings.Index(name, ".")+1:]
+		if i := strings.Index(name, "["); i >= 0 {
+			if j := strings.Index(name[i:], "]"); j >= 0 {
+				name = name[:i] + name[i+j+1:]
+			}
+		}
+		name = strings.NewReplacer("(*", "", ")", "").Replace(name)
+	}
+	m.names[pc] = name
+
+	return name
+}
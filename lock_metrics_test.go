@@ -0,0 +1,101 @@
+package lattice_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/mosaic"
+)
+
+func Test_spatial_grid_LockMetrics(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []lattice.Option
+		want []lattice.LockStats
+	}{
+		{
+			name: "disabled",
+			want: []lattice.LockStats{},
+		},
+		{
+			name: "read write",
+			opts: []lattice.Option{lattice.WithLockMetrics()},
+			want: []lattice.LockStats{
+				{Operation: "SpatialGrid.FindNear", Acquisitions: 3},
+				{Operation: "SpatialGrid.Insert", Write: true, Acquisitions: 2},
+			},
+		},
+		{
+			name: "exclusive",
+			opts: []lattice.Option{lattice.WithLockMetrics(), lattice.WithLocking(lattice.LockingExclusive)},
+			want: []lattice.LockStats{
+				{Operation: "SpatialGrid.FindNear", Acquisitions: 3},
+				{Operation: "SpatialGrid.Insert", Write: true, Acquisitions: 2},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg, err := lattice.NewSpatialGridOpts[int](4, 4, 32, tt.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			bounds := mosaic.NewRectangle(mosaic.NewVector(16, 16), 8, 8)
+			sg.Insert(lattice.Item[int]{1, bounds, 1})
+			sg.Insert(lattice.Item[int]{2, bounds, 1})
+			for i := 0; i < 3; i++ {
+				sg.FindNear(bounds)
+			}
+
+			got := sg.LockMetrics()
+			if len(got) != len(tt.want) {
+				t.Fatal(fmt.Errorf("spatialGrid.LockMetrics() want: %+v, got: %+v\n", tt.want, got))
+			}
+			for i := range got {
+				// nothing contends in a single goroutine
+				if got[i] != tt.want[i] {
+					t.Error(fmt.Errorf("spatialGrid.LockMetrics() want: %+v, got: %+v\n", tt.want[i], got[i]))
+				}
+			}
+
+			sg.ResetLockMetrics()
+			if len(sg.LockMetrics()) != 0 {
+				t.Error(fmt.Errorf("spatialGrid.ResetLockMetrics() want: %+v, got: %+v\n", []lattice.LockStats{}, sg.LockMetrics()))
+			}
+		})
+	}
+}
+
+func Test_spatial_grid_LockMetrics_contention(t *testing.T) {
+	sg, err := lattice.NewSpatialGridOpts[int](4, 4, 32, lattice.WithLockMetrics())
+	if err != nil {
+		t.Fatal(err)
+	}
+	bounds := mosaic.NewRectangle(mosaic.NewVector(16, 16), 8, 8)
+
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				sg.Insert(lattice.Item[int]{w*1000 + i, bounds, 1})
+				sg.FindNear(bounds)
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	acquisitions := int64(0)
+	for _, s := range sg.LockMetrics() {
+		acquisitions += s.Acquisitions
+		if s.Contended > s.Acquisitions || (s.Contended == 0 && s.Wait != 0) || s.MaxWait > s.Wait {
+			t.Error(fmt.Errorf("spatialGrid.LockMetrics() inconsistent stats: %+v\n", s))
+		}
+	}
+	if acquisitions != 1600 {
+		t.Error(fmt.Errorf("spatialGrid.LockMetrics() want: %+v acquisitions, got: %+v\n", 1600, acquisitions))
+	}
+}
@@ -0,0 +1,107 @@
+package lattice
+
+import "unsafe"
+
+func (sg *SpatialGrid[T]) lock() {
+	switch sg.config.locking {
+	case lockingSnapshot:
+		panic("lattice: write to a read-only grid snapshot")
+	case LockingNone:
+	case LockingAssert:
+		if !sg.access.CompareAndSwap(0, -1) {
+			panic(ErrConcurrentAccess)
+		}
+	default:
+		if sg.metrics != nil {
+			sg.metrics.acquire(sg.nodesMu.TryLock, sg.nodesMu.Lock, true)
+			break
+		}
+		sg.nodesMu.Lock()
+	}
+	sg.writes++
+}
+
+func (sg *SpatialGrid[T]) unlock() {
+	notices := sg.notices
+	sg.notices = nil
+
+	switch sg.config.locking {
+	case LockingNone:
+	case LockingAssert:
+		sg.access.Store(0)
+	case LockingReadOptimized:
+		sg.snapshot.Store(sg.clone())
+		sg.nodesMu.Unlock()
+	default:
+		sg.nodesMu.Unlock()
+	}
+
+	if len(notices) > 0 {
+		sg.deliver(notices)
+	}
+}
+
+// rlock returns the grid reads should run against, which is the latest
+// published snapshot in read-optimized mode
+func (sg *SpatialGrid[T]) rlock() *SpatialGrid[T] {
+	switch sg.config.locking {
+	case LockingExclusive:
+		if sg.metrics != nil {
+			sg.metrics.acquire(sg.nodesMu.TryLock, sg.nodesMu.Lock, false)
+			break
+		}
+		sg.nodesMu.Lock()
+	case LockingReadOptimized:
+		return sg.snapshot.Load()
+	case LockingNone, lockingSnapshot:
+	case LockingAssert:
+		// readers may overlap each other, just never a writer
+		if sg.access.Add(1) <= 0 {
+			panic(ErrConcurrentAccess)
+		}
+	default:
+		if sg.metrics != nil {
+			sg.metrics.acquire(sg.nodesMu.TryRLock, sg.nodesMu.RLock, false)
+			break
+		}
+		sg.nodesMu.RLock()
+	}
+
+	return sg
+}
+
+func (sg *SpatialGrid[T]) runlock() {
+	switch sg.config.locking {
+	case LockingExclusive:
+		sg.nodesMu.Unlock()
+	case LockingReadOptimized, LockingNone, lockingSnapshot:
+	case LockingAssert:
+		sg.access.Add(-1)
+	default:
+		sg.nodesMu.RUnlock()
+	}
+}
+
+// rlockPair read locks two grids in address order. Taking them in argument
+// order lets a.X(b) and b.X(a) each hold one read lock while a writer queued
+// on the other grid blocks the second, which deadlocks. The returned grids
+// are the ones to read and release unlocks both.
+func rlockPair[T, U comparable](a *SpatialGrid[T], b *SpatialGrid[U]) (*SpatialGrid[T], *SpatialGrid[U], func()) {
+	if any(a) == any(b) {
+		a = a.rlock()
+		return a, any(a).(*SpatialGrid[U]), a.runlock
+	}
+
+	if uintptr(unsafe.Pointer(b)) < uintptr(unsafe.Pointer(a)) {
+		b = b.rlock()
+		a = a.rlock()
+	} else {
+		a = a.rlock()
+		b = b.rlock()
+	}
+
+	return a, b, func() {
+		b.runlock()
+		a.runlock()
+	}
+}
@@ -0,0 +1,81 @@
+package lattice
+
+import "slices"
+
+// MapValues returns an independent copy of sg with every stored value passed
+// through f. Weights, terrain, paint, labels, cell data, portals and edge rules carry over,
+// subscriptions, deadlines, cell history and lock metrics do not.
+func MapValues[T, U comparable](sg *SpatialGrid[T], f func(T) U) *SpatialGrid[U] {
+	sg = sg.rlock()
+	defer sg.runlock()
+
+	nodes := make([][]spatialGridNode[U], len(sg.Nodes))
+	for x := range sg.Nodes {
+		nodes[x] = make([]spatialGridNode[U], len(sg.Nodes[x]))
+		for y, node := range sg.Nodes[x] {
+			items := make([]spatialGridNodeItem[U], len(node.Items), max(len(node.Items), sg.config.capacity))
+			for i, item := range node.Items {
+				items[i] = newSpatialGridNodeItem(f(item.value), item.bounds, item.weight, item.multiplier)
+			}
+
+			nodes[x][y] = spatialGridNode[U]{
+				x:        node.x,
+				y:        node.y,
+				bounds:   node.bounds,
+				weight:   node.weight,
+				terrain:  node.terrain,
+				paint:    node.paint,
+				restored: node.restored,
+				label:    node.label,
+				data:     node.data,
+				scent:    node.scent,
+				heat:     node.heat,
+				Items:    items,
+				packed:   node.packed.clone(),
+				static:   node.static,
+				pinned:   node.pinned,
+			}
+		}
+	}
+
+	overflow := make([]spilled[U], len(sg.overflow))
+	for i, item := range sg.overflow {
+		overflow[i] = spilled[U]{
+			Item:      Item[U]{Value: f(item.Value), Bounds: item.Bounds, Multiplier: item.Multiplier},
+			partition: item.partition,
+		}
+	}
+
+	cfg := sg.config
+	if cfg.locking == lockingSnapshot {
+		cfg.locking = LockingReadOptimized
+	}
+	mapped := &SpatialGrid[U]{
+		Nodes:      nodes,
+		SizeX:      sg.SizeX,
+		SizeY:      sg.SizeY,
+		ChunkSize:  sg.ChunkSize,
+		origin:     sg.origin,
+		overflow:   slices.Clip(overflow),
+		itemCount:  sg.itemCount,
+		scentDecay: sg.scentDecay,
+		heatCool:   sg.heatCool,
+		heatCurve:  sg.heatCurve,
+		blocked:    sg.blocked.clone(),
+		blockedAt:  sg.blockedAt,
+		portals:    sg.clonePortals(),
+		edgeRules:  sg.cloneEdgeRules(),
+		writes:     sg.writes,
+		shape:      sg.shape,
+		goalBounds: sg.goalBounds,
+		config:     cfg,
+	}
+	if cfg.lockMetrics {
+		mapped.metrics = newLockMetrics()
+	}
+	if cfg.locking == LockingReadOptimized {
+		mapped.snapshot.Store(mapped.clone())
+	}
+
+	return mapped
+}
@@ -0,0 +1,70 @@
+package lattice_test
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/mosaic"
+)
+
+func Test_MapValues(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []lattice.Option
+	}{
+		{name: "read write"},
+		{name: "read optimized", opts: []lattice.Option{lattice.WithReadOptimized()}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg, err := lattice.NewSpatialGridOpts[int](8, 8, 16, tt.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i := 0; i < 8; i++ {
+				sg.Insert(lattice.Item[int]{
+					i,
+					mosaic.NewRectangle(mosaic.NewVector(float64(i*16+8), 40), 16, 16),
+					float64(i),
+				})
+			}
+			sg.SetTerrain(3, 3, 5)
+
+			mapped := lattice.MapValues(sg, strconv.Itoa)
+			sg.Delete(0, mosaic.NewRectangle(mosaic.NewVector(8, 40), 16, 16))
+
+			bounds := mosaic.NewRectangle(mosaic.NewVector(32, 40), 64, 16)
+			want := []string{}
+			for _, v := range sg.FindNear(bounds) {
+				want = append(want, strconv.Itoa(v))
+			}
+			want = append(want, "0")
+			got := mapped.FindNear(bounds)
+			slices.Sort(want)
+			slices.Sort(got)
+			if !slices.Equal(want, got) {
+				t.Error(fmt.Errorf("lattice.MapValues() want: %+v, got: %+v\n", want, got))
+			}
+
+			for x := 1; x < 8; x++ {
+				for y := 0; y < 8; y++ {
+					if sg.GetLocationWeight(x, y) != mapped.GetLocationWeight(x, y) {
+						t.Error(fmt.Errorf("lattice.MapValues() weight at %d, %d want: %+v, got: %+v\n", x, y, sg.GetLocationWeight(x, y), mapped.GetLocationWeight(x, y)))
+					}
+				}
+			}
+			if mapped.Size() != 8 {
+				t.Error(fmt.Errorf("lattice.MapValues() size want: %+v, got: %+v\n", 8, mapped.Size()))
+			}
+
+			// the copy stays writable
+			err = mapped.Delete("7", mosaic.NewRectangle(mosaic.NewVector(120, 40), 16, 16))
+			if err != nil || mapped.Size() != 7 || sg.Size() != 7 {
+				t.Error(fmt.Errorf("spatialGrid.Delete() want: %+v, got: %+v, %+v\n", 7, mapped.Size(), err))
+			}
+		})
+	}
+}
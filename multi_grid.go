@@ -0,0 +1,298 @@
+package lattice
+
+import (
+	"errors"
+	"math"
+	"sync"
+
+	"github.com/maladroitthief/mosaic"
+)
+
+type (
+	// MultiGrid routes items and queries to whichever sub grid covers them.
+	// Sub grids should not overlap, the first one covering a point owns it.
+	MultiGrid[T comparable] struct {
+		mu          sync.RWMutex
+		grids       []*SpatialGrid[T]
+		connections []Connection
+	}
+
+	// Connection is a one-way link from a point in one sub grid to a point in
+	// another, such as a door into an interior
+	Connection struct {
+		From      int
+		FromPoint mosaic.Vector
+		To        int
+		ToPoint   mosaic.Vector
+		Cost      float64
+	}
+
+	// MultiPath is a route split into one leg per stretch spent in a sub grid.
+	// Cost includes the connections taken between legs.
+	MultiPath struct {
+		Legs []PathLeg
+		Cost float64
+	}
+
+	PathLeg struct {
+		Grid int
+		Path Path
+	}
+)
+
+var (
+	ErrInvalidGrid = errors.New("no sub grid with that index")
+)
+
+func NewMultiGrid[T comparable](grids ...*SpatialGrid[T]) *MultiGrid[T] {
+	return &MultiGrid[T]{grids: grids}
+}
+
+// AddGrid appends a sub grid and returns its index
+func (mg *MultiGrid[T]) AddGrid(sg *SpatialGrid[T]) int {
+	mg.mu.Lock()
+	defer mg.mu.Unlock()
+
+	mg.grids = append(mg.grids, sg)
+	return len(mg.grids) - 1
+}
+
+func (mg *MultiGrid[T]) Grid(i int) *SpatialGrid[T] {
+	mg.mu.RLock()
+	defer mg.mu.RUnlock()
+
+	if i < 0 || i >= len(mg.grids) {
+		return nil
+	}
+	return mg.grids[i]
+}
+
+// GridAt returns the index of the sub grid covering position
+func (mg *MultiGrid[T]) GridAt(position mosaic.Vector) (int, bool) {
+	mg.mu.RLock()
+	defer mg.mu.RUnlock()
+
+	return mg.gridAt(position)
+}
+
+func (mg *MultiGrid[T]) gridAt(position mosaic.Vector) (int, bool) {
+	for i, sg := range mg.grids {
+		if mg.covers(sg, position) {
+			return i, true
+		}
+	}
+
+	return 0, false
+}
+
+func (mg *MultiGrid[T]) covers(sg *SpatialGrid[T], position mosaic.Vector) bool {
+	sg = sg.rlock()
+	defer sg.runlock()
+
+	_, _, ok := sg.WorldToCell(position)
+	return ok
+}
+
+func (mg *MultiGrid[T]) Connect(c Connection) error {
+	mg.mu.Lock()
+	defer mg.mu.Unlock()
+
+	for _, end := range []struct {
+		grid  int
+		point mosaic.Vector
+	}{{c.From, c.FromPoint}, {c.To, c.ToPoint}} {
+		if end.grid < 0 || end.grid >= len(mg.grids) {
+			return ErrInvalidGrid
+		}
+		if !mg.covers(mg.grids[end.grid], end.point) {
+			return ErrOutOfBounds
+		}
+	}
+
+	mg.connections = append(mg.connections, c)
+	return nil
+}
+
+func (mg *MultiGrid[T]) Insert(item Item[T]) error {
+	mg.mu.RLock()
+	defer mg.mu.RUnlock()
+
+	i, ok := mg.gridAt(item.Bounds.Position)
+	if !ok {
+		return ErrOutOfBounds
+	}
+
+	return mg.grids[i].Insert(item)
+}
+
+func (mg *MultiGrid[T]) Delete(val T, bounds mosaic.Rectangle) error {
+	mg.mu.RLock()
+	defer mg.mu.RUnlock()
+
+	i, ok := mg.gridAt(bounds.Position)
+	if !ok {
+		return ErrOutOfBounds
+	}
+
+	return mg.grids[i].Delete(val, bounds)
+}
+
+// Update moves item between sub grids when its new position is owned by a
+// different one
+func (mg *MultiGrid[T]) Update(item Item[T], oldBounds mosaic.Rectangle) error {
+	mg.mu.RLock()
+	defer mg.mu.RUnlock()
+
+	from, ok := mg.gridAt(oldBounds.Position)
+	if !ok {
+		return ErrOutOfBounds
+	}
+	to, ok := mg.gridAt(item.Bounds.Position)
+	if !ok {
+		return ErrOutOfBounds
+	}
+	if from == to {
+		return mg.grids[to].Update(item, oldBounds)
+	}
+
+	err := mg.grids[from].Delete(item.Value, oldBounds)
+	if err != nil && !errors.Is(err, ErrItemNotFound) {
+		return err
+	}
+
+	return mg.grids[to].Insert(item)
+}
+
+// FindNear merges the results of every sub grid bounds overlaps
+func (mg *MultiGrid[T]) FindNear(bounds mosaic.Rectangle) []T {
+	mg.mu.RLock()
+	defer mg.mu.RUnlock()
+
+	values := []T{}
+	for _, sg := range mg.grids {
+		if !mg.overlaps(sg, bounds) {
+			continue
+		}
+		values = append(values, sg.FindNear(bounds)...)
+	}
+
+	return values
+}
+
+func (mg *MultiGrid[T]) overlaps(sg *SpatialGrid[T], bounds mosaic.Rectangle) bool {
+	sg = sg.rlock()
+	defer sg.runlock()
+
+	low, high := sg.CellToWorld(0, 0), sg.CellToWorld(sg.SizeX, sg.SizeY)
+	minPoint, maxPoint := bounds.MinPoint(), bounds.MaxPoint()
+
+	return maxPoint.X >= low.X && maxPoint.Y >= low.Y && minPoint.X < high.X && minPoint.Y < high.Y
+}
+
+// FindPath plans over the graph of start, end and the connection endpoints,
+// where points in the same sub grid are joined by a regular FindPath with
+// opts. Each leg is searched at most once per call.
+func (mg *MultiGrid[T]) FindPath(start, end mosaic.Vector, opts PathOptions) (MultiPath, error) {
+	mg.mu.RLock()
+	defer mg.mu.RUnlock()
+
+	type point struct {
+		grid     int
+		position mosaic.Vector
+	}
+	startGrid, ok := mg.gridAt(start)
+	if !ok {
+		return MultiPath{}, ErrOutOfBounds
+	}
+	endGrid, ok := mg.gridAt(end)
+	if !ok {
+		return MultiPath{}, ErrOutOfBounds
+	}
+
+	// 0 is the start, 1 the end, then the two ends of every connection
+	points := []point{{startGrid, start}, {endGrid, end}}
+	for _, c := range mg.connections {
+		points = append(points, point{c.From, c.FromPoint}, point{c.To, c.ToPoint})
+	}
+
+	type leg struct {
+		path Path
+		ok   bool
+	}
+	legs := map[[2]int]leg{}
+	walk := func(from, to int) leg {
+		key := [2]int{from, to}
+		cached, ok := legs[key]
+		if ok {
+			return cached
+		}
+
+		path, err := mg.grids[points[from].grid].FindPath(points[from].position, points[to].position, opts)
+		cached = leg{path: path, ok: err == nil}
+		legs[key] = cached
+		return cached
+	}
+
+	costs := make([]float64, len(points))
+	cameFrom := make([]int, len(points))
+	settled := make([]bool, len(points))
+	for i := range costs {
+		costs[i] = math.Inf(1)
+		cameFrom[i] = -1
+	}
+	costs[0] = 0
+
+	for {
+		current := -1
+		for i := range points {
+			if !settled[i] && !math.IsInf(costs[i], 1) && (current < 0 || costs[i] < costs[current]) {
+				current = i
+			}
+		}
+		if current < 0 || current == 1 {
+			break
+		}
+		settled[current] = true
+
+		relax := func(next int, cost float64) {
+			if cost < costs[next] {
+				costs[next] = cost
+				cameFrom[next] = current
+			}
+		}
+		// the far end of a connection is only reachable through it
+		if current >= 2 && current%2 == 0 {
+			c := mg.connections[(current-2)/2]
+			relax(current+1, costs[current]+c.Cost)
+		}
+		for next := 1; next < len(points); next++ {
+			if settled[next] || points[next].grid != points[current].grid || (next >= 2 && next%2 == 1) {
+				continue
+			}
+			l := walk(current, next)
+			if l.ok {
+				relax(next, costs[current]+l.path.Cost)
+			}
+		}
+	}
+
+	if math.IsInf(costs[1], 1) {
+		return MultiPath{}, ErrPathNotFound
+	}
+
+	route := []int{}
+	for current := 1; current >= 0; current = cameFrom[current] {
+		route = append(route, current)
+	}
+
+	result := MultiPath{Legs: []PathLeg{}, Cost: costs[1]}
+	for i := len(route) - 1; i > 0; i-- {
+		from, to := route[i], route[i-1]
+		if points[from].grid != points[to].grid || (from >= 2 && from%2 == 0 && to == from+1) {
+			continue
+		}
+		result.Legs = append(result.Legs, PathLeg{Grid: points[from].grid, Path: walk(from, to).path})
+	}
+
+	return result, nil
+}
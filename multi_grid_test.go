@@ -0,0 +1,135 @@
+package lattice_test
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/mosaic"
+)
+
+func newMultiGrid(t *testing.T) *lattice.MultiGrid[int] {
+	outside, err := lattice.NewSpatialGridOpts[int](4, 4, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	interior, err := lattice.NewSpatialGridOpts[int](2, 2, 8, lattice.WithOrigin(100, 100))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return lattice.NewMultiGrid(outside, interior)
+}
+
+func Test_multi_grid_routing(t *testing.T) {
+	tests := []struct {
+		name     string
+		position mosaic.Vector
+		grid     int
+		err      error
+	}{
+		{name: "outside", position: mosaic.NewVector(4, 28), grid: 0},
+		{name: "interior", position: mosaic.NewVector(112, 104), grid: 1},
+		{name: "between grids", position: mosaic.NewVector(50, 50), err: lattice.ErrOutOfBounds},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mg := newMultiGrid(t)
+			bounds := mosaic.NewRectangle(tt.position, 2, 2)
+			err := mg.Insert(lattice.Item[int]{1, bounds, 1})
+			if !errors.Is(err, tt.err) {
+				t.Fatal(fmt.Errorf("multiGrid.Insert() want error: %+v, got error: %+v\n", tt.err, err))
+			}
+			if err != nil {
+				return
+			}
+
+			if mg.Grid(tt.grid).Size() != 1 {
+				t.Error(fmt.Errorf("multiGrid.Insert() want grid: %+v, got size: %+v\n", tt.grid, mg.Grid(tt.grid).Size()))
+			}
+			got := mg.FindNear(bounds)
+			if !slices.Equal(got, []int{1}) {
+				t.Error(fmt.Errorf("multiGrid.FindNear() want: %+v, got: %+v\n", []int{1}, got))
+			}
+
+			moved := mosaic.NewRectangle(mosaic.NewVector(108, 108), 2, 2)
+			err = mg.Update(lattice.Item[int]{1, moved, 1}, bounds)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if mg.Grid(1).Size() != 1 || mg.Grid(0).Size() != 0 {
+				t.Error(fmt.Errorf("multiGrid.Update() want sizes: %+v, got: %+v\n", []int{0, 1}, []int{mg.Grid(0).Size(), mg.Grid(1).Size()}))
+			}
+		})
+	}
+}
+
+func Test_multi_grid_FindPath(t *testing.T) {
+	type want struct {
+		legs []int
+		cost float64
+		err  error
+	}
+	tests := []struct {
+		name        string
+		connections []lattice.Connection
+		want        want
+	}{
+		{
+			name: "through a door",
+			connections: []lattice.Connection{
+				{From: 0, FromPoint: mosaic.NewVector(28, 28), To: 1, ToPoint: mosaic.NewVector(104, 104), Cost: 5},
+			},
+			want: want{legs: []int{0, 1}, cost: 5},
+		},
+		{
+			name: "cheapest door",
+			connections: []lattice.Connection{
+				{From: 0, FromPoint: mosaic.NewVector(28, 28), To: 1, ToPoint: mosaic.NewVector(104, 104), Cost: 50},
+				{From: 0, FromPoint: mosaic.NewVector(4, 4), To: 1, ToPoint: mosaic.NewVector(104, 104), Cost: 20},
+			},
+			want: want{legs: []int{0, 1}, cost: 20},
+		},
+		{
+			name: "one way door",
+			connections: []lattice.Connection{
+				{From: 1, FromPoint: mosaic.NewVector(104, 104), To: 0, ToPoint: mosaic.NewVector(28, 28), Cost: 5},
+			},
+			want: want{err: lattice.ErrPathNotFound},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mg := newMultiGrid(t)
+			for _, c := range tt.connections {
+				if err := mg.Connect(c); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			got, err := mg.FindPath(mosaic.NewVector(4, 4), mosaic.NewVector(108, 108), lattice.PathOptions{})
+			if !errors.Is(err, tt.want.err) {
+				t.Fatal(fmt.Errorf("multiGrid.FindPath() want error: %+v, got error: %+v\n", tt.want.err, err))
+			}
+			if err != nil {
+				return
+			}
+
+			legs := []int{}
+			for _, leg := range got.Legs {
+				legs = append(legs, leg.Grid)
+			}
+			if !slices.Equal(legs, tt.want.legs) || got.Cost != tt.want.cost {
+				t.Error(fmt.Errorf("multiGrid.FindPath() want: %+v, got: %+v\n", tt.want, got))
+			}
+		})
+	}
+
+	mg := newMultiGrid(t)
+	err := mg.Connect(lattice.Connection{From: 0, To: 2})
+	if !errors.Is(err, lattice.ErrInvalidGrid) {
+		t.Error(fmt.Errorf("multiGrid.Connect() want error: %+v, got error: %+v\n", lattice.ErrInvalidGrid, err))
+	}
+}
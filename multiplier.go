@@ -0,0 +1,36 @@
+package lattice
+
+// SetMultiplier rescales every stored copy of val in place, so toggling a
+// door or slow field doesn't need the bounds it was inserted with.
+func (sg *SpatialGrid[T]) SetMultiplier(val T, multiplier float64) {
+	sg.lock()
+	defer sg.unlock()
+
+	for x := range sg.Nodes {
+		for y := range sg.Nodes[x] {
+			node, ok := sg.Nodes[x][y].setMultiplier(val, multiplier, sg.config.weigh)
+			if !ok {
+				continue
+			}
+			sg.Nodes[x][y] = node
+			sg.updateBlocked(x, y)
+		}
+	}
+}
+
+func (sgn spatialGridNode[T]) setMultiplier(val T, multiplier float64, weigh WeightFunc) (spatialGridNode[T], bool) {
+	found := false
+	for i := 0; i < len(sgn.Items); i++ {
+		if sgn.Items[i].value != val {
+			continue
+		}
+		sgn.Items[i].multiplier = multiplier
+		sgn.Items[i].weight = weigh(sgn.bounds, sgn.Items[i].bounds, multiplier)
+		found = true
+	}
+	if found {
+		sgn.weight = sgn.itemWeights()
+	}
+
+	return sgn, found
+}
@@ -0,0 +1,61 @@
+package lattice_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/mosaic"
+)
+
+func Test_spatial_grid_SetMultiplier(t *testing.T) {
+	type want struct {
+		weight  float64
+		blocked bool
+	}
+	tests := []struct {
+		name       string
+		item       int
+		multiplier float64
+		want       want
+	}{
+		{
+			name:       "slow field",
+			item:       1,
+			multiplier: 3,
+			want:       want{weight: 4*3 + 4, blocked: false},
+		},
+		{
+			name:       "door closes",
+			item:       1,
+			multiplier: math.Inf(1),
+			want:       want{weight: math.Inf(1), blocked: true},
+		},
+		{
+			name:       "unknown item",
+			item:       3,
+			multiplier: 5,
+			want:       want{weight: 4 + 4, blocked: false},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg := lattice.NewSpatialGrid[int](4, 4, 8)
+			sg.Insert(lattice.Item[int]{1, mosaic.NewRectangle(mosaic.Vector{X: 4, Y: 4}, 2, 2), 1.0})
+			sg.Insert(lattice.Item[int]{2, mosaic.NewRectangle(mosaic.Vector{X: 2, Y: 2}, 2, 2), 1.0})
+
+			sg.SetMultiplier(tt.item, tt.multiplier)
+			got := want{weight: sg.GetLocationWeight(0, 0), blocked: sg.Blocked(0, 0)}
+			if got != tt.want {
+				t.Error(fmt.Errorf("spatialGrid.SetMultiplier() want: %+v, got: %+v\n", tt.want, got))
+			}
+
+			sg.SetMultiplier(tt.item, 1)
+			got = want{weight: sg.GetLocationWeight(0, 0), blocked: sg.Blocked(0, 0)}
+			if got.weight != 8 || got.blocked {
+				t.Error(fmt.Errorf("spatialGrid.SetMultiplier() want: %+v, got: %+v\n", want{weight: 8}, got))
+			}
+		})
+	}
+}
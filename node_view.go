@@ -0,0 +1,46 @@
+package lattice
+
+import "github.com/maladroitthief/mosaic"
+
+// NodeView is a read-only copy of a cell taken when Node or NodeAtPosition
+// was called, later writes to the grid do not show up in it
+type NodeView[T comparable] struct {
+	x      int
+	y      int
+	bounds mosaic.Rectangle
+	weight float64
+	items  []T
+}
+
+func (sgn spatialGridNode[T]) view() NodeView[T] {
+	return NodeView[T]{
+		x:      sgn.x,
+		y:      sgn.y,
+		bounds: sgn.bounds,
+		weight: sgn.weight,
+		items:  sgn.Values(),
+	}
+}
+
+func (nv NodeView[T]) X() int {
+	return nv.x
+}
+
+func (nv NodeView[T]) Y() int {
+	return nv.y
+}
+
+func (nv NodeView[T]) Bounds() mosaic.Rectangle {
+	return nv.bounds
+}
+
+func (nv NodeView[T]) Weight() float64 {
+	return nv.weight
+}
+
+func (nv NodeView[T]) Items() []T {
+	items := make([]T, len(nv.items))
+	copy(items, nv.items)
+
+	return items
+}
@@ -0,0 +1,72 @@
+package lattice_test
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/mosaic"
+)
+
+func Test_spatial_grid_Node(t *testing.T) {
+	type want struct {
+		x, y   int
+		bounds mosaic.Rectangle
+		weight float64
+		items  []int
+	}
+	tests := []struct {
+		name     string
+		position mosaic.Vector
+		want     want
+	}{
+		{
+			name:     "occupied cell",
+			position: mosaic.NewVector(12, 4),
+			want: want{
+				x:      1,
+				y:      0,
+				bounds: mosaic.NewRectangle(mosaic.NewVector(12, 4), 8, 8),
+				weight: 8,
+				items:  []int{1, 2},
+			},
+		},
+		{
+			name:     "empty cell",
+			position: mosaic.NewVector(28, 28),
+			want: want{
+				x:      3,
+				y:      3,
+				bounds: mosaic.NewRectangle(mosaic.NewVector(28, 28), 8, 8),
+				weight: 0,
+				items:  []int{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg := lattice.NewSpatialGrid[int](4, 4, 8)
+			sg.Insert(lattice.Item[int]{1, mosaic.NewRectangle(mosaic.NewVector(12, 4), 2, 2), 1})
+			sg.Insert(lattice.Item[int]{2, mosaic.NewRectangle(mosaic.NewVector(12, 4), 2, 1), 2})
+
+			for _, node := range []lattice.NodeView[int]{
+				sg.NodeAtPosition(tt.position.X, tt.position.Y),
+				sg.Node(tt.want.x, tt.want.y),
+			} {
+				got := want{node.X(), node.Y(), node.Bounds(), node.Weight(), node.Items()}
+				slices.Sort(got.items)
+				if got.x != tt.want.x || got.y != tt.want.y || got.bounds != tt.want.bounds ||
+					got.weight != tt.want.weight || !slices.Equal(got.items, tt.want.items) {
+					t.Error(fmt.Errorf("spatialGrid.Node() want: %+v, got: %+v\n", tt.want, got))
+				}
+			}
+
+			view := sg.Node(tt.want.x, tt.want.y)
+			sg.Drop()
+			if len(view.Items()) != len(tt.want.items) {
+				t.Error(fmt.Errorf("nodeView.Items() want: %+v, got: %+v\n", tt.want.items, view.Items()))
+			}
+		})
+	}
+}
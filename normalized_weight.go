@@ -0,0 +1,21 @@
+package lattice
+
+// MaxWeight is the weight of a cell fully covered at the configured max
+// multiplier, the ceiling NormalizedWeight scales against
+func (sg *SpatialGrid[T]) MaxWeight() float64 {
+	return sg.ChunkSize * sg.ChunkSize * sg.config.maxMultiplier
+}
+
+// NormalizedWeight is the weight of cell x, y relative to MaxWeight, clamped
+// to [0, 1]
+func (sg *SpatialGrid[T]) NormalizedWeight(x, y int) float64 {
+	sg = sg.rlock()
+	defer sg.runlock()
+
+	weight := sg.Nodes[x][y].weight / sg.MaxWeight()
+	if weight <= 0 {
+		return 0
+	}
+
+	return min(weight, 1)
+}
@@ -0,0 +1,71 @@
+package lattice_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/mosaic"
+)
+
+func Test_spatial_grid_NormalizedWeight(t *testing.T) {
+	type params struct {
+		maxMultiplier float64
+		size          float64
+		multiplier    float64
+	}
+	type want struct {
+		max        float64
+		normalized float64
+	}
+	tests := []struct {
+		name   string
+		params params
+		want   want
+	}{
+		{
+			name:   "quarter covered",
+			params: params{maxMultiplier: 1, size: 4, multiplier: 1},
+			want:   want{max: 64, normalized: 0.25},
+		},
+		{
+			name:   "heavier max multiplier",
+			params: params{maxMultiplier: 4, size: 4, multiplier: 1},
+			want:   want{max: 256, normalized: 0.0625},
+		},
+		{
+			name:   "clamped above the max",
+			params: params{maxMultiplier: 1, size: 8, multiplier: 10},
+			want:   want{max: 64, normalized: 1},
+		},
+		{
+			name:   "blocked cell",
+			params: params{maxMultiplier: 1, size: 4, multiplier: math.Inf(1)},
+			want:   want{max: 64, normalized: 1},
+		},
+		{
+			name:   "negative weight",
+			params: params{maxMultiplier: 1, size: 4, multiplier: -1},
+			want:   want{max: 64, normalized: 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg, err := lattice.NewSpatialGridOpts[int](4, 4, 8, lattice.WithMaxMultiplier(tt.params.maxMultiplier))
+			if err != nil {
+				t.Fatal(err)
+			}
+			bounds := mosaic.NewRectangle(mosaic.NewVector(4, 4), tt.params.size, tt.params.size)
+			sg.Insert(lattice.Item[int]{1, bounds, tt.params.multiplier})
+
+			if sg.MaxWeight() != tt.want.max {
+				t.Error(fmt.Errorf("spatialGrid.MaxWeight() want: %+v, got: %+v\n", tt.want.max, sg.MaxWeight()))
+			}
+			got := sg.NormalizedWeight(0, 0)
+			if got != tt.want.normalized {
+				t.Error(fmt.Errorf("spatialGrid.NormalizedWeight() want: %+v, got: %+v\n", tt.want.normalized, got))
+			}
+		})
+	}
+}
@@ -0,0 +1,228 @@
+package lattice
+
+import (
+	"errors"
+	"math"
+
+	"github.com/maladroitthief/mosaic"
+)
+
+type (
+	Option func(*config) error
+
+	LockingMode int
+
+	Offset struct {
+		X int
+		Y int
+	}
+
+	config struct {
+		capacity      int
+		locking       LockingMode
+		neighbors     [][]int
+		stepL1        float64
+		stepLInf      float64
+		deterministic bool
+		precise       bool
+		strict        bool
+		autoGrow      bool
+		origin        mosaic.Vector
+		cellCap       int
+		overflow      Overflow
+		maxMultiplier float64
+		lockMetrics   bool
+		// boundsDiagnostics records out of bounds inserts for Stats
+		boundsDiagnostics bool
+		// history is how many mutations each cell remembers
+		history int
+		weigh   WeightFunc
+		// autoCompact is the spare capacity ratio that compacts a cell on
+		// delete, zero when off
+		autoCompact float64
+	}
+)
+
+const (
+	LockingReadWrite LockingMode = iota
+	LockingExclusive
+	LockingReadOptimized
+	LockingNone
+	LockingAssert
+
+	// lockingSnapshot marks the immutable grids published by
+	// LockingReadOptimized, which need no synchronization at all
+	lockingSnapshot
+)
+
+var (
+	FourWay  = []Offset{{0, 1}, {0, -1}, {1, 0}, {-1, 0}}
+	EightWay = []Offset{
+		{0, 1}, {0, -1}, {1, 0}, {-1, 0},
+		{1, 1}, {1, -1}, {-1, 1}, {-1, -1},
+	}
+	KnightMoves = []Offset{
+		{1, 2}, {2, 1}, {2, -1}, {1, -2},
+		{-1, -2}, {-2, -1}, {-2, 1}, {-1, 2},
+	}
+)
+
+var (
+	ErrInvalidDimensions = errors.New("grid dimensions must be positive")
+	ErrInvalidChunkSize  = errors.New("grid chunk size must be positive and finite")
+	ErrInvalidOption     = errors.New("invalid grid option")
+	ErrConcurrentAccess  = errors.New("grid accessed from multiple goroutines without locking")
+)
+
+func defaultConfig() config {
+	return config{
+		capacity:      512,
+		locking:       LockingReadWrite,
+		neighbors:     directions,
+		stepL1:        1,
+		stepLInf:      1,
+		maxMultiplier: 1,
+		weigh:         OverlapWeight,
+	}
+}
+
+func WithCapacity(capacity int) Option {
+	return func(c *config) error {
+		if capacity < 0 {
+			return ErrInvalidOption
+		}
+		c.capacity = capacity
+		return nil
+	}
+}
+
+func WithLocking(mode LockingMode) Option {
+	return func(c *config) error {
+		if mode < LockingReadWrite || mode > LockingAssert {
+			return ErrInvalidOption
+		}
+		c.locking = mode
+		return nil
+	}
+}
+
+func WithNeighbors(offsets ...Offset) Option {
+	return func(c *config) error {
+		if len(offsets) == 0 {
+			return ErrInvalidOption
+		}
+
+		neighbors := make([][]int, len(offsets))
+		stepL1, stepLInf := 0, 0
+		for i, offset := range offsets {
+			if offset.X == 0 && offset.Y == 0 {
+				return ErrInvalidOption
+			}
+			neighbors[i] = []int{offset.X, offset.Y}
+			stepL1 = max(stepL1, abs(offset.X)+abs(offset.Y))
+			stepLInf = max(stepLInf, abs(offset.X), abs(offset.Y))
+		}
+		c.neighbors = neighbors
+		c.stepL1, c.stepLInf = float64(stepL1), float64(stepLInf)
+		return nil
+	}
+}
+
+// WithDeterministicOrder makes queries return values in cell scan order
+// instead of map iteration order.
+func WithDeterministicOrder() Option {
+	return func(c *config) error {
+		c.deterministic = true
+		return nil
+	}
+}
+
+// WithPreciseQueries makes FindNear and FindNearSorted drop items whose own
+// bounds miss the query, instead of returning everything in the touched cells.
+func WithPreciseQueries() Option {
+	return func(c *config) error {
+		c.precise = true
+		return nil
+	}
+}
+
+// WithReadOptimized lets reads run lock-free against an immutable snapshot.
+// Every write deep copies the grid to publish a new one, so this only pays
+// off when writes are rare and batched (Reset, UpdateBatch) and reads are
+// heavy. Reads may briefly observe the state before an in-flight write.
+func WithReadOptimized() Option {
+	return WithLocking(LockingReadOptimized)
+}
+
+// WithNoLocking skips all synchronization. The caller guarantees the grid is
+// only ever touched from one goroutine at a time.
+func WithNoLocking() Option {
+	return WithLocking(LockingNone)
+}
+
+// WithLockAssertions also skips the mutex but panics with
+// ErrConcurrentAccess when a write overlaps any other access, for checking
+// that WithNoLocking is safe in a given program.
+func WithLockAssertions() Option {
+	return WithLocking(LockingAssert)
+}
+
+// WithStrictBounds makes positions outside the grid an error instead of
+// clamping them into the border cells. Writes and searches return
+// ErrOutOfBounds, range queries that miss the grid entirely return nothing.
+func WithStrictBounds() Option {
+	return func(c *config) error {
+		c.strict = true
+		return nil
+	}
+}
+
+// WithAutoGrow extends the grid in steps of autoGrowStep cells whenever an
+// insert lands outside it. Growing left or up moves the origin, so cell
+// indices held from before the insert (paths, reservations) go stale.
+func WithAutoGrow() Option {
+	return func(c *config) error {
+		c.autoGrow = true
+		return nil
+	}
+}
+
+// WithOrigin places the minimum corner of cell 0, 0 at x, y so the grid can
+// cover negative world coordinates
+func WithOrigin(x, y float64) Option {
+	return func(c *config) error {
+		if math.IsInf(x, 0) || math.IsNaN(x) || math.IsInf(y, 0) || math.IsNaN(y) {
+			return ErrInvalidOption
+		}
+
+		c.origin = mosaic.NewVector(x, y)
+		return nil
+	}
+}
+
+// WithCellCap limits how many items a single cell holds, with overflow
+// deciding what happens to inserts into a full cell
+func WithCellCap(limit int, overflow Overflow) Option {
+	return func(c *config) error {
+		if limit <= 0 || overflow < OverflowReject || overflow > OverflowSpill {
+			return ErrInvalidOption
+		}
+
+		c.cellCap = limit
+		c.overflow = overflow
+		return nil
+	}
+}
+
+// WithMaxMultiplier sets the multiplier of a fully covered cell that
+// NormalizedWeight treats as 1
+func WithMaxMultiplier(multiplier float64) Option {
+	return func(c *config) error {
+		if multiplier <= 0 || math.IsInf(multiplier, 0) || math.IsNaN(multiplier) {
+			return ErrInvalidOption
+		}
+
+		c.maxMultiplier = multiplier
+		return nil
+	}
+}
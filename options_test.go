@@ -0,0 +1,412 @@
+package lattice_test
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/mosaic"
+)
+
+func Test_spatial_grid_NewSpatialGridOpts(t *testing.T) {
+	type params struct {
+		x    int
+		y    int
+		size float64
+		opts []lattice.Option
+	}
+	tests := []struct {
+		name   string
+		params params
+		want   error
+	}{
+		{
+			name:   "defaults",
+			params: params{x: 4, y: 4, size: 8},
+		},
+		{
+			name:   "zero width",
+			params: params{x: 0, y: 4, size: 8},
+			want:   lattice.ErrInvalidDimensions,
+		},
+		{
+			name:   "negative height",
+			params: params{x: 4, y: -1, size: 8},
+			want:   lattice.ErrInvalidDimensions,
+		},
+		{
+			name:   "zero chunk size",
+			params: params{x: 4, y: 4, size: 0},
+			want:   lattice.ErrInvalidChunkSize,
+		},
+		{
+			name:   "infinite chunk size",
+			params: params{x: 4, y: 4, size: math.Inf(1)},
+			want:   lattice.ErrInvalidChunkSize,
+		},
+		{
+			name: "negative capacity",
+			params: params{x: 4, y: 4, size: 8, opts: []lattice.Option{
+				lattice.WithCapacity(-1),
+			}},
+			want: lattice.ErrInvalidOption,
+		},
+		{
+			name: "zero neighbor offset",
+			params: params{x: 4, y: 4, size: 8, opts: []lattice.Option{
+				lattice.WithNeighbors(lattice.Offset{X: 0, Y: 0}),
+			}},
+			want: lattice.ErrInvalidOption,
+		},
+		{
+			name: "all options",
+			params: params{x: 4, y: 4, size: 8, opts: []lattice.Option{
+				lattice.WithCapacity(16),
+				lattice.WithLocking(lattice.LockingExclusive),
+				lattice.WithNeighbors(lattice.Offset{X: 1, Y: 1}, lattice.Offset{X: -1, Y: -1}),
+				lattice.WithDeterministicOrder(),
+				lattice.WithPreciseQueries(),
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg, err := lattice.NewSpatialGridOpts[int](tt.params.x, tt.params.y, tt.params.size, tt.params.opts...)
+			if !errors.Is(err, tt.want) {
+				t.Error(fmt.Errorf("lattice.NewSpatialGridOpts() want error: %+v, got error: %+v\n", tt.want, err))
+			}
+			if err == nil && sg == nil {
+				t.Error("lattice.NewSpatialGridOpts() returned a nil grid without an error")
+			}
+		})
+	}
+}
+
+func Test_spatial_grid_WithDeterministicOrder(t *testing.T) {
+	sg, err := lattice.NewSpatialGridOpts[int](4, 4, 8, lattice.WithDeterministicOrder())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []int{}
+	for i := 0; i < 64; i++ {
+		x := float64(i%4)*8 + 4
+		y := float64((i/4)%4)*8 + 4
+		sg.Insert(lattice.Item[int]{i, mosaic.NewRectangle(mosaic.Vector{X: x, Y: y}, 1, 1), 1.0})
+	}
+	for x := 0; x < 4; x++ {
+		for y := 0; y < 4; y++ {
+			for i := 0; i < 64; i++ {
+				if i%4 == x && (i/4)%4 == y {
+					want = append(want, i)
+				}
+			}
+		}
+	}
+
+	query := mosaic.NewRectangle(mosaic.Vector{X: 16, Y: 16}, 32, 32)
+	for i := 0; i < 8; i++ {
+		got := sg.FindNear(query)
+		if !slices.Equal(want, got) {
+			t.Fatal(fmt.Errorf("spatialGrid.FindNear() want: %+v, got: %+v\n", want, got))
+		}
+	}
+}
+
+func Test_spatial_grid_WithNeighbors(t *testing.T) {
+	sg, err := lattice.NewSpatialGridOpts[int](4, 4, 8,
+		lattice.WithNeighbors(lattice.Offset{X: 1, Y: 1}, lattice.Offset{X: -1, Y: -1}),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := sg.WeightedSearch(mosaic.Vector{X: 4, Y: 4}, mosaic.Vector{X: 28, Y: 28}, 16)
+	if err != nil {
+		t.Fatal(fmt.Errorf("spatialGrid.WeightedSearch() error: %+v\n", err))
+	}
+
+	want := []mosaic.Vector{{X: 4, Y: 4}, {X: 12, Y: 12}, {X: 20, Y: 20}, {X: 28, Y: 28}}
+	if !slices.Equal(want, got) {
+		t.Error(fmt.Errorf("spatialGrid.WeightedSearch() want: %+v, got: %+v\n", want, got))
+	}
+
+	_, err = sg.WeightedSearch(mosaic.Vector{X: 4, Y: 4}, mosaic.Vector{X: 12, Y: 4}, 16)
+	if err != lattice.ErrPathNotFound {
+		t.Error(fmt.Errorf("spatialGrid.WeightedSearch() want error: %+v, got error: %+v\n", lattice.ErrPathNotFound, err))
+	}
+}
+
+func Test_spatial_grid_WithPreciseQueries(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []lattice.Option
+		want []int
+	}{
+		{
+			name: "cell level",
+			want: []int{1, 2, 3},
+		},
+		{
+			name: "precise",
+			opts: []lattice.Option{lattice.WithPreciseQueries()},
+			want: []int{2, 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg, err := lattice.NewSpatialGridOpts[int](4, 4, 8, tt.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sg.Insert(lattice.Item[int]{1, mosaic.NewRectangle(mosaic.Vector{X: 1, Y: 1}, 1, 1), 1.0})
+			sg.Insert(lattice.Item[int]{2, mosaic.NewRectangle(mosaic.Vector{X: 6, Y: 6}, 2, 2), 1.0})
+			sg.Insert(lattice.Item[int]{3, mosaic.NewRectangle(mosaic.Vector{X: 12, Y: 12}, 4, 4), 1.0})
+
+			query := mosaic.NewRectangle(mosaic.Vector{X: 8, Y: 8}, 6, 6)
+			got := sg.FindNear(query)
+			slices.Sort(got)
+			if !slices.Equal(tt.want, got) {
+				t.Error(fmt.Errorf("spatialGrid.FindNear() want: %+v, got: %+v\n", tt.want, got))
+			}
+
+			got = sg.FindNearSorted(query)
+			slices.Sort(got)
+			if !slices.Equal(tt.want, got) {
+				t.Error(fmt.Errorf("spatialGrid.FindNearSorted() want: %+v, got: %+v\n", tt.want, got))
+			}
+		})
+	}
+}
+
+func Test_spatial_grid_WithLockAssertions(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []lattice.Option
+		process func(sg *lattice.SpatialGrid[int]) func([]int) error
+		want    any
+	}{
+		{
+			name: "read while reading",
+			opts: []lattice.Option{lattice.WithLockAssertions()},
+			process: func(sg *lattice.SpatialGrid[int]) func([]int) error {
+				return func([]int) error {
+					sg.FindNear(mosaic.NewRectangle(mosaic.Vector{X: 4, Y: 4}, 2, 2))
+					return nil
+				}
+			},
+		},
+		{
+			name: "write while reading",
+			opts: []lattice.Option{lattice.WithLockAssertions()},
+			process: func(sg *lattice.SpatialGrid[int]) func([]int) error {
+				return func([]int) error {
+					sg.Insert(lattice.Item[int]{2, mosaic.NewRectangle(mosaic.Vector{X: 4, Y: 4}, 2, 2), 1.0})
+					return nil
+				}
+			},
+			want: lattice.ErrConcurrentAccess,
+		},
+		{
+			name: "no locking",
+			opts: []lattice.Option{lattice.WithNoLocking()},
+			process: func(sg *lattice.SpatialGrid[int]) func([]int) error {
+				return func([]int) error {
+					sg.Insert(lattice.Item[int]{2, mosaic.NewRectangle(mosaic.Vector{X: 4, Y: 4}, 2, 2), 1.0})
+					return nil
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg, err := lattice.NewSpatialGridOpts[int](4, 4, 8, tt.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sg.Insert(lattice.Item[int]{1, mosaic.NewRectangle(mosaic.Vector{X: 4, Y: 4}, 2, 2), 1.0})
+
+			got := func() (got any) {
+				defer func() { got = recover() }()
+				sg.Search(4, 4, 0, tt.process(sg))
+				return nil
+			}()
+			if got != tt.want {
+				t.Error(fmt.Errorf("spatialGrid.Search() want panic: %+v, got: %+v\n", tt.want, got))
+			}
+		})
+	}
+}
+
+func Test_spatial_grid_neighbor_presets(t *testing.T) {
+	type want struct {
+		steps  int
+		edges  int
+		search int
+	}
+	tests := []struct {
+		name      string
+		neighbors []lattice.Offset
+		end       mosaic.Vector
+		want      want
+	}{
+		{
+			name:      "four way",
+			neighbors: lattice.FourWay,
+			end:       mosaic.Vector{X: 28, Y: 28},
+			want:      want{steps: 6, edges: 4, search: 5},
+		},
+		{
+			name:      "eight way",
+			neighbors: lattice.EightWay,
+			end:       mosaic.Vector{X: 28, Y: 28},
+			want:      want{steps: 3, edges: 8, search: 9},
+		},
+		{
+			name:      "knight moves",
+			neighbors: lattice.KnightMoves,
+			end:       mosaic.Vector{X: 12, Y: 20},
+			want:      want{steps: 1, edges: 4, search: 5},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg, err := lattice.NewSpatialGridOpts[int](4, 4, 8, lattice.WithNeighbors(tt.neighbors...))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			path, err := sg.WeightedSearch(mosaic.Vector{X: 4, Y: 4}, tt.end, 64)
+			if err != nil {
+				t.Fatal(fmt.Errorf("spatialGrid.WeightedSearch() error: %+v\n", err))
+			}
+			edges := sg.Edges(sg.Node(1, 1))
+			search := 0
+			sg.Search(12, 12, 1, func([]int) error {
+				search++
+				return nil
+			})
+
+			got := want{steps: len(path) - 1, edges: len(edges), search: search}
+			if got != tt.want {
+				t.Error(fmt.Errorf("lattice.WithNeighbors() want: %+v, got: %+v\n", tt.want, got))
+			}
+		})
+	}
+}
+
+func Test_spatial_grid_WithStrictBounds(t *testing.T) {
+	inside := mosaic.NewRectangle(mosaic.Vector{X: 4, Y: 4}, 2, 2)
+	outside := mosaic.NewRectangle(mosaic.Vector{X: -4, Y: 4}, 2, 2)
+	tests := []struct {
+		name string
+		opts []lattice.Option
+		want error
+		near int
+		size int
+	}{
+		{
+			name: "clamped",
+			near: 2,
+			size: 2,
+		},
+		{
+			name: "strict",
+			opts: []lattice.Option{lattice.WithStrictBounds()},
+			want: lattice.ErrOutOfBounds,
+			near: 0,
+			size: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg, err := lattice.NewSpatialGridOpts[int](4, 4, 8, tt.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := sg.Insert(lattice.Item[int]{1, inside, 1.0}); err != nil {
+				t.Fatal(err)
+			}
+
+			errs := []error{
+				sg.Insert(lattice.Item[int]{2, outside, 1.0}),
+				sg.Update(lattice.Item[int]{1, outside, 1.0}, inside),
+			}
+			_, searchErr := sg.Search(outside.Position.X, outside.Position.Y, 16, func([]int) error { return nil })
+			_, pathErr := sg.FindPath(inside.Position, outside.Position, lattice.PathOptions{})
+			errs = append(errs, searchErr, pathErr)
+			for i, err := range errs {
+				if !errors.Is(err, tt.want) {
+					t.Error(fmt.Errorf("strict bounds call %+v want error: %+v, got error: %+v\n", i, tt.want, err))
+				}
+			}
+
+			got := sg.FindNear(outside)
+			if len(got) != tt.near {
+				t.Error(fmt.Errorf("spatialGrid.FindNear() want: %+v items, got: %+v\n", tt.near, got))
+			}
+			if sg.Size() != tt.size {
+				t.Error(fmt.Errorf("spatialGrid.Size() want: %+v, got: %+v\n", tt.size, sg.Size()))
+			}
+		})
+	}
+}
+
+func Test_spatial_grid_WithOrigin(t *testing.T) {
+	tests := []struct {
+		name     string
+		x, y     float64
+		position mosaic.Vector
+		want     lattice.Cell
+		err      error
+	}{
+		{
+			name:     "centered map",
+			x:        -16,
+			y:        -16,
+			position: mosaic.NewVector(-12, -4),
+			want:     lattice.Cell{0, 1},
+		},
+		{
+			name:     "shifted map",
+			x:        100,
+			y:        200,
+			position: mosaic.NewVector(125, 201),
+			want:     lattice.Cell{3, 0},
+		},
+		{
+			name: "infinite origin",
+			x:    math.Inf(-1),
+			err:  lattice.ErrInvalidOption,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg, err := lattice.NewSpatialGridOpts[int](4, 4, 8, lattice.WithOrigin(tt.x, tt.y))
+			if !errors.Is(err, tt.err) {
+				t.Fatal(fmt.Errorf("lattice.WithOrigin() want error: %+v, got error: %+v\n", tt.err, err))
+			}
+			if err != nil {
+				return
+			}
+
+			x, y := sg.Location(tt.position.X, tt.position.Y)
+			if (lattice.Cell{x, y}) != tt.want {
+				t.Error(fmt.Errorf("spatialGrid.Location() want: %+v, got: %+v\n", tt.want, lattice.Cell{x, y}))
+			}
+			if err := sg.Insert(lattice.Item[int]{1, mosaic.NewRectangle(tt.position, 2, 2), 1.0}); err != nil {
+				t.Fatal(err)
+			}
+			got := sg.GetItemsAtLocation(tt.want.X, tt.want.Y)
+			if len(got) != 1 {
+				t.Error(fmt.Errorf("spatialGrid.GetItemsAtLocation() want: %+v, got: %+v\n", []int{1}, got))
+			}
+			if sg.CellToWorld(0, 0) != mosaic.NewVector(tt.x, tt.y) {
+				t.Error(fmt.Errorf("spatialGrid.CellToWorld() want: %+v, got: %+v\n", mosaic.NewVector(tt.x, tt.y), sg.CellToWorld(0, 0)))
+			}
+		})
+	}
+}
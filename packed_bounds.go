@@ -0,0 +1,101 @@
+package lattice
+
+import (
+	"slices"
+
+	"github.com/maladroitthief/mosaic"
+)
+
+// packedBounds mirrors a node's item bounds as flat min/max columns so the
+// overlap test can run as a tight, branch-free loop.
+type packedBounds struct {
+	minX []float64
+	minY []float64
+	maxX []float64
+	maxY []float64
+}
+
+func (pb packedBounds) append(bounds mosaic.Rectangle) packedBounds {
+	minPoint, maxPoint := bounds.MinPoint(), bounds.MaxPoint()
+	pb.minX = append(pb.minX, minPoint.X)
+	pb.minY = append(pb.minY, minPoint.Y)
+	pb.maxX = append(pb.maxX, maxPoint.X)
+	pb.maxY = append(pb.maxY, maxPoint.Y)
+
+	return pb
+}
+
+func (pb packedBounds) clone() packedBounds {
+	return packedBounds{
+		minX: slices.Clone(pb.minX),
+		minY: slices.Clone(pb.minY),
+		maxX: slices.Clone(pb.maxX),
+		maxY: slices.Clone(pb.maxY),
+	}
+}
+
+func (pb packedBounds) set(i int, bounds mosaic.Rectangle) packedBounds {
+	minPoint, maxPoint := bounds.MinPoint(), bounds.MaxPoint()
+	pb.minX[i], pb.minY[i] = minPoint.X, minPoint.Y
+	pb.maxX[i], pb.maxY[i] = maxPoint.X, maxPoint.Y
+
+	return pb
+}
+
+func (pb packedBounds) swap(i, j int) packedBounds {
+	pb.minX[i], pb.minX[j] = pb.minX[j], pb.minX[i]
+	pb.minY[i], pb.minY[j] = pb.minY[j], pb.minY[i]
+	pb.maxX[i], pb.maxX[j] = pb.maxX[j], pb.maxX[i]
+	pb.maxY[i], pb.maxY[j] = pb.maxY[j], pb.maxY[i]
+
+	return pb
+}
+
+func (pb packedBounds) truncate(n int) packedBounds {
+	pb.minX, pb.minY = pb.minX[:n], pb.minY[:n]
+	pb.maxX, pb.maxY = pb.maxX[:n], pb.maxY[:n]
+
+	return pb
+}
+
+func (pb packedBounds) reset() packedBounds {
+	pb.minX, pb.minY = pb.minX[:0], pb.minY[:0]
+	pb.maxX, pb.maxY = pb.maxX[:0], pb.maxY[:0]
+
+	return pb
+}
+
+// intersect writes 1 into mask for every packed rectangle touching the query,
+// matching mosaic.Rectangle.Intersects semantics.
+func (pb packedBounds) intersect(query mosaic.Rectangle, mask []uint8) []uint8 {
+	n := len(pb.minX)
+	if cap(mask) < n {
+		mask = make([]uint8, n)
+	}
+	mask = mask[:n]
+
+	qMin, qMax := query.MinPoint(), query.MaxPoint()
+	minX, minY := pb.minX[:n], pb.minY[:n]
+	maxX, maxY := pb.maxX[:n], pb.maxY[:n]
+
+	i := 0
+	for ; i+4 <= n; i += 4 {
+		mask[i] = b2u(minX[i] <= qMax.X) & b2u(maxX[i] >= qMin.X) & b2u(minY[i] <= qMax.Y) & b2u(maxY[i] >= qMin.Y)
+		mask[i+1] = b2u(minX[i+1] <= qMax.X) & b2u(maxX[i+1] >= qMin.X) & b2u(minY[i+1] <= qMax.Y) & b2u(maxY[i+1] >= qMin.Y)
+		mask[i+2] = b2u(minX[i+2] <= qMax.X) & b2u(maxX[i+2] >= qMin.X) & b2u(minY[i+2] <= qMax.Y) & b2u(maxY[i+2] >= qMin.Y)
+		mask[i+3] = b2u(minX[i+3] <= qMax.X) & b2u(maxX[i+3] >= qMin.X) & b2u(minY[i+3] <= qMax.Y) & b2u(maxY[i+3] >= qMin.Y)
+	}
+	for ; i < n; i++ {
+		mask[i] = b2u(minX[i] <= qMax.X) & b2u(maxX[i] >= qMin.X) & b2u(minY[i] <= qMax.Y) & b2u(maxY[i] >= qMin.Y)
+	}
+
+	return mask
+}
+
+// b2u compiles down to a SETcc, keeping the overlap loop free of branches
+func b2u(b bool) uint8 {
+	if b {
+		return 1
+	}
+	return 0
+}
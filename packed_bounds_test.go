@@ -0,0 +1,74 @@
+package lattice
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/maladroitthief/mosaic"
+)
+
+func randomBounds(n int) []mosaic.Rectangle {
+	bounds := make([]mosaic.Rectangle, n)
+	for i := range bounds {
+		bounds[i] = mosaic.NewRectangle(
+			mosaic.NewVector(rand.Float64()*256, rand.Float64()*256),
+			rand.Float64()*32,
+			rand.Float64()*32,
+		)
+	}
+
+	return bounds
+}
+
+func Test_packed_bounds_intersect(t *testing.T) {
+	bounds := randomBounds(1031)
+	pb := packedBounds{}
+	for _, b := range bounds {
+		pb = pb.append(b)
+	}
+
+	for q := 0; q < 64; q++ {
+		query := randomBounds(1)[0]
+		mask := pb.intersect(query, nil)
+		for i, b := range bounds {
+			want := b.Intersects(query)
+			if want != (mask[i] == 1) {
+				t.Errorf("packedBounds.intersect() index %d want: %+v, got: %+v", i, want, mask[i])
+			}
+		}
+	}
+}
+
+func BenchmarkOverlapScalar(b *testing.B) {
+	bounds := randomBounds(512)
+	query := mosaic.NewRectangle(mosaic.NewVector(128, 128), 64, 64)
+	hits := 0
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		for i := range bounds {
+			if bounds[i].Intersects(query) {
+				hits++
+			}
+		}
+	}
+}
+
+func BenchmarkOverlapPacked(b *testing.B) {
+	bounds := randomBounds(512)
+	query := mosaic.NewRectangle(mosaic.NewVector(128, 128), 64, 64)
+	pb := packedBounds{}
+	for _, r := range bounds {
+		pb = pb.append(r)
+	}
+	mask := make([]uint8, len(bounds))
+	hits := 0
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		mask = pb.intersect(query, mask)
+		for _, hit := range mask {
+			hits += int(hit)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package lattice
+
+import (
+	"math"
+
+	"github.com/maladroitthief/mosaic"
+)
+
+// PaintWeights adds delta to the paint of every cell brush touches. Paint
+// is a weight layer for hand tuning searches, kept apart from items and
+// terrain so ErasePaint can take it back out. Negative paint lowers a cell's
+// weight but never below zero.
+func (sg *SpatialGrid[T]) PaintWeights(brush mosaic.Rectangle, delta float64) error {
+	if math.IsInf(delta, 0) || math.IsNaN(delta) {
+		return ErrInvalidOption
+	}
+
+	sg.lock()
+	defer sg.unlock()
+
+	xMin, yMin, xMax, yMax, ok := sg.cellRange(brush)
+	if !ok {
+		return nil
+	}
+	for x := xMin; x <= xMax; x++ {
+		for y := yMin; y <= yMax; y++ {
+			sg.setPaint(x, y, sg.Nodes[x][y].paint+delta)
+		}
+	}
+
+	return nil
+}
+
+// PaintCircle adds delta to the paint of every cell whose center lies
+// within radius of center
+func (sg *SpatialGrid[T]) PaintCircle(center mosaic.Vector, radius, delta float64) error {
+	if radius < 0 || math.IsInf(delta, 0) || math.IsNaN(delta) {
+		return ErrInvalidOption
+	}
+
+	sg.lock()
+	defer sg.unlock()
+
+	brush := mosaic.NewRectangle(center, 2*radius, 2*radius)
+	xMin, yMin, xMax, yMax, ok := sg.cellRange(brush)
+	if !ok {
+		return nil
+	}
+	for x := xMin; x <= xMax; x++ {
+		for y := yMin; y <= yMax; y++ {
+			c := sg.CellCenter(x, y)
+			if math.Hypot(c.X-center.X, c.Y-center.Y) > radius {
+				continue
+			}
+			sg.setPaint(x, y, sg.Nodes[x][y].paint+delta)
+		}
+	}
+
+	return nil
+}
+
+// ErasePaint clears the paint of every cell area touches
+func (sg *SpatialGrid[T]) ErasePaint(area mosaic.Rectangle) {
+	sg.lock()
+	defer sg.unlock()
+
+	xMin, yMin, xMax, yMax, ok := sg.cellRange(area)
+	if !ok {
+		return
+	}
+	for x := xMin; x <= xMax; x++ {
+		for y := yMin; y <= yMax; y++ {
+			if sg.Nodes[x][y].paint != 0 {
+				sg.setPaint(x, y, 0)
+			}
+		}
+	}
+}
+
+func (sg *SpatialGrid[T]) Paint(x, y int) float64 {
+	sg = sg.rlock()
+	defer sg.runlock()
+
+	return sg.Nodes[x][y].paint
+}
+
+func (sg *SpatialGrid[T]) setPaint(x, y int, paint float64) {
+	node := sg.Nodes[x][y]
+	node.paint = paint
+	node.weight = node.itemWeights()
+	sg.Nodes[x][y] = node
+	sg.updateBlocked(x, y)
+}
+
+// spreadPaint is spreadTerrain for paint
+func (sg *SpatialGrid[T]) spreadPaint(bounds mosaic.Rectangle, density float64) {
+	xMin, yMin, xMax, yMax, _ := sg.cellRange(bounds)
+	for x := xMin; x <= xMax; x++ {
+		for y := yMin; y <= yMax; y++ {
+			overlap := sg.Nodes[x][y].bounds.AreaOfOverlap(bounds)
+			if overlap <= 0 {
+				continue
+			}
+			sg.setPaint(x, y, sg.Nodes[x][y].paint+density*overlap)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package lattice_test
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/mosaic"
+)
+
+func Test_spatial_grid_PaintWeights(t *testing.T) {
+	type want struct {
+		// rows top to bottom
+		weights [][]float64
+		err     error
+	}
+	tests := []struct {
+		name  string
+		paint func(sg *lattice.SpatialGrid[int]) error
+		want  want
+	}{
+		{
+			name: "rectangle brush",
+			paint: func(sg *lattice.SpatialGrid[int]) error {
+				return sg.PaintWeights(mosaic.NewRectangle(mosaic.NewVector(16, 16), 16, 16), 2)
+			},
+			want: want{weights: [][]float64{
+				{2, 2, 0},
+				{2, 2, 0},
+				{0, 0, 0},
+			}},
+		},
+		{
+			name: "strokes add up",
+			paint: func(sg *lattice.SpatialGrid[int]) error {
+				sg.PaintWeights(mosaic.NewRectangle(mosaic.NewVector(8, 8), 4, 4), 2)
+				return sg.PaintWeights(mosaic.NewRectangle(mosaic.NewVector(8, 8), 4, 4), 3)
+			},
+			want: want{weights: [][]float64{
+				{5, 0, 0},
+				{0, 0, 0},
+				{0, 0, 0},
+			}},
+		},
+		{
+			name: "circle brush",
+			paint: func(sg *lattice.SpatialGrid[int]) error {
+				return sg.PaintCircle(mosaic.NewVector(24, 24), 16, 1)
+			},
+			want: want{weights: [][]float64{
+				{0, 1, 0},
+				{1, 1, 1},
+				{0, 1, 0},
+			}},
+		},
+		{
+			name: "negative paint stops at zero",
+			paint: func(sg *lattice.SpatialGrid[int]) error {
+				sg.SetTerrain(0, 0, 4)
+				return sg.PaintWeights(mosaic.NewRectangle(mosaic.NewVector(8, 8), 4, 4), -10)
+			},
+			want: want{weights: [][]float64{
+				{0, 0, 0},
+				{0, 0, 0},
+				{0, 0, 0},
+			}},
+		},
+		{
+			name: "erase",
+			paint: func(sg *lattice.SpatialGrid[int]) error {
+				sg.SetTerrain(0, 0, 4)
+				sg.PaintWeights(mosaic.NewRectangle(mosaic.NewVector(24, 24), 48, 48), 7)
+				sg.ErasePaint(mosaic.NewRectangle(mosaic.NewVector(8, 24), 4, 48))
+				return nil
+			},
+			want: want{weights: [][]float64{
+				{4, 7, 7},
+				{0, 7, 7},
+				{0, 7, 7},
+			}},
+		},
+		{
+			name: "invalid delta",
+			paint: func(sg *lattice.SpatialGrid[int]) error {
+				return sg.PaintWeights(mosaic.NewRectangle(mosaic.NewVector(8, 8), 4, 4), math.NaN())
+			},
+			want: want{
+				weights: [][]float64{{0, 0, 0}, {0, 0, 0}, {0, 0, 0}},
+				err:     lattice.ErrInvalidOption,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg := lattice.NewSpatialGrid[int](3, 3, 16)
+			err := tt.paint(sg)
+			if !errors.Is(err, tt.want.err) {
+				t.Fatal(fmt.Errorf("spatialGrid.PaintWeights() want: %+v, got: %+v\n", tt.want.err, err))
+			}
+
+			for y, row := range tt.want.weights {
+				for x, want := range row {
+					got := sg.GetLocationWeight(x, y)
+					if got != want {
+						t.Error(fmt.Errorf("spatialGrid.GetLocationWeight(%d, %d) want: %+v, got: %+v\n", x, y, want, got))
+					}
+				}
+			}
+
+			// paint outlives the items
+			sg.Drop()
+			for y, row := range tt.want.weights {
+				for x, want := range row {
+					if got := sg.GetLocationWeight(x, y); got != want {
+						t.Error(fmt.Errorf("spatialGrid.Drop() weight at %d, %d want: %+v, got: %+v\n", x, y, want, got))
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package lattice
+
+import (
+	"math"
+
+	"github.com/maladroitthief/mosaic"
+)
+
+// Partition assigns every reachable open cell, indexed like Nodes, the index
+// of the seed with the cheapest route to it, where every step costs 1 plus
+// the weight of the cell entered. Ties go to the earlier seed, and blocked or
+// unreachable cells get -1.
+func (sg *SpatialGrid[T]) Partition(seeds []mosaic.Vector) ([][]int, error) {
+	sg = sg.rlock()
+	defer sg.runlock()
+
+	cells := sg.SizeX * sg.SizeY
+	owners := make([]int, cells)
+	costs := make([]float64, cells)
+	for i := range owners {
+		owners[i] = -1
+		costs[i] = math.Inf(1)
+	}
+
+	heap := minHeap{}
+	for i, seed := range seeds {
+		x, y, err := sg.cell(seed.X, seed.Y)
+		if err != nil {
+			return [][]int{}, err
+		}
+		index := sg.index(x, y)
+		if sg.blocked.get(index) || owners[index] >= 0 {
+			continue
+		}
+		owners[index], costs[index] = i, 0
+		heap = heap.Push(int32(index), 0)
+	}
+
+	for heap.Len() > 0 {
+		priority := heap[0].priority
+		var current int32
+		current, heap = heap.Pop()
+		// stale entry left behind by a cheaper route
+		if priority > costs[current] {
+			continue
+		}
+
+		sg.goalEdges(current, TraversalProfile{}, func(next int32, _ int, cost float64) {
+			newCost := costs[current] + cost + 1
+			if newCost > costs[next] || (newCost == costs[next] && owners[current] >= owners[next]) {
+				return
+			}
+			costs[next], owners[next] = newCost, owners[current]
+			heap = heap.Push(next, newCost)
+		})
+	}
+
+	partition := make([][]int, sg.SizeX)
+	for x := range partition {
+		partition[x] = make([]int, sg.SizeY)
+		for y := range partition[x] {
+			partition[x][y] = owners[sg.index(x, y)]
+		}
+	}
+
+	return partition, nil
+}
@@ -0,0 +1,104 @@
+package lattice_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/mosaic"
+)
+
+func Test_spatial_grid_Partition(t *testing.T) {
+	type want struct {
+		// rows top to bottom, transposed into Nodes order before comparing
+		rows [][]int
+		err  error
+	}
+	tests := []struct {
+		name    string
+		builder Builder
+		seeds   []mosaic.Vector
+		opts    []lattice.Option
+		want    want
+	}{
+		{
+			name: "split down the middle",
+			builder: Builder{x: 4, y: 4, size: 16, layout: "" +
+				"0000" +
+				"0000" +
+				"0000" +
+				"0000"},
+			seeds: []mosaic.Vector{mosaic.NewVector(8, 8), mosaic.NewVector(56, 8)},
+			want: want{rows: [][]int{
+				{0, 0, 1, 1},
+				{0, 0, 1, 1},
+				{0, 0, 1, 1},
+				{0, 0, 1, 1},
+			}},
+		},
+		{
+			name: "ties go to the earlier seed",
+			builder: Builder{x: 3, y: 3, size: 16, layout: "" +
+				"000" +
+				"000" +
+				"000"},
+			seeds: []mosaic.Vector{mosaic.NewVector(40, 8), mosaic.NewVector(8, 8)},
+			want: want{rows: [][]int{
+				{1, 0, 0},
+				{1, 0, 0},
+				{1, 0, 0},
+			}},
+		},
+		{
+			name: "walls and weights",
+			builder: Builder{x: 4, y: 4, size: 16, layout: "" +
+				"0x00" +
+				"0x11" +
+				"0x00" +
+				"0000"},
+			seeds: []mosaic.Vector{mosaic.NewVector(8, 8), mosaic.NewVector(56, 8)},
+			want: want{rows: [][]int{
+				{0, -1, 1, 1},
+				{0, -1, 1, 1},
+				{0, -1, 0, 0},
+				{0, 0, 0, 0},
+			}},
+		},
+		{
+			name:    "seed out of bounds",
+			builder: Builder{x: 2, y: 2, size: 16, layout: "0000"},
+			seeds:   []mosaic.Vector{mosaic.NewVector(-8, 8)},
+			opts:    []lattice.Option{lattice.WithStrictBounds()},
+			want:    want{err: lattice.ErrOutOfBounds},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg, err := lattice.NewSpatialGridOpts[int](tt.builder.x, tt.builder.y, float64(tt.builder.size), tt.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			setup_grid(sg, tt.builder)
+
+			got, err := sg.Partition(tt.seeds)
+			if !errors.Is(err, tt.want.err) {
+				t.Fatal(fmt.Errorf("spatialGrid.Partition() want: %+v, got: %+v\n", tt.want.err, err))
+			}
+			if err != nil {
+				return
+			}
+			want := make([][]int, tt.builder.x)
+			for x := range want {
+				want[x] = make([]int, tt.builder.y)
+				for y := range want[x] {
+					want[x][y] = tt.want.rows[y][x]
+				}
+			}
+			if !reflect.DeepEqual(want, got) {
+				t.Error(fmt.Errorf("spatialGrid.Partition() want: %+v, got: %+v\n", want, got))
+			}
+		})
+	}
+}
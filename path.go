@@ -0,0 +1,113 @@
+package lattice
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/maladroitthief/mosaic"
+)
+
+type (
+	// PathOptions limits are disabled when zero. MaxExpansions caps how many
+	// cells the search may dequeue, MaxPathLength caps the number of steps in
+	// any route the search will consider; while it binds the search keeps a
+	// state per step count, so memory grows with it. Profile is handed to
+	// edge rules and TurnPenalty is added every time the route changes
+	// direction. TieBreak picks among equally cheap routes, Seed feeds
+	// TieBreakJitter.
+	// AllowPartial turns an unreachable goal into a route to the reachable
+	// cell closest to it, flagged by Path.Partial. Landmarks tightens the
+	// heuristic and Clearance adds its wall penalty, both only while they
+	// still match the grid. Layers add scaled costs such as danger on top of
+	// the grid's weights. AllowedLabels, when set, confines the route to cells
+	// carrying one of them; list "" to allow unlabeled cells. Reservations
+	// makes the search time aware: every step or wait takes one tick from
+	// Tick, cells other agents hold at that tick are avoided for Agent and
+	// the route has one waypoint per tick. TurnPenalty, TieBreak,
+	// AllowPartial and Landmarks do not apply to such searches.
+	PathOptions struct {
+		MaxExpansions int
+		MaxPathLength int
+		Profile       TraversalProfile
+		TurnPenalty   float64
+		TieBreak      TieBreak
+		Seed          uint64
+		AllowPartial  bool
+		Landmarks     *Landmarks
+		Clearance     *Clearance
+		Layers        []WeightLayer
+		AllowedLabels []string
+		Reservations  *ReservationTable
+		Agent         int
+		Tick          int
+	}
+
+	// Path.Costs holds the cumulative cost on arriving at each waypoint,
+	// starting at 0, so movement can be timed by terrain.
+	Path struct {
+		Waypoints []mosaic.Vector
+		Costs     []float64
+		Cost      float64
+		Partial   bool
+	}
+)
+
+var (
+	ErrMaxExpansionsReached  = errors.New("search reached its maximum expansions")
+	ErrMaxPathLengthExceeded = errors.New("no path within the maximum path length")
+	ErrOutOfBounds           = errors.New("position is outside of the grid")
+)
+
+func (sg *SpatialGrid[T]) FindPath(start, end mosaic.Vector, opts PathOptions) (Path, error) {
+	sg = sg.rlock()
+	defer sg.runlock()
+
+	s := sg.searcher()
+	defer sg.searchers.Put(s)
+
+	path, err := s.findPath(start, end, opts)
+	if err != nil {
+		return Path{Waypoints: []mosaic.Vector{}}, err
+	}
+	path.Waypoints = slices.Clone(path.Waypoints)
+	path.Costs = slices.Clone(path.Costs)
+
+	return path, nil
+}
+
+func (sg *SpatialGrid[T]) WeightedSearchCellsIdx(startX, startY, endX, endY int, maxDepth int) ([]Cell, error) {
+	sg = sg.rlock()
+	defer sg.runlock()
+
+	if !sg.inBounds(startX, startY) || !sg.inBounds(endX, endY) {
+		return []Cell{}, ErrOutOfBounds
+	}
+	if maxDepth < 0 {
+		return []Cell{}, ErrMaxDepthReached
+	}
+
+	s := sg.searcher()
+	defer sg.searchers.Put(s)
+
+	_, err := s.findCells(Cell{startX, startY}, Cell{endX, endY}, PathOptions{MaxExpansions: maxDepth + 1})
+	if err != nil {
+		return []Cell{}, depthError(err)
+	}
+
+	path := make([]Cell, len(s.cells))
+	for i := range s.cells {
+		index := int(s.cells[len(s.cells)-1-i])
+		path[i] = Cell{X: index % sg.SizeX, Y: index / sg.SizeX}
+	}
+
+	return path, nil
+}
+
+// depthError keeps the legacy maxDepth searches, which are MaxExpansions
+// limits under the hood, reporting ErrMaxDepthReached
+func depthError(err error) error {
+	if errors.Is(err, ErrMaxExpansionsReached) {
+		return ErrMaxDepthReached
+	}
+	return err
+}
@@ -0,0 +1,418 @@
+package lattice_test
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/lattice/latticegen"
+	"github.com/maladroitthief/mosaic"
+)
+
+func Test_spatial_grid_FindPath(t *testing.T) {
+	maze := Builder{
+		x:    9,
+		y:    9,
+		size: 32,
+		layout: "" +
+			"000000000" +
+			"0xxxxxxx0" +
+			"0x00000x0" +
+			"0x0x0x0x0" +
+			"0x0x0x0x0" +
+			"0x0xxx0x0" +
+			"0x00000x0" +
+			"0x0xxx0x0" +
+			"000000000",
+	}
+	type params struct {
+		start mosaic.Vector
+		end   mosaic.Vector
+		opts  lattice.PathOptions
+	}
+	type want struct {
+		steps int
+		err   error
+	}
+	tests := []struct {
+		name   string
+		params params
+		want   want
+	}{
+		{
+			name: "unlimited",
+			params: params{
+				start: mosaic.NewVector(0, 0),
+				end:   mosaic.NewVector(4, 4),
+			},
+			want: want{steps: 20},
+		},
+		{
+			name: "path length limit",
+			params: params{
+				start: mosaic.NewVector(0, 0),
+				end:   mosaic.NewVector(4, 4),
+				opts:  lattice.PathOptions{MaxPathLength: 12},
+			},
+			want: want{err: lattice.ErrMaxPathLengthExceeded},
+		},
+		{
+			name: "path length fits",
+			params: params{
+				start: mosaic.NewVector(0, 0),
+				end:   mosaic.NewVector(4, 4),
+				opts:  lattice.PathOptions{MaxPathLength: 20},
+			},
+			want: want{steps: 20},
+		},
+		{
+			name: "expansion limit",
+			params: params{
+				start: mosaic.NewVector(0, 0),
+				end:   mosaic.NewVector(4, 4),
+				opts:  lattice.PathOptions{MaxExpansions: 8},
+			},
+			want: want{err: lattice.ErrMaxExpansionsReached},
+		},
+	}
+	if errors.Is(lattice.ErrMaxExpansionsReached, lattice.ErrMaxDepthReached) {
+		t.Error(fmt.Errorf("lattice.ErrMaxExpansionsReached want: distinct from %+v\n", lattice.ErrMaxDepthReached))
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scale := func(b Builder, v float64) float64 {
+				return v*float64(b.size) + float64(b.size)/2
+			}
+			sg := lattice.NewSpatialGrid[int](maze.x, maze.y, float64(maze.size))
+			setup_grid(sg, maze)
+			start := mosaic.NewVector(scale(maze, tt.params.start.X), scale(maze, tt.params.start.Y))
+			end := mosaic.NewVector(scale(maze, tt.params.end.X), scale(maze, tt.params.end.Y))
+
+			got, err := sg.FindPath(start, end, tt.params.opts)
+			if !errors.Is(err, tt.want.err) {
+				t.Error(fmt.Errorf("spatialGrid.FindPath() want error: %+v, got error: %+v\n", tt.want.err, err))
+			}
+			if err != nil {
+				return
+			}
+			if len(got.Waypoints)-1 != tt.want.steps {
+				t.Error(fmt.Errorf("spatialGrid.FindPath() want steps: %+v, got: %+v\n", tt.want.steps, len(got.Waypoints)-1))
+			}
+		})
+	}
+}
+
+func Test_spatial_grid_WeightedSearchCellsIdx(t *testing.T) {
+	maze := Builder{
+		x:    5,
+		y:    5,
+		size: 32,
+		layout: "" +
+			"00000" +
+			"0xxx0" +
+			"0x0x0" +
+			"0x0x0" +
+			"00000",
+	}
+	type params struct {
+		start lattice.Cell
+		end   lattice.Cell
+	}
+	type want struct {
+		path []lattice.Cell
+		err  error
+	}
+	tests := []struct {
+		name   string
+		params params
+		want   want
+	}{
+		{
+			name:   "around the wall",
+			params: params{start: lattice.Cell{X: 2, Y: 0}, end: lattice.Cell{X: 2, Y: 4}},
+			want: want{
+				path: []lattice.Cell{
+					{X: 2, Y: 0}, {X: 3, Y: 0}, {X: 4, Y: 0}, {X: 4, Y: 1}, {X: 4, Y: 2},
+					{X: 4, Y: 3}, {X: 4, Y: 4}, {X: 3, Y: 4}, {X: 2, Y: 4},
+				},
+			},
+		},
+		{
+			name:   "out of bounds",
+			params: params{start: lattice.Cell{X: 0, Y: 0}, end: lattice.Cell{X: 5, Y: 0}},
+			want:   want{path: []lattice.Cell{}, err: lattice.ErrOutOfBounds},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg := lattice.NewSpatialGrid[int](maze.x, maze.y, float64(maze.size))
+			setup_grid(sg, maze)
+
+			got, err := sg.WeightedSearchCellsIdx(tt.params.start.X, tt.params.start.Y, tt.params.end.X, tt.params.end.Y, 64)
+			if err != tt.want.err {
+				t.Error(fmt.Errorf("spatialGrid.WeightedSearchCellsIdx() want error: %+v, got error: %+v\n", tt.want.err, err))
+			}
+			if !slices.Equal(tt.want.path, got) {
+				t.Error(fmt.Errorf("spatialGrid.WeightedSearchCellsIdx() want: %+v, got: %+v\n", tt.want.path, got))
+			}
+		})
+	}
+}
+
+func Test_spatial_grid_FindPath_TurnPenalty(t *testing.T) {
+	stairs := Builder{
+		x:    5,
+		y:    5,
+		size: 8,
+		layout: "" +
+			"00111" +
+			"10011" +
+			"11001" +
+			"11100" +
+			"11110",
+	}
+	turns := func(path []mosaic.Vector) int {
+		count := 0
+		for i := 2; i < len(path); i++ {
+			a := path[i-1].Subtract(path[i-2])
+			b := path[i].Subtract(path[i-1])
+			if a != b {
+				count++
+			}
+		}
+		return count
+	}
+	type want struct {
+		steps int
+		turns int
+		cost  float64
+	}
+	tests := []struct {
+		name    string
+		penalty float64
+		want    want
+	}{
+		{
+			name:    "stairs",
+			penalty: 0,
+			want:    want{steps: 8, turns: 7, cost: 0},
+		},
+		{
+			name:    "straighter",
+			penalty: 1000,
+			want:    want{steps: 8, turns: 1, cost: 5*64 + 1000},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg := lattice.NewSpatialGrid[int](stairs.x, stairs.y, float64(stairs.size))
+			setup_grid(sg, stairs)
+
+			got, err := sg.FindPath(mosaic.Vector{X: 4, Y: 4}, mosaic.Vector{X: 36, Y: 36}, lattice.PathOptions{TurnPenalty: tt.penalty})
+			if err != nil {
+				t.Fatal(fmt.Errorf("spatialGrid.FindPath() error: %+v\n", err))
+			}
+
+			result := want{steps: len(got.Waypoints) - 1, turns: turns(got.Waypoints), cost: got.Cost}
+			if result != tt.want {
+				t.Error(fmt.Errorf("spatialGrid.FindPath() want: %+v, got: %+v\n", tt.want, result))
+			}
+		})
+	}
+}
+
+func Test_spatial_grid_FindPath_AllowPartial(t *testing.T) {
+	walled := Builder{
+		x:    5,
+		y:    5,
+		size: 8,
+		layout: "" +
+			"00000" +
+			"00000" +
+			"00000" +
+			"000xx" +
+			"000x0",
+	}
+	type want struct {
+		partial bool
+		last    []mosaic.Vector
+		err     error
+	}
+	tests := []struct {
+		name string
+		end  mosaic.Vector
+		opts lattice.PathOptions
+		want want
+	}{
+		{
+			name: "unreachable",
+			end:  mosaic.Vector{X: 36, Y: 36},
+			want: want{err: lattice.ErrPathNotFound},
+		},
+		{
+			name: "closest reachable cell",
+			end:  mosaic.Vector{X: 36, Y: 36},
+			opts: lattice.PathOptions{AllowPartial: true},
+			want: want{partial: true, last: []mosaic.Vector{{X: 36, Y: 20}, {X: 20, Y: 36}}},
+		},
+		{
+			name: "reachable goal is not partial",
+			end:  mosaic.Vector{X: 20, Y: 36},
+			opts: lattice.PathOptions{AllowPartial: true},
+			want: want{last: []mosaic.Vector{{X: 20, Y: 36}}},
+		},
+		{
+			name: "expansion limit",
+			end:  mosaic.Vector{X: 20, Y: 36},
+			opts: lattice.PathOptions{AllowPartial: true, MaxExpansions: 1},
+			want: want{partial: true, last: []mosaic.Vector{{X: 4, Y: 12}, {X: 12, Y: 4}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg := lattice.NewSpatialGrid[int](walled.x, walled.y, float64(walled.size))
+			setup_grid(sg, walled)
+
+			got, err := sg.FindPath(mosaic.Vector{X: 4, Y: 4}, tt.end, tt.opts)
+			if !errors.Is(err, tt.want.err) {
+				t.Error(fmt.Errorf("spatialGrid.FindPath() want error: %+v, got error: %+v\n", tt.want.err, err))
+			}
+			if err != nil {
+				return
+			}
+			if got.Partial != tt.want.partial {
+				t.Error(fmt.Errorf("spatialGrid.FindPath() want partial: %+v, got: %+v\n", tt.want.partial, got.Partial))
+			}
+			last := got.Waypoints[len(got.Waypoints)-1]
+			if !slices.Contains(tt.want.last, last) {
+				t.Error(fmt.Errorf("spatialGrid.FindPath() want last waypoint in: %+v, got: %+v\n", tt.want.last, last))
+			}
+		})
+	}
+}
+
+func BenchmarkSpatialGridFindPathMaze(b *testing.B) {
+	layout := latticegen.Maze(129, 129, 1)
+	sg := lattice.NewSpatialGrid[int](layout.Width, layout.Height, GridSize)
+	sg.Reset(latticegen.Items(layout, sg, func(x, y int) int { return y*layout.Width + x }))
+
+	open := layout.OpenCells()
+	start := sg.CellCenter(open[0].X, open[0].Y)
+	end := sg.CellCenter(open[len(open)-1].X, open[len(open)-1].Y)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		sg.FindPath(start, end, lattice.PathOptions{})
+	}
+}
+
+func Test_spatial_grid_FindPath_Costs(t *testing.T) {
+	tests := []struct {
+		name    string
+		x       int
+		y       int
+		terrain []float64
+		end     mosaic.Vector
+		opts    lattice.PathOptions
+		want    []float64
+	}{
+		{
+			name:    "terrain",
+			x:       5,
+			y:       1,
+			terrain: []float64{1, 2, 3, 4, 5},
+			end:     mosaic.Vector{X: 36, Y: 4},
+			want:    []float64{0, 2, 5, 9, 14},
+		},
+		{
+			name:    "turn penalty",
+			x:       2,
+			y:       2,
+			terrain: []float64{1, math.Inf(1), 1, 1},
+			end:     mosaic.Vector{X: 12, Y: 12},
+			opts:    lattice.PathOptions{TurnPenalty: 10},
+			want:    []float64{0, 1, 12},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg := lattice.NewSpatialGrid[int](tt.x, tt.y, 8)
+			for i, weight := range tt.terrain {
+				sg.SetTerrain(i%tt.x, i/tt.x, weight)
+			}
+
+			got, err := sg.FindPath(mosaic.Vector{X: 4, Y: 4}, tt.end, tt.opts)
+			if err != nil {
+				t.Fatal(fmt.Errorf("spatialGrid.FindPath() error: %+v\n", err))
+			}
+			if !slices.Equal(tt.want, got.Costs) {
+				t.Error(fmt.Errorf("spatialGrid.FindPath() want: %+v, got: %+v\n", tt.want, got.Costs))
+			}
+			if len(got.Costs) != len(got.Waypoints) || got.Costs[len(got.Costs)-1] != got.Cost {
+				t.Error(fmt.Errorf("spatialGrid.FindPath() want: %+v, got: %+v\n", got.Cost, got.Costs))
+			}
+		})
+	}
+}
+
+// a cheap detour that reaches a cell first must not hide the costlier
+// straight route that still fits the length limit
+func Test_spatial_grid_FindPath_MaxPathLength_detour(t *testing.T) {
+	sg := lattice.NewSpatialGrid[int](6, 2, 10)
+	for x := 0; x < 6; x++ {
+		for y := 0; y < 2; y++ {
+			sg.SetTerrain(x, y, 1)
+		}
+	}
+	sg.SetTerrain(1, 0, 100)
+
+	start, end := mosaic.NewVector(5, 5), mosaic.NewVector(55, 5)
+	unlimited, err := sg.FindPath(start, end, lattice.PathOptions{})
+	if err != nil || len(unlimited.Waypoints)-1 != 7 {
+		t.Error(fmt.Errorf("spatialGrid.FindPath() want steps: %+v, got: %+v %+v\n", 7, unlimited, err))
+	}
+
+	got, err := sg.FindPath(start, end, lattice.PathOptions{MaxPathLength: 5})
+	if err != nil {
+		t.Fatal(fmt.Errorf("spatialGrid.FindPath() want error: %+v, got error: %+v\n", nil, err))
+	}
+	if len(got.Waypoints)-1 != 5 || got.Cost != 104 {
+		t.Error(fmt.Errorf("spatialGrid.FindPath() want: %+v steps costing %+v, got: %+v\n", 5, 104, got))
+	}
+}
+
+func Test_spatial_grid_FindPath_MaxPathLength_optimal(t *testing.T) {
+	sg := lattice.NewSpatialGrid[int](5, 3, 1)
+	for x := 0; x < 5; x++ {
+		for y := 0; y < 3; y++ {
+			sg.SetTerrain(x, y, 1)
+		}
+	}
+	for y := 0; y < 3; y++ {
+		sg.SetTerrain(3, y, 500)
+	}
+	sg.SetTerrain(1, 1, 100)
+	sg.SetTerrain(1, 2, 500)
+
+	tests := []struct {
+		name  string
+		limit int
+		want  float64
+	}{
+		{name: "no limit", limit: 0, want: 505},
+		{name: "limit never binds", limit: 100, want: 505},
+		{name: "limit fits the cheapest route", limit: 6, want: 505},
+		{name: "limit binds", limit: 5, want: 602},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := sg.FindPath(mosaic.NewVector(0.5, 1.5), mosaic.NewVector(4.5, 1.5), lattice.PathOptions{MaxPathLength: tt.limit})
+			if err != nil || got.Cost != tt.want {
+				t.Error(fmt.Errorf("spatialGrid.FindPath() want: %+v, got: %+v, %+v\n", tt.want, got.Cost, err))
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package lattice
+
+import (
+	"math"
+
+	"github.com/maladroitthief/mosaic"
+)
+
+// PathsFrom runs a single Dijkstra expansion from start and returns one path
+// per goal, in the order given. Unreachable goals get an empty path with an
+// infinite cost and the call returns ErrPathNotFound alongside the others.
+func (sg *SpatialGrid[T]) PathsFrom(start mosaic.Vector, goals []mosaic.Vector) ([]Path, error) {
+	sg = sg.rlock()
+	defer sg.runlock()
+
+	s := sg.searcher()
+	defer sg.searchers.Put(s)
+
+	startX, startY, err := sg.cell(start.X, start.Y)
+	if err != nil {
+		return []Path{}, err
+	}
+	startIndex := int32(sg.index(startX, startY))
+
+	pending := map[int32]bool{}
+	for _, goal := range goals {
+		x, y, err := sg.cell(goal.X, goal.Y)
+		if err != nil {
+			return []Path{}, err
+		}
+		pending[int32(sg.index(x, y))] = true
+	}
+	s.expand(startIndex, pending)
+
+	paths := make([]Path, len(goals))
+	for i, goal := range goals {
+		x, y := sg.Location(goal.X, goal.Y)
+		goalIndex := int32(sg.index(x, y))
+		if !s.seen(goalIndex) {
+			paths[i] = Path{Waypoints: []mosaic.Vector{}, Costs: []float64{}, Cost: math.Inf(1)}
+			err = ErrPathNotFound
+			continue
+		}
+
+		cells := []int32{}
+		for current := goalIndex; current != startIndex; current = s.cameFrom[current] {
+			cells = append(cells, current)
+		}
+		cells = append(cells, startIndex)
+
+		waypoints := make([]mosaic.Vector, len(cells))
+		costs := make([]float64, len(cells))
+		for j := range cells {
+			index := cells[len(cells)-1-j]
+			waypoints[j] = sg.CellCenter(int(index)%sg.SizeX, int(index)/sg.SizeX)
+			costs[j] = s.costs[index]
+		}
+		paths[i] = Path{Waypoints: waypoints, Costs: costs, Cost: s.costs[goalIndex]}
+	}
+
+	return paths, err
+}
+
+// expand settles cells in cost order until every pending cell is settled or
+// the frontier runs out
+func (s *Searcher[T]) expand(start int32, pending map[int32]bool) {
+	sg := s.grid
+	s.reset(sg.SizeX * sg.SizeY)
+
+	s.visit(start, 0, start, 0)
+	s.open.Push(start, 0)
+
+	remaining := len(pending)
+	for s.open.Len() > 0 && remaining > 0 {
+		current := s.open.Pop()
+		if pending[current] {
+			pending[current] = false
+			remaining--
+		}
+
+		currentX, currentY := int(current)%sg.SizeX, int(current)/sg.SizeX
+		for _, direction := range sg.config.neighbors {
+			nextX := currentX + direction[0]
+			nextY := currentY + direction[1]
+			if nextX < 0 || nextX >= sg.SizeX || nextY < 0 || nextY >= sg.SizeY {
+				continue
+			}
+
+			next := int32(sg.index(nextX, nextY))
+			if sg.blocked.get(int(next)) {
+				continue
+			}
+			if !sg.edgeAllowed(int(current), direction[0], direction[1], TraversalProfile{}) {
+				continue
+			}
+
+			newCost := s.costs[current] + sg.Nodes[nextX][nextY].weight
+			if s.seen(next) && newCost >= s.costs[next] {
+				continue
+			}
+
+			s.visit(next, newCost, current, s.steps[current]+1)
+			s.open.Push(next, newCost)
+		}
+
+		for _, p := range sg.portals[int(current)] {
+			next := int32(p.to)
+			if sg.blocked.get(p.to) {
+				continue
+			}
+
+			newCost := s.costs[current] + p.cost + sg.Nodes[p.to%sg.SizeX][p.to/sg.SizeX].weight
+			if s.seen(next) && newCost >= s.costs[next] {
+				continue
+			}
+
+			s.visit(next, newCost, current, s.steps[current]+1)
+			s.open.Push(next, newCost)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package lattice_test
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/mosaic"
+)
+
+func Test_spatial_grid_PathsFrom(t *testing.T) {
+	grid := Builder{
+		x:    5,
+		y:    5,
+		size: 32,
+		layout: "" +
+			"00100" +
+			"01100" +
+			"000xx" +
+			"0x0x0" +
+			"000x0",
+	}
+	scale := func(b Builder, v float64) float64 {
+		return v*float64(b.size) + float64(b.size)/2
+	}
+	type want struct {
+		cost  float64
+		steps int
+	}
+	tests := []struct {
+		name  string
+		goals []mosaic.Vector
+		want  []want
+		err   error
+	}{
+		{
+			name:  "matches single searches",
+			goals: []mosaic.Vector{{X: 4, Y: 0}, {X: 2, Y: 4}, {X: 0, Y: 0}},
+			want:  []want{{cost: 1024, steps: 4}, {cost: 0, steps: 6}, {cost: 0, steps: 0}},
+		},
+		{
+			name:  "unreachable goal",
+			goals: []mosaic.Vector{{X: 4, Y: 4}, {X: 0, Y: 4}},
+			want:  []want{{cost: math.Inf(1), steps: -1}, {cost: 0, steps: 4}},
+			err:   lattice.ErrPathNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg := lattice.NewSpatialGrid[int](grid.x, grid.y, float64(grid.size))
+			setup_grid(sg, grid)
+
+			start := mosaic.NewVector(scale(grid, 0), scale(grid, 0))
+			goals := make([]mosaic.Vector, len(tt.goals))
+			for i, goal := range tt.goals {
+				goals[i] = mosaic.NewVector(scale(grid, goal.X), scale(grid, goal.Y))
+			}
+
+			got, err := sg.PathsFrom(start, goals)
+			if !errors.Is(err, tt.err) {
+				t.Error(fmt.Errorf("spatialGrid.PathsFrom() want error: %+v, got error: %+v\n", tt.err, err))
+			}
+			if len(got) != len(tt.want) {
+				t.Fatal(fmt.Errorf("spatialGrid.PathsFrom() want: %+v paths, got: %+v\n", len(tt.want), len(got)))
+			}
+			for i, w := range tt.want {
+				if got[i].Cost != w.cost || len(got[i].Waypoints)-1 != w.steps {
+					t.Error(fmt.Errorf("spatialGrid.PathsFrom() want: %+v, got: %+v\n", w, got[i]))
+				}
+				if w.steps < 0 {
+					continue
+				}
+				if costs := got[i].Costs; len(costs) != len(got[i].Waypoints) || costs[len(costs)-1] != got[i].Cost {
+					t.Error(fmt.Errorf("spatialGrid.PathsFrom() want cost: %+v, got: %+v\n", got[i].Cost, costs))
+				}
+
+				single, err := sg.FindPath(start, goals[i], lattice.PathOptions{})
+				if err != nil || single.Cost != got[i].Cost {
+					t.Error(fmt.Errorf("spatialGrid.PathsFrom() want cost: %+v, got: %+v\n", single.Cost, got[i].Cost))
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package lattice
+
+import (
+	"math"
+	"slices"
+
+	"github.com/maladroitthief/mosaic"
+)
+
+// GeneratePatrol picks up to count passable cells inside area, spread as far
+// apart as possible among those reachable from the cell nearest its center,
+// and orders them into a cheap loop. The returned path visits them in that
+// order and ends back at the first one, with opts applied to every leg.
+func (sg *SpatialGrid[T]) GeneratePatrol(area mosaic.Rectangle, count int, opts PathOptions) (Path, error) {
+	sg = sg.rlock()
+	defer sg.runlock()
+
+	if count < 2 {
+		return Path{Waypoints: []mosaic.Vector{}}, ErrInvalidOption
+	}
+	xMin, yMin, xMax, yMax, ok := sg.cellRange(area)
+	if !ok {
+		return Path{Waypoints: []mosaic.Vector{}}, ErrPathNotFound
+	}
+
+	// the open cell nearest the center anchors the reachable set
+	centerX, centerY := float64(xMin+xMax)/2, float64(yMin+yMax)/2
+	seed, seedDistance := int32(-1), math.Inf(1)
+	for x := xMin; x <= xMax; x++ {
+		for y := yMin; y <= yMax; y++ {
+			d := math.Hypot(float64(x)-centerX, float64(y)-centerY)
+			if !sg.blocked.get(sg.index(x, y)) && d < seedDistance {
+				seed, seedDistance = int32(sg.index(x, y)), d
+			}
+		}
+	}
+	if seed < 0 {
+		return Path{Waypoints: []mosaic.Vector{}}, ErrPathNotFound
+	}
+
+	w := sg.newGoalWalker(sg.SizeX * sg.SizeY)
+	w.profile = opts.Profile
+	hops := make([]int32, sg.SizeX*sg.SizeY)
+	w.hops(seed, hops, true)
+
+	candidates := []Cell{}
+	for x := xMin; x <= xMax; x++ {
+		for y := yMin; y <= yMax; y++ {
+			if hops[sg.index(x, y)] != math.MaxInt32 {
+				candidates = append(candidates, Cell{x, y})
+			}
+		}
+	}
+	stops := spreadCells(candidates, Cell{int(seed) % sg.SizeX, int(seed) / sg.SizeX}, count)
+	if len(stops) < 2 {
+		return Path{Waypoints: []mosaic.Vector{}}, ErrPathNotFound
+	}
+
+	s := sg.searcher()
+	defer sg.searchers.Put(s)
+
+	// legs are charged their cost plus their length so open ground, where
+	// every cell weighs nothing, still favors short loops
+	costs := make([][]float64, len(stops))
+	for i := range stops {
+		costs[i] = make([]float64, len(stops))
+		for j := range stops {
+			if i == j {
+				continue
+			}
+			cost, err := s.findCells(stops[i], stops[j], opts)
+			if err != nil {
+				return Path{Waypoints: []mosaic.Vector{}}, err
+			}
+			costs[i][j] = cost + float64(len(s.cells))
+		}
+	}
+
+	order := loopOrder(costs)
+	path := Path{Waypoints: []mosaic.Vector{}, Costs: []float64{}}
+	for i := range order {
+		from, to := stops[order[i]], stops[order[(i+1)%len(order)]]
+		leg, err := s.findPath(sg.CellCenter(from.X, from.Y), sg.CellCenter(to.X, to.Y), opts)
+		if err != nil {
+			return Path{Waypoints: []mosaic.Vector{}}, err
+		}
+
+		waypoints, costs := leg.Waypoints, leg.Costs
+		if len(path.Waypoints) > 0 {
+			waypoints, costs = waypoints[1:], costs[1:]
+		}
+		path.Waypoints = append(path.Waypoints, waypoints...)
+		for _, cost := range costs {
+			path.Costs = append(path.Costs, path.Cost+cost)
+		}
+		path.Cost += leg.Cost
+	}
+
+	return path, nil
+}
+
+// spreadCells picks up to count cells, starting with the one farthest from
+// anchor and then always the one farthest from every pick so far
+func spreadCells(cells []Cell, anchor Cell, count int) []Cell {
+	distance := func(a, b Cell) float64 {
+		return math.Hypot(float64(a.X-b.X), float64(a.Y-b.Y))
+	}
+
+	nearest := make([]float64, len(cells))
+	for i, c := range cells {
+		nearest[i] = distance(c, anchor)
+	}
+
+	picks := []Cell{}
+	for len(picks) < count {
+		best := -1
+		for i := range cells {
+			if nearest[i] > 0 && (best < 0 || nearest[i] > nearest[best]) {
+				best = i
+			}
+		}
+		if best < 0 {
+			break
+		}
+
+		picks = append(picks, cells[best])
+		for i, c := range cells {
+			nearest[i] = min(nearest[i], distance(c, cells[best]))
+		}
+	}
+
+	return picks
+}
+
+// loopOrder builds a tour by nearest neighbor from stop 0, then applies
+// 2-opt reversals until none shortens it
+func loopOrder(costs [][]float64) []int {
+	n := len(costs)
+	order := []int{0}
+	used := make([]bool, n)
+	used[0] = true
+	for len(order) < n {
+		last, next := order[len(order)-1], -1
+		for j := 0; j < n; j++ {
+			if !used[j] && (next < 0 || costs[last][j] < costs[last][next]) {
+				next = j
+			}
+		}
+		used[next] = true
+		order = append(order, next)
+	}
+
+	length := func(order []int) float64 {
+		total := 0.0
+		for i := range order {
+			total += costs[order[i]][order[(i+1)%n]]
+		}
+		return total
+	}
+
+	best := length(order)
+	for improved := true; improved; {
+		improved = false
+		for i := 1; i < n-1; i++ {
+			for j := i + 1; j < n; j++ {
+				slices.Reverse(order[i : j+1])
+				if l := length(order); l < best {
+					best, improved = l, true
+					continue
+				}
+				slices.Reverse(order[i : j+1])
+			}
+		}
+	}
+
+	return order
+}
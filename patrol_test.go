@@ -0,0 +1,95 @@
+package lattice_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/mosaic"
+)
+
+func Test_spatial_grid_GeneratePatrol(t *testing.T) {
+	room := Builder{
+		x:    9,
+		y:    9,
+		size: 16,
+		layout: "" +
+			"000000000" +
+			"000000000" +
+			"000000000" +
+			"000xxx000" +
+			"000xxx000" +
+			"000xxx000" +
+			"000000000" +
+			"000000000" +
+			"000000000",
+	}
+	whole := mosaic.NewRectangle(mosaic.NewVector(72, 72), 144, 144)
+	type want struct {
+		err error
+	}
+	tests := []struct {
+		name  string
+		area  mosaic.Rectangle
+		count int
+		want  want
+	}{
+		{name: "corners of the room", area: whole, count: 4, want: want{}},
+		{name: "too few", area: whole, count: 1, want: want{err: lattice.ErrInvalidOption}},
+		{
+			name:  "walled off area",
+			area:  mosaic.NewRectangle(mosaic.NewVector(72, 72), 40, 40),
+			count: 3,
+			want:  want{err: lattice.ErrPathNotFound},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg := lattice.NewSpatialGrid[int](room.x, room.y, float64(room.size))
+			setup_grid(sg, room)
+
+			path, err := sg.GeneratePatrol(tt.area, tt.count, lattice.PathOptions{})
+			if !errors.Is(err, tt.want.err) {
+				t.Fatal(fmt.Errorf("spatialGrid.GeneratePatrol() want: %+v, got: %+v\n", tt.want.err, err))
+			}
+			if err != nil {
+				return
+			}
+
+			waypoints := path.Waypoints
+			if waypoints[0] != waypoints[len(waypoints)-1] {
+				t.Error(fmt.Errorf("spatialGrid.GeneratePatrol() want a closed loop, got: %+v\n", waypoints))
+			}
+			corners := map[mosaic.Vector]bool{
+				mosaic.NewVector(8, 8): false, mosaic.NewVector(136, 8): false,
+				mosaic.NewVector(8, 136): false, mosaic.NewVector(136, 136): false,
+			}
+			for i, waypoint := range waypoints {
+				if _, ok := corners[waypoint]; ok {
+					corners[waypoint] = true
+				}
+				x, y := sg.Location(waypoint.X, waypoint.Y)
+				if sg.Blocked(x, y) {
+					t.Error(fmt.Errorf("spatialGrid.GeneratePatrol() waypoint %d is blocked: %+v\n", i, waypoint))
+				}
+				if i == 0 {
+					continue
+				}
+				dx, dy := waypoint.X-waypoints[i-1].X, waypoint.Y-waypoints[i-1].Y
+				if dx*dx+dy*dy != 256 {
+					t.Error(fmt.Errorf("spatialGrid.GeneratePatrol() waypoints %d and %d are not adjacent\n", i-1, i))
+				}
+			}
+			for corner, seen := range corners {
+				if !seen {
+					t.Error(fmt.Errorf("spatialGrid.GeneratePatrol() want corner %+v on the loop\n", corner))
+				}
+			}
+			// the loop around the pillar is the perimeter of the room
+			if len(waypoints) != 33 {
+				t.Error(fmt.Errorf("spatialGrid.GeneratePatrol() want: %+v waypoints, got: %+v\n", 33, len(waypoints)))
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package lattice
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+
+	"github.com/maladroitthief/mosaic"
+)
+
+// The saved layout is a fixed header followed by one little endian float64
+// per cell in index order (y*SizeX + x), so the weights of cell i always sit
+// at persistHeader + 8*i and a memory mapped file can be read in place.
+//
+//	offset size field
+//	0      4    magic "LTCG"
+//	4      4    version
+//	8      4    SizeX
+//	12     4    SizeY
+//	16     8    ChunkSize
+//	24     8    origin X
+//	32     8    origin Y
+const (
+	persistMagic   = "LTCG"
+	persistVersion = 1
+	persistHeader  = 40
+)
+
+var (
+	ErrInvalidFormat = errors.New("data is not a saved lattice grid")
+)
+
+// SaveTo writes the static weight of every cell: its terrain and paint plus
+// the weight of its static items. Dynamic items are not saved, and since item
+// values are not serialized static items and paint come back as plain
+// terrain.
+func (sg *SpatialGrid[T]) SaveTo(w io.WriterAt) error {
+	sg = sg.rlock()
+	defer sg.runlock()
+
+	header := make([]byte, persistHeader)
+	copy(header[0:4], persistMagic)
+	binary.LittleEndian.PutUint32(header[4:8], persistVersion)
+	binary.LittleEndian.PutUint32(header[8:12], uint32(sg.SizeX))
+	binary.LittleEndian.PutUint32(header[12:16], uint32(sg.SizeY))
+	binary.LittleEndian.PutUint64(header[16:24], math.Float64bits(sg.ChunkSize))
+	binary.LittleEndian.PutUint64(header[24:32], math.Float64bits(sg.origin.X))
+	binary.LittleEndian.PutUint64(header[32:40], math.Float64bits(sg.origin.Y))
+	_, err := w.WriteAt(header, 0)
+	if err != nil {
+		return err
+	}
+
+	row := make([]byte, 8*sg.SizeX)
+	for y := 0; y < sg.SizeY; y++ {
+		for x := 0; x < sg.SizeX; x++ {
+			binary.LittleEndian.PutUint64(row[8*x:], math.Float64bits(sg.Nodes[x][y].staticWeight()))
+		}
+
+		_, err = w.WriteAt(row, int64(persistHeader+8*sg.index(0, y)))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// OpenFrom builds a grid from data written by SaveTo. The saved dimensions
+// and origin win over any passed in opts.
+func OpenFrom[T comparable](r io.ReaderAt, opts ...Option) (*SpatialGrid[T], error) {
+	header := make([]byte, persistHeader)
+	err := readAt(r, header, 0)
+	if err != nil {
+		return nil, err
+	}
+	if string(header[0:4]) != persistMagic || binary.LittleEndian.Uint32(header[4:8]) != persistVersion {
+		return nil, ErrInvalidFormat
+	}
+
+	sizeX := int(binary.LittleEndian.Uint32(header[8:12]))
+	sizeY := int(binary.LittleEndian.Uint32(header[12:16]))
+	size := math.Float64frombits(binary.LittleEndian.Uint64(header[16:24]))
+	origin := mosaic.NewVector(
+		math.Float64frombits(binary.LittleEndian.Uint64(header[24:32])),
+		math.Float64frombits(binary.LittleEndian.Uint64(header[32:40])),
+	)
+	if sizeX <= 0 || sizeY <= 0 || sizeX > (math.MaxInt-persistHeader)/8/sizeY ||
+		!(size > 0) || math.IsInf(size, 1) {
+		return nil, ErrInvalidFormat
+	}
+
+	// the header alone must not decide how much gets allocated, so make sure
+	// the data it promises is really there
+	err = readAt(r, header[:1], int64(persistHeader+8*sizeX*sizeY-1))
+	if err != nil {
+		return nil, err
+	}
+
+	opts = append(opts[:len(opts):len(opts)], WithOrigin(origin.X, origin.Y))
+	sg, err := NewSpatialGridOpts[T](sizeX, sizeY, size, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	sg.lock()
+	defer sg.unlock()
+
+	row := make([]byte, 8*sizeX)
+	for y := 0; y < sizeY; y++ {
+		err = readAt(r, row, int64(persistHeader+8*sg.index(0, y)))
+		if err != nil {
+			return nil, err
+		}
+
+		for x := 0; x < sizeX; x++ {
+			weight := math.Float64frombits(binary.LittleEndian.Uint64(row[8*x:]))
+			if weight != 0 {
+				sg.setTerrain(x, y, weight)
+			}
+		}
+	}
+
+	return sg, nil
+}
+
+// readAt treats a short read as a truncated file, ReaderAt may report io.EOF
+// alongside a full read at the very end of the data
+func readAt(r io.ReaderAt, buf []byte, offset int64) error {
+	n, err := r.ReadAt(buf, offset)
+	if n < len(buf) {
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return ErrInvalidFormat
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (sgn spatialGridNode[T]) staticWeight() float64 {
+	weight := sgn.base()
+	for i := 0; i < sgn.static; i++ {
+		weight += sgn.Items[i].weight
+	}
+
+	return weight
+}
@@ -0,0 +1,128 @@
+package lattice_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/mosaic"
+)
+
+type memoryFile struct {
+	data []byte
+}
+
+func (m *memoryFile) WriteAt(p []byte, off int64) (int, error) {
+	if end := int(off) + len(p); end > len(m.data) {
+		m.data = append(m.data, make([]byte, end-len(m.data))...)
+	}
+
+	return copy(m.data[off:], p), nil
+}
+
+func Test_spatial_grid_SaveTo(t *testing.T) {
+	sg, err := lattice.NewSpatialGridOpts[int](3, 2, 8, lattice.WithOrigin(-8, 16))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sg.InsertStatic(lattice.Item[int]{1, mosaic.NewRectangle(mosaic.NewVector(-4, 20), 2, 2), 1})
+	sg.InsertStatic(lattice.Item[int]{2, mosaic.NewRectangle(mosaic.NewVector(12, 28), 2, 2), math.Inf(1)})
+	sg.Insert(lattice.Item[int]{3, mosaic.NewRectangle(mosaic.NewVector(4, 20), 2, 2), 5})
+	sg.SetTerrain(1, 1, 7)
+
+	tests := []struct {
+		name string
+		open func(t *testing.T) (*lattice.SpatialGrid[int], error)
+	}{
+		{
+			name: "memory",
+			open: func(t *testing.T) (*lattice.SpatialGrid[int], error) {
+				file := &memoryFile{}
+				if err := sg.SaveTo(file); err != nil {
+					t.Fatal(err)
+				}
+				return lattice.OpenFrom[int](bytes.NewReader(file.data))
+			},
+		},
+		{
+			name: "file",
+			open: func(t *testing.T) (*lattice.SpatialGrid[int], error) {
+				file, err := os.Create(filepath.Join(t.TempDir(), "grid.bin"))
+				if err != nil {
+					t.Fatal(err)
+				}
+				defer file.Close()
+				if err := sg.SaveTo(file); err != nil {
+					t.Fatal(err)
+				}
+				return lattice.OpenFrom[int](file)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.open(t)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got.SizeX != 3 || got.SizeY != 2 || got.ChunkSize != 8 || got.Origin() != sg.Origin() {
+				t.Error(fmt.Errorf("lattice.OpenFrom() want shape: %+v, got: %+v\n", sg, got))
+			}
+			want := [][]float64{{4, 0}, {0, 7}, {0, math.Inf(1)}}
+			for x := range want {
+				for y, weight := range want[x] {
+					if got.GetLocationWeight(x, y) != weight {
+						t.Error(fmt.Errorf("lattice.OpenFrom() cell %+v, %+v want: %+v, got: %+v\n", x, y, weight, got.GetLocationWeight(x, y)))
+					}
+				}
+			}
+			if !got.Blocked(2, 1) || got.Size() != 0 {
+				t.Error(fmt.Errorf("lattice.OpenFrom() want blocked cell and no items, got: %+v items\n", got.Size()))
+			}
+		})
+	}
+}
+
+func Test_spatial_grid_OpenFrom_invalid(t *testing.T) {
+	file := &memoryFile{}
+	lattice.NewSpatialGrid[int](2, 2, 8).SaveTo(file)
+	put32 := func(data []byte, offset int, value uint32) []byte {
+		data = bytes.Clone(data)
+		binary.LittleEndian.PutUint32(data[offset:], value)
+		return data
+	}
+	put64 := func(data []byte, offset int, value uint64) []byte {
+		data = bytes.Clone(data)
+		binary.LittleEndian.PutUint64(data[offset:], value)
+		return data
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{name: "empty", data: []byte{}},
+		{name: "bad magic", data: append([]byte("NOPE"), file.data[4:]...)},
+		{name: "truncated", data: file.data[:len(file.data)-1]},
+		{name: "no columns", data: put32(file.data, 8, 0)},
+		{name: "more rows than data", data: put32(file.data, 12, 1<<20)},
+		{name: "overflowing size", data: put32(put32(file.data, 8, math.MaxUint32), 12, math.MaxUint32)},
+		{name: "no chunk size", data: put64(file.data, 16, 0)},
+		{name: "nan chunk size", data: put64(file.data, 16, math.Float64bits(math.NaN()))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := lattice.OpenFrom[int](bytes.NewReader(tt.data))
+			if !errors.Is(err, lattice.ErrInvalidFormat) {
+				t.Error(fmt.Errorf("lattice.OpenFrom() want error: %+v, got error: %+v\n", lattice.ErrInvalidFormat, err))
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package lattice
+
+import "github.com/maladroitthief/mosaic"
+
+// InsertPinned stores the item as a static item that also survives Drop and
+// Reset, for level geometry that outlives every round. Delete still removes
+// it and Update reinserts it as dynamic.
+func (sg *SpatialGrid[T]) InsertPinned(item Item[T]) error {
+	sg.lock()
+	defer sg.unlock()
+
+	err := sg.insertAs(item, partitionPinned)
+	if err == nil {
+		sg.track(item.Value, item.Bounds, true)
+	}
+
+	return err
+}
+
+// InsertPinned keeps pinned items packed at the front of the static ones
+func (sgn spatialGridNode[T]) InsertPinned(item T, bounds mosaic.Rectangle, multiplier float64, weigh WeightFunc) spatialGridNode[T] {
+	sgn = sgn.InsertStatic(item, bounds, multiplier, weigh)
+	sgn = sgn.swap(sgn.pinned, sgn.static-1)
+	sgn.pinned++
+
+	return sgn
+}
+
+func (sgn spatialGridNode[T]) keepPinned() spatialGridNode[T] {
+	sgn.Items = sgn.Items[:sgn.pinned]
+	sgn.packed = sgn.packed.truncate(sgn.pinned)
+	sgn.static = sgn.pinned
+	sgn.weight = sgn.itemWeights()
+
+	return sgn
+}
@@ -0,0 +1,125 @@
+package lattice_test
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/mosaic"
+)
+
+func Test_spatial_grid_InsertPinned(t *testing.T) {
+	const (
+		dynamic = iota
+		static
+		pinned
+	)
+	type params struct {
+		item   int
+		bounds mosaic.Rectangle
+		kind   int
+	}
+	tests := []struct {
+		name     string
+		params   []params
+		deleted  []params
+		drop     func(sg *lattice.SpatialGrid[int])
+		want     []int
+		weighted []int
+		wantSize int
+	}{
+		{
+			name: "drop keeps pinned",
+			params: []params{
+				{item: 1, bounds: mosaic.NewRectangle(mosaic.Vector{X: 4, Y: 4}, 8, 8), kind: pinned},
+				{item: 2, bounds: mosaic.NewRectangle(mosaic.Vector{X: 5, Y: 5}, 1, 1)},
+				{item: 3, bounds: mosaic.NewRectangle(mosaic.Vector{X: 3, Y: 3}, 2, 2), kind: static},
+				{item: 4, bounds: mosaic.NewRectangle(mosaic.Vector{X: 12, Y: 4}, 1, 1), kind: pinned},
+			},
+			drop:     (*lattice.SpatialGrid[int]).Drop,
+			want:     []int{1, 4},
+			weighted: []int{1},
+		},
+		{
+			name: "drop dynamic keeps static and pinned",
+			params: []params{
+				{item: 1, bounds: mosaic.NewRectangle(mosaic.Vector{X: 4, Y: 4}, 8, 8), kind: pinned},
+				{item: 2, bounds: mosaic.NewRectangle(mosaic.Vector{X: 5, Y: 5}, 1, 1)},
+				{item: 3, bounds: mosaic.NewRectangle(mosaic.Vector{X: 3, Y: 3}, 2, 2), kind: static},
+			},
+			drop:     (*lattice.SpatialGrid[int]).DropDynamic,
+			want:     []int{1, 3},
+			weighted: []int{1, 3},
+		},
+		{
+			name: "reset keeps pinned",
+			params: []params{
+				{item: 1, bounds: mosaic.NewRectangle(mosaic.Vector{X: 4, Y: 4}, 8, 8), kind: pinned},
+				{item: 2, bounds: mosaic.NewRectangle(mosaic.Vector{X: 5, Y: 5}, 1, 1)},
+			},
+			drop: func(sg *lattice.SpatialGrid[int]) {
+				sg.Reset([]lattice.Item[int]{{5, mosaic.NewRectangle(mosaic.Vector{X: 12, Y: 12}, 1, 1), 1.0}})
+			},
+			want:     []int{1, 5},
+			weighted: []int{1},
+		},
+		{
+			name: "delete unpins",
+			params: []params{
+				{item: 1, bounds: mosaic.NewRectangle(mosaic.Vector{X: 4, Y: 4}, 8, 8), kind: pinned},
+				{item: 2, bounds: mosaic.NewRectangle(mosaic.Vector{X: 3, Y: 3}, 2, 2), kind: static},
+				{item: 3, bounds: mosaic.NewRectangle(mosaic.Vector{X: 5, Y: 5}, 2, 2), kind: pinned},
+				{item: 4, bounds: mosaic.NewRectangle(mosaic.Vector{X: 6, Y: 6}, 1, 1)},
+			},
+			deleted: []params{
+				{item: 1, bounds: mosaic.NewRectangle(mosaic.Vector{X: 4, Y: 4}, 8, 8)},
+			},
+			drop:     (*lattice.SpatialGrid[int]).Drop,
+			want:     []int{3},
+			weighted: []int{3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg := lattice.NewSpatialGrid[int](4, 4, 8)
+			for _, param := range tt.params {
+				item := lattice.Item[int]{param.item, param.bounds, 1.0}
+				switch param.kind {
+				case pinned:
+					sg.InsertPinned(item)
+				case static:
+					sg.InsertStatic(item)
+				default:
+					sg.Insert(item)
+				}
+			}
+			for _, param := range tt.deleted {
+				sg.Delete(param.item, param.bounds)
+			}
+
+			tt.drop(sg)
+			query := mosaic.NewRectangle(mosaic.Vector{X: 16, Y: 16}, 32, 32)
+			got := sg.FindNear(query)
+			slices.Sort(got)
+			if !slices.Equal(tt.want, got) {
+				t.Error(fmt.Errorf("spatialGrid.FindNear() want: %+v, got: %+v\n", tt.want, got))
+			}
+			if sg.Size() != len(tt.want) {
+				t.Error(fmt.Errorf("spatialGrid.Size() want: %+v, got: %+v\n", len(tt.want), sg.Size()))
+			}
+
+			want := 0.0
+			for _, item := range tt.weighted {
+				for _, param := range tt.params {
+					if param.item == item {
+						want += param.bounds.Area()
+					}
+				}
+			}
+			if got := sg.GetLocationWeight(0, 0); got != want {
+				t.Error(fmt.Errorf("spatialGrid.GetLocationWeight() want: %+v, got: %+v\n", want, got))
+			}
+		})
+	}
+}
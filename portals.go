@@ -0,0 +1,70 @@
+package lattice
+
+import "maps"
+
+type portal struct {
+	to   int
+	cost float64
+}
+
+// AddPortal adds a one-way edge between two cells. Taking it costs cost plus
+// the destination's weight, the same as stepping into any other cell.
+func (sg *SpatialGrid[T]) AddPortal(fromX, fromY, toX, toY int, cost float64) error {
+	sg.lock()
+	defer sg.unlock()
+
+	if !sg.inBounds(fromX, fromY) || !sg.inBounds(toX, toY) {
+		return ErrOutOfBounds
+	}
+
+	sg.shape++
+	if sg.portals == nil {
+		sg.portals = map[int][]portal{}
+	}
+	from, to := sg.index(fromX, fromY), sg.index(toX, toY)
+	for i, p := range sg.portals[from] {
+		if p.to == to {
+			sg.portals[from][i].cost = cost
+			return nil
+		}
+	}
+	sg.portals[from] = append(sg.portals[from], portal{to: to, cost: cost})
+
+	return nil
+}
+
+func (sg *SpatialGrid[T]) RemovePortal(fromX, fromY, toX, toY int) {
+	sg.lock()
+	defer sg.unlock()
+
+	if !sg.inBounds(fromX, fromY) || !sg.inBounds(toX, toY) {
+		return
+	}
+
+	sg.shape++
+	from, to := sg.index(fromX, fromY), sg.index(toX, toY)
+	kept := []portal{}
+	for _, p := range sg.portals[from] {
+		if p.to != to {
+			kept = append(kept, p)
+		}
+	}
+	if len(kept) == 0 {
+		delete(sg.portals, from)
+		return
+	}
+	sg.portals[from] = kept
+}
+
+func (sg *SpatialGrid[T]) clonePortals() map[int][]portal {
+	if sg.portals == nil {
+		return nil
+	}
+
+	portals := maps.Clone(sg.portals)
+	for from, edges := range portals {
+		portals[from] = append([]portal(nil), edges...)
+	}
+
+	return portals
+}
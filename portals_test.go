@@ -0,0 +1,97 @@
+package lattice_test
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/mosaic"
+)
+
+func Test_spatial_grid_AddPortal(t *testing.T) {
+	grid := Builder{
+		x:    5,
+		y:    5,
+		size: 8,
+		layout: "" +
+			"00x00" +
+			"00x00" +
+			"00x00" +
+			"00x00" +
+			"00x00",
+	}
+	type portal struct {
+		fromX, fromY, toX, toY int
+		cost                   float64
+	}
+	type want struct {
+		path  []mosaic.Vector
+		edges int
+		err   error
+	}
+	tests := []struct {
+		name    string
+		portals []portal
+		removed []portal
+		want    want
+	}{
+		{
+			name: "disconnected",
+			want: want{path: []mosaic.Vector{}, edges: 3, err: lattice.ErrPathNotFound},
+		},
+		{
+			name:    "through the portal",
+			portals: []portal{{fromX: 1, fromY: 0, toX: 3, toY: 4, cost: 1}},
+			want: want{path: []mosaic.Vector{
+				{X: 4, Y: 4}, {X: 12, Y: 4}, {X: 28, Y: 36}, {X: 36, Y: 36},
+			}, edges: 4},
+		},
+		{
+			name:    "one way",
+			portals: []portal{{fromX: 3, fromY: 4, toX: 1, toY: 0, cost: 1}},
+			want:    want{path: []mosaic.Vector{}, edges: 3, err: lattice.ErrPathNotFound},
+		},
+		{
+			name:    "removed",
+			portals: []portal{{fromX: 1, fromY: 0, toX: 3, toY: 4, cost: 1}},
+			removed: []portal{{fromX: 1, fromY: 0, toX: 3, toY: 4}},
+			want:    want{path: []mosaic.Vector{}, edges: 3, err: lattice.ErrPathNotFound},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg := lattice.NewSpatialGrid[int](grid.x, grid.y, float64(grid.size))
+			setup_grid(sg, grid)
+			for _, p := range tt.portals {
+				err := sg.AddPortal(p.fromX, p.fromY, p.toX, p.toY, p.cost)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+			for _, p := range tt.removed {
+				sg.RemovePortal(p.fromX, p.fromY, p.toX, p.toY)
+			}
+
+			got, err := sg.WeightedSearch(mosaic.Vector{X: 4, Y: 4}, mosaic.Vector{X: 36, Y: 36}, 64)
+			if !errors.Is(err, tt.want.err) {
+				t.Error(fmt.Errorf("spatialGrid.WeightedSearch() want error: %+v, got error: %+v\n", tt.want.err, err))
+			}
+			if !slices.Equal(tt.want.path, got) {
+				t.Error(fmt.Errorf("spatialGrid.WeightedSearch() want: %+v, got: %+v\n", tt.want.path, got))
+			}
+
+			edges := sg.Edges(sg.Node(1, 0))
+			if len(edges) != tt.want.edges {
+				t.Error(fmt.Errorf("spatialGrid.Edges() want: %+v, got: %+v\n", tt.want.edges, len(edges)))
+			}
+		})
+	}
+
+	sg := lattice.NewSpatialGrid[int](grid.x, grid.y, float64(grid.size))
+	err := sg.AddPortal(0, 0, 5, 0, 1)
+	if err != lattice.ErrOutOfBounds {
+		t.Error(fmt.Errorf("spatialGrid.AddPortal() want error: %+v, got error: %+v\n", lattice.ErrOutOfBounds, err))
+	}
+}
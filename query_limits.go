@@ -0,0 +1,77 @@
+package lattice
+
+import (
+	"errors"
+
+	"github.com/maladroitthief/mosaic"
+)
+
+type (
+	// QueryLimits caps the work a single query may do, zero leaves a limit
+	// off. Cells are counted as they are scanned, in index order.
+	QueryLimits struct {
+		MaxResults      int
+		MaxCellsVisited int
+	}
+)
+
+var (
+	ErrQueryTruncated = errors.New("query stopped at one of its limits")
+)
+
+// FindNearLimited is FindNear bounded by limits. When a limit is hit it stops
+// and returns the values found so far with ErrQueryTruncated.
+func (sg *SpatialGrid[T]) FindNearLimited(bounds mosaic.Rectangle, limits QueryLimits) ([]T, error) {
+	sg = sg.rlock()
+	defer sg.runlock()
+
+	return sg.findLimited(bounds, limits, sg.config.precise)
+}
+
+// FindIntersectingLimited is FindIntersecting bounded by limits, see
+// FindNearLimited
+func (sg *SpatialGrid[T]) FindIntersectingLimited(bounds mosaic.Rectangle, limits QueryLimits) ([]T, error) {
+	sg = sg.rlock()
+	defer sg.runlock()
+
+	return sg.findLimited(bounds, limits, true)
+}
+
+func (sg *SpatialGrid[T]) findLimited(bounds mosaic.Rectangle, limits QueryLimits, intersect bool) ([]T, error) {
+	if limits.MaxResults < 0 || limits.MaxCellsVisited < 0 {
+		return nil, ErrInvalidOption
+	}
+
+	set := sg.newValueSet()
+	xMinIndex, yMinIndex, xMaxIndex, yMaxIndex, ok := sg.cellRange(bounds)
+	if !ok {
+		return set.values(), nil
+	}
+
+	var mask []uint8
+	visited := 0
+	for y := yMinIndex; y <= yMaxIndex; y++ {
+		for x := xMinIndex; x <= xMaxIndex; x++ {
+			if limits.MaxCellsVisited > 0 && visited == limits.MaxCellsVisited {
+				return set.values(), ErrQueryTruncated
+			}
+			visited++
+
+			node := sg.Nodes[x][y]
+			if intersect {
+				mask = node.packed.intersect(bounds, mask)
+			}
+			for i := range node.Items {
+				if intersect && mask[i] == 0 {
+					continue
+				}
+				if limits.MaxResults > 0 && set.len() == limits.MaxResults && !set.has(node.Items[i].value) {
+					return set.values(), ErrQueryTruncated
+				}
+				set.add(node.Items[i].value)
+			}
+		}
+	}
+
+	return set.values(), nil
+}
@@ -0,0 +1,87 @@
+package lattice_test
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/mosaic"
+)
+
+func Test_spatial_grid_FindNearLimited(t *testing.T) {
+	everything := mosaic.NewRectangle(mosaic.Vector{X: 16, Y: 16}, 32, 32)
+	tests := []struct {
+		name      string
+		bounds    mosaic.Rectangle
+		limits    lattice.QueryLimits
+		intersect bool
+		want      []int
+		err       error
+	}{
+		{
+			name:   "unlimited",
+			bounds: everything,
+			want:   []int{1, 2, 3},
+		},
+		{
+			name:   "max results reached exactly",
+			bounds: everything,
+			limits: lattice.QueryLimits{MaxResults: 3},
+			want:   []int{1, 2, 3},
+		},
+		{
+			name:   "max results",
+			bounds: everything,
+			limits: lattice.QueryLimits{MaxResults: 2},
+			want:   []int{1, 2},
+			err:    lattice.ErrQueryTruncated,
+		},
+		{
+			name:   "max cells",
+			bounds: everything,
+			limits: lattice.QueryLimits{MaxCellsVisited: 2},
+			want:   []int{1, 2},
+			err:    lattice.ErrQueryTruncated,
+		},
+		{
+			name:      "intersecting",
+			bounds:    mosaic.NewRectangle(mosaic.Vector{X: 4, Y: 10}, 2, 8),
+			limits:    lattice.QueryLimits{MaxCellsVisited: 2},
+			intersect: true,
+			want:      []int{3},
+		},
+		{
+			name:   "negative limit",
+			bounds: everything,
+			limits: lattice.QueryLimits{MaxResults: -1},
+			err:    lattice.ErrInvalidOption,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg := lattice.NewSpatialGrid[int](4, 4, 8)
+			sg.Insert(lattice.Item[int]{1, mosaic.NewRectangle(mosaic.Vector{X: 4, Y: 4}, 2, 2), 1})
+			sg.Insert(lattice.Item[int]{2, mosaic.NewRectangle(mosaic.Vector{X: 12, Y: 4}, 2, 2), 1})
+			sg.Insert(lattice.Item[int]{3, mosaic.NewRectangle(mosaic.Vector{X: 4, Y: 12}, 2, 2), 1})
+
+			var (
+				got []int
+				err error
+			)
+			if tt.intersect {
+				got, err = sg.FindIntersectingLimited(tt.bounds, tt.limits)
+			} else {
+				got, err = sg.FindNearLimited(tt.bounds, tt.limits)
+			}
+			slices.Sort(got)
+			if !errors.Is(err, tt.err) {
+				t.Error(fmt.Errorf("spatialGrid.FindNearLimited() want: %+v, got: %+v\n", tt.err, err))
+			}
+			if !slices.Equal(tt.want, got) {
+				t.Error(fmt.Errorf("spatialGrid.FindNearLimited() want: %+v, got: %+v\n", tt.want, got))
+			}
+		})
+	}
+}
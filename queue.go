@@ -0,0 +1,34 @@
+package lattice
+
+// ringQueue is a FIFO over a circular buffer that doubles when full
+type ringQueue[T any] struct {
+	items []T
+	head  int
+	size  int
+}
+
+func (q *ringQueue[T]) Len() int {
+	return q.size
+}
+
+func (q *ringQueue[T]) Enqueue(value T) {
+	if q.size == len(q.items) {
+		items := make([]T, max(2*len(q.items), 16))
+		n := copy(items, q.items[q.head:])
+		copy(items[n:], q.items[:q.head])
+		q.items, q.head = items, 0
+	}
+
+	q.items[(q.head+q.size)%len(q.items)] = value
+	q.size++
+}
+
+func (q *ringQueue[T]) Dequeue() T {
+	var zero T
+	value := q.items[q.head]
+	q.items[q.head] = zero
+	q.head = (q.head + 1) % len(q.items)
+	q.size--
+
+	return value
+}
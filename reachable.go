@@ -0,0 +1,74 @@
+package lattice
+
+import (
+	"cmp"
+	"math"
+	"slices"
+
+	"github.com/maladroitthief/mosaic"
+)
+
+type (
+	// ReachableCell is a cell within a movement budget and the cheapest cost
+	// of reaching it
+	ReachableCell struct {
+		Cell Cell
+		Cost float64
+	}
+)
+
+// ReachableCells returns every cell reachable from start for at most maxCost,
+// cheapest first with ties in index order, start included at zero. Costs follow FindPath: entering
+// a cell costs its weight, portals add their own cost, and edge rules see
+// profile.
+func (sg *SpatialGrid[T]) ReachableCells(start mosaic.Vector, maxCost float64, profile TraversalProfile) ([]ReachableCell, error) {
+	if maxCost < 0 || math.IsNaN(maxCost) {
+		return nil, ErrInvalidOption
+	}
+
+	sg = sg.rlock()
+	defer sg.runlock()
+
+	startX, startY, err := sg.cell(start.X, start.Y)
+	if err != nil {
+		return nil, err
+	}
+
+	cells := sg.SizeX * sg.SizeY
+	costs := make([]float64, cells)
+	for i := range costs {
+		costs[i] = math.Inf(1)
+	}
+	settled := newBitset(cells)
+	open := indexedHeap{}
+	open.reset(cells)
+
+	startIndex := int32(sg.index(startX, startY))
+	costs[startIndex] = 0
+	open.Push(startIndex, 0)
+
+	reached := []ReachableCell{}
+	for open.Len() > 0 {
+		current := open.Pop()
+		settled.set(int(current), true)
+		reached = append(reached, ReachableCell{
+			Cell: Cell{X: int(current) % sg.SizeX, Y: int(current) / sg.SizeX},
+			Cost: costs[current],
+		})
+
+		sg.goalEdges(current, profile, func(next int32, _ int, cost float64) {
+			newCost := costs[current] + cost
+			if settled.get(int(next)) || newCost > maxCost || newCost >= costs[next] {
+				return
+			}
+			costs[next] = newCost
+			open.Push(next, newCost)
+		})
+	}
+
+	slices.SortStableFunc(reached, func(a, b ReachableCell) int {
+		return cmp.Or(cmp.Compare(a.Cost, b.Cost), cmp.Compare(a.Cell.Y, b.Cell.Y), cmp.Compare(a.Cell.X, b.Cell.X))
+	})
+
+	return reached, nil
+}
@@ -0,0 +1,91 @@
+package lattice_test
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/mosaic"
+)
+
+func Test_spatial_grid_ReachableCells(t *testing.T) {
+	tests := []struct {
+		name    string
+		terrain map[lattice.Cell]float64
+		walls   []lattice.Cell
+		start   mosaic.Vector
+		budget  float64
+		want    []lattice.ReachableCell
+		err     error
+	}{
+		{
+			name:   "start only",
+			start:  mosaic.NewVector(4, 4),
+			budget: 0.2,
+			want:   []lattice.ReachableCell{{Cell: lattice.Cell{X: 0, Y: 0}, Cost: 0}},
+		},
+		{
+			name:    "budget and terrain",
+			terrain: map[lattice.Cell]float64{{X: 0, Y: 0}: 1, {X: 1, Y: 0}: 1, {X: 2, Y: 0}: 1, {X: 0, Y: 1}: 3},
+			start:   mosaic.NewVector(4, 4),
+			budget:  2,
+			want: []lattice.ReachableCell{
+				{Cell: lattice.Cell{X: 0, Y: 0}, Cost: 0},
+				{Cell: lattice.Cell{X: 1, Y: 0}, Cost: 1},
+				{Cell: lattice.Cell{X: 1, Y: 1}, Cost: 1.25},
+				{Cell: lattice.Cell{X: 2, Y: 1}, Cost: 1.5},
+				{Cell: lattice.Cell{X: 1, Y: 2}, Cost: 1.5},
+				{Cell: lattice.Cell{X: 0, Y: 2}, Cost: 1.75},
+				{Cell: lattice.Cell{X: 2, Y: 2}, Cost: 1.75},
+				{Cell: lattice.Cell{X: 2, Y: 0}, Cost: 2},
+			},
+		},
+		{
+			name:    "walls",
+			terrain: map[lattice.Cell]float64{{X: 0, Y: 0}: 1, {X: 1, Y: 0}: 1, {X: 0, Y: 1}: 1, {X: 0, Y: 2}: 1},
+			walls:   []lattice.Cell{{X: 1, Y: 1}},
+			start:   mosaic.NewVector(4, 4),
+			budget:  1.5,
+			want: []lattice.ReachableCell{
+				{Cell: lattice.Cell{X: 0, Y: 0}, Cost: 0},
+				{Cell: lattice.Cell{X: 1, Y: 0}, Cost: 1},
+				{Cell: lattice.Cell{X: 0, Y: 1}, Cost: 1},
+				{Cell: lattice.Cell{X: 2, Y: 0}, Cost: 1.25},
+				{Cell: lattice.Cell{X: 2, Y: 1}, Cost: 1.5},
+			},
+		},
+		{
+			name:   "negative budget",
+			start:  mosaic.NewVector(4, 4),
+			budget: -1,
+			err:    lattice.ErrInvalidOption,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg := lattice.NewSpatialGrid[int](3, 3, 8)
+			for x := 0; x < 3; x++ {
+				for y := 0; y < 3; y++ {
+					sg.SetTerrain(x, y, 0.25)
+				}
+			}
+			for cell, weight := range tt.terrain {
+				sg.SetTerrain(cell.X, cell.Y, weight)
+			}
+			for _, cell := range tt.walls {
+				sg.SetTerrain(cell.X, cell.Y, math.Inf(1))
+			}
+
+			got, err := sg.ReachableCells(tt.start, tt.budget, lattice.TraversalProfile{})
+			if !errors.Is(err, tt.err) {
+				t.Error(fmt.Errorf("spatialGrid.ReachableCells() want: %+v, got: %+v\n", tt.err, err))
+			}
+			if !slices.Equal(tt.want, got) {
+				t.Error(fmt.Errorf("spatialGrid.ReachableCells() want: %+v, got: %+v\n", tt.want, got))
+			}
+		})
+	}
+}
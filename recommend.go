@@ -0,0 +1,88 @@
+package lattice
+
+import (
+	"math"
+	"time"
+
+	"github.com/maladroitthief/mosaic"
+)
+
+type (
+	// Workload is a sample of what a grid will be asked to do. Bounds is the
+	// world area the grid has to cover.
+	Workload[T comparable] struct {
+		Bounds  mosaic.Rectangle
+		Items   []Item[T]
+		Queries []mosaic.Rectangle
+	}
+
+	ChunkResult struct {
+		ChunkSize float64
+		Insert    time.Duration
+		Query     time.Duration
+	}
+
+	// Recommendation holds the measurements for every candidate in the order
+	// given, ChunkSize is the one with the lowest combined time
+	Recommendation struct {
+		ChunkSize float64
+		Results   []ChunkResult
+	}
+)
+
+// recommendRounds repeats each measurement and keeps the fastest, which
+// filters out most scheduler and GC noise
+const recommendRounds = 3
+
+// Recommend replays the workload against a grid for every candidate chunk
+// size, inserting all items and then running all queries, and reports the
+// fastest. Timings are wall clock, so run it on the target hardware.
+func Recommend[T comparable](chunkSizes []float64, workload Workload[T], opts ...Option) (Recommendation, error) {
+	if len(chunkSizes) == 0 {
+		return Recommendation{}, ErrInvalidChunkSize
+	}
+	if workload.Bounds.Width <= 0 || workload.Bounds.Height <= 0 {
+		return Recommendation{}, ErrInvalidDimensions
+	}
+
+	origin := workload.Bounds.MinPoint()
+	opts = append(opts[:len(opts):len(opts)], WithOrigin(origin.X, origin.Y))
+
+	recommendation := Recommendation{Results: make([]ChunkResult, 0, len(chunkSizes))}
+	best := time.Duration(math.MaxInt64)
+	for _, size := range chunkSizes {
+		if size <= 0 || math.IsInf(size, 0) || math.IsNaN(size) {
+			return Recommendation{}, ErrInvalidChunkSize
+		}
+
+		x := int(math.Ceil(workload.Bounds.Width / size))
+		y := int(math.Ceil(workload.Bounds.Height / size))
+		result := ChunkResult{ChunkSize: size, Insert: math.MaxInt64, Query: math.MaxInt64}
+		for round := 0; round < recommendRounds; round++ {
+			sg, err := NewSpatialGridOpts[T](x, y, size, opts...)
+			if err != nil {
+				return Recommendation{}, err
+			}
+
+			start := time.Now()
+			for _, item := range workload.Items {
+				sg.Insert(item)
+			}
+			result.Insert = min(result.Insert, time.Since(start))
+
+			start = time.Now()
+			for _, query := range workload.Queries {
+				sg.FindNear(query)
+			}
+			result.Query = min(result.Query, time.Since(start))
+		}
+
+		recommendation.Results = append(recommendation.Results, result)
+		if result.Insert+result.Query < best {
+			best = result.Insert + result.Query
+			recommendation.ChunkSize = size
+		}
+	}
+
+	return recommendation, nil
+}
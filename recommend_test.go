@@ -0,0 +1,58 @@
+package lattice_test
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/mosaic"
+)
+
+func Test_Recommend(t *testing.T) {
+	workload := lattice.Workload[int]{
+		Bounds:  mosaic.NewRectangle(mosaic.NewVector(0, 0), 256, 256),
+		Items:   []lattice.Item[int]{},
+		Queries: []mosaic.Rectangle{},
+	}
+	for i := 0; i < 256; i++ {
+		position := mosaic.NewVector(float64(i%16*16-120), float64(i/16*16-120))
+		workload.Items = append(workload.Items, lattice.Item[int]{i, mosaic.NewRectangle(position, 4, 4), 1})
+		workload.Queries = append(workload.Queries, mosaic.NewRectangle(position, 24, 24))
+	}
+
+	tests := []struct {
+		name   string
+		sizes  []float64
+		bounds mosaic.Rectangle
+		err    error
+	}{
+		{name: "candidates", sizes: []float64{4, 16, 64}, bounds: workload.Bounds},
+		{name: "no candidates", sizes: []float64{}, bounds: workload.Bounds, err: lattice.ErrInvalidChunkSize},
+		{name: "bad candidate", sizes: []float64{16, -1}, bounds: workload.Bounds, err: lattice.ErrInvalidChunkSize},
+		{name: "empty world", sizes: []float64{16}, err: lattice.ErrInvalidDimensions},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := workload
+			w.Bounds = tt.bounds
+			got, err := lattice.Recommend(tt.sizes, w)
+			if !errors.Is(err, tt.err) {
+				t.Fatal(fmt.Errorf("lattice.Recommend() want error: %+v, got error: %+v\n", tt.err, err))
+			}
+			if err != nil {
+				return
+			}
+
+			if !slices.Contains(tt.sizes, got.ChunkSize) || len(got.Results) != len(tt.sizes) {
+				t.Error(fmt.Errorf("lattice.Recommend() want one of: %+v, got: %+v\n", tt.sizes, got))
+			}
+			for i, result := range got.Results {
+				if result.ChunkSize != tt.sizes[i] || result.Insert <= 0 || result.Query <= 0 {
+					t.Error(fmt.Errorf("lattice.Recommend() want measured result for: %+v, got: %+v\n", tt.sizes[i], result))
+				}
+			}
+		})
+	}
+}
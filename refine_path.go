@@ -0,0 +1,46 @@
+package lattice
+
+import "github.com/maladroitthief/mosaic"
+
+// RefinePath turns a route planned on a Downsample grid into one on sg. It
+// runs a local search to every window-th coarse waypoint in turn, from the
+// first waypoint to the last, so pass the real start and goal at the ends. A
+// waypoint that lands on a blocked fine cell is approached as closely as the
+// fine grid allows, only the goal has to be reached exactly.
+func (sg *SpatialGrid[T]) RefinePath(coarsePath []mosaic.Vector, window int) (Path, error) {
+	if window < 1 || len(coarsePath) == 0 {
+		return Path{Waypoints: []mosaic.Vector{}}, ErrInvalidOption
+	}
+
+	sg = sg.rlock()
+	defer sg.runlock()
+
+	s := sg.searcher()
+	defer sg.searchers.Put(s)
+
+	path := Path{Waypoints: []mosaic.Vector{}, Costs: []float64{}}
+	from := coarsePath[0]
+	for i := 0; ; {
+		i = min(i+window, len(coarsePath)-1)
+		goal := i == len(coarsePath)-1
+
+		leg, err := s.findPath(from, coarsePath[i], PathOptions{AllowPartial: !goal})
+		if err != nil {
+			return Path{Waypoints: []mosaic.Vector{}}, err
+		}
+
+		waypoints, costs := leg.Waypoints, leg.Costs
+		if len(path.Waypoints) > 0 {
+			waypoints, costs = waypoints[1:], costs[1:]
+		}
+		path.Waypoints = append(path.Waypoints, waypoints...)
+		for _, cost := range costs {
+			path.Costs = append(path.Costs, path.Cost+cost)
+		}
+		path.Cost += leg.Cost
+		if goal {
+			return path, nil
+		}
+		from = leg.Waypoints[len(leg.Waypoints)-1]
+	}
+}
@@ -0,0 +1,83 @@
+package lattice_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/mosaic"
+)
+
+func Test_spatial_grid_RefinePath(t *testing.T) {
+	maze := Builder{x: 8, y: 8, size: 8, layout: "" +
+		"00000000" +
+		"0xxxxxx0" +
+		"0x000000" +
+		"0x0xxxxx" +
+		"0x000000" +
+		"0xxxxxx0" +
+		"00000x00" +
+		"xxx00000"}
+	tests := []struct {
+		name   string
+		window int
+		coarse bool
+		err    error
+	}{
+		{name: "window of one", window: 1, coarse: true},
+		{name: "wide window", window: 3, coarse: true},
+		{name: "single waypoint", window: 1},
+		{name: "invalid window", window: 0, coarse: true, err: lattice.ErrInvalidOption},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg := lattice.NewSpatialGrid[int](maze.x, maze.y, float64(maze.size))
+			setup_grid(sg, maze)
+			start, end := mosaic.NewVector(4, 4), mosaic.NewVector(60, 60)
+
+			route := []mosaic.Vector{start}
+			if tt.coarse {
+				coarse, err := sg.Downsample(2, nil)
+				if err != nil {
+					t.Fatal(err)
+				}
+				plan, err := coarse.FindPath(start, end, lattice.PathOptions{})
+				if err != nil {
+					t.Fatal(err)
+				}
+				route = plan.Waypoints
+				route[0], route[len(route)-1] = start, end
+			}
+
+			got, err := sg.RefinePath(route, tt.window)
+			if !errors.Is(err, tt.err) {
+				t.Fatal(fmt.Errorf("spatialGrid.RefinePath() want: %+v, got: %+v\n", tt.err, err))
+			}
+			if err != nil {
+				return
+			}
+
+			want := route[len(route)-1]
+			if got.Waypoints[0] != start || got.Waypoints[len(got.Waypoints)-1] != want {
+				t.Error(fmt.Errorf("spatialGrid.RefinePath() want: %+v to %+v, got: %+v\n", start, want, got.Waypoints))
+			}
+			if len(got.Costs) != len(got.Waypoints) || got.Costs[len(got.Costs)-1] != got.Cost {
+				t.Error(fmt.Errorf("spatialGrid.RefinePath() want: %+v, got: %+v\n", got.Cost, got.Costs))
+			}
+			for i, waypoint := range got.Waypoints {
+				x, y := sg.Location(waypoint.X, waypoint.Y)
+				if sg.Blocked(x, y) {
+					t.Error(fmt.Errorf("spatialGrid.RefinePath() want open cells, got: %+v\n", waypoint))
+				}
+				if i == 0 {
+					continue
+				}
+				step := waypoint.Subtract(got.Waypoints[i-1])
+				if max(step.X, -step.X)+max(step.Y, -step.Y) != 8 {
+					t.Error(fmt.Errorf("spatialGrid.RefinePath() want single steps, got: %+v\n", got.Waypoints))
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package lattice
+
+import (
+	"math"
+
+	"github.com/maladroitthief/mosaic"
+)
+
+// Repartition rebuilds the grid with cells of the given size over at least
+// the same world area, reinserting every item with its static and pinned
+// flags intact.
+// Terrain and paint are resampled by area. Portals, edge rules, cell data,
+// labels, heat and scent are tied to the old cells and are cleared. Cell indices held from
+// before the call are meaningless afterwards. It returns ErrCellFull and
+// leaves the grid as it was when the new cells could not hold every item
+// under a cell cap that does not spill.
+func (sg *SpatialGrid[T]) Repartition(size float64) error {
+	if size <= 0 || math.IsInf(size, 0) || math.IsNaN(size) {
+		return ErrInvalidChunkSize
+	}
+
+	sg.lock()
+	defer sg.unlock()
+
+	old, oldSize := sg.Nodes, sg.ChunkSize
+	width, height := float64(sg.SizeX)*oldSize, float64(sg.SizeY)*oldSize
+	overflow := sg.overflow
+	sizeX := max(1, int(math.Ceil(width/size)))
+	sizeY := max(1, int(math.Ceil(height/size)))
+	if !sg.fitsCap(sizeX, sizeY, size) {
+		return ErrCellFull
+	}
+
+	sg.ChunkSize = size
+	sg.shape++
+	sg.SizeX, sg.SizeY = sizeX, sizeY
+	sg.Nodes = sg.newNodes()
+	sg.blocked = newBitset(sg.SizeX * sg.SizeY)
+	sg.portals, sg.edgeRules = nil, nil
+	sg.overflow = nil
+	sg.itemCount = 0
+
+	oldArea := oldSize * oldSize
+	for x := range old {
+		for _, node := range old[x] {
+			if node.terrain != 0 {
+				sg.spreadTerrain(node.bounds, node.terrain/oldArea)
+			}
+			// restored weight no longer lines up with any one cell, so it
+			// becomes terrain
+			if node.restored != 0 {
+				sg.spreadTerrain(node.bounds, node.restored/oldArea)
+			}
+			if node.paint != 0 {
+				sg.spreadPaint(node.bounds, node.paint/oldArea)
+			}
+		}
+	}
+
+	var err error
+	keep := func(insertErr error) {
+		if insertErr != nil {
+			err = insertErr
+		}
+	}
+	for x := range old {
+		for _, node := range old[x] {
+			for i, item := range node.Items {
+				next := Item[T]{Value: item.value, Bounds: item.bounds, Multiplier: item.multiplier}
+				switch {
+				case i < node.pinned:
+					keep(sg.place(next, partitionPinned))
+				case i < node.static:
+					keep(sg.place(next, partitionStatic))
+				default:
+					keep(sg.place(next, partitionDynamic))
+				}
+			}
+		}
+	}
+	for _, item := range overflow {
+		keep(sg.place(item.Item, item.partition))
+	}
+
+	return err
+}
+
+// fitsCap reports whether every item would find room under the cell cap in
+// a sizeX by sizeY layout of the given chunk size; only spilling makes room
+// for the rest
+func (sg *SpatialGrid[T]) fitsCap(sizeX, sizeY int, size float64) bool {
+	if sg.config.cellCap <= 0 || sg.config.overflow == OverflowSpill {
+		return true
+	}
+
+	counts := make([]int, sizeX*sizeY)
+	for x := range sg.Nodes {
+		for _, node := range sg.Nodes[x] {
+			for _, item := range node.Items {
+				cellX := min(max(int((item.bounds.Position.X-sg.origin.X)/size), 0), sizeX-1)
+				cellY := min(max(int((item.bounds.Position.Y-sg.origin.Y)/size), 0), sizeY-1)
+				counts[cellX+cellY*sizeX]++
+				if counts[cellX+cellY*sizeX] > sg.config.cellCap {
+					return false
+				}
+			}
+		}
+	}
+
+	return true
+}
+
+// spreadTerrain adds density times the overlapping area to every cell under
+// bounds
+func (sg *SpatialGrid[T]) spreadTerrain(bounds mosaic.Rectangle, density float64) {
+	xMin, yMin, xMax, yMax, _ := sg.cellRange(bounds)
+	for x := xMin; x <= xMax; x++ {
+		for y := yMin; y <= yMax; y++ {
+			overlap := sg.Nodes[x][y].bounds.AreaOfOverlap(bounds)
+			if overlap <= 0 {
+				continue
+			}
+			sg.setTerrain(x, y, sg.Nodes[x][y].terrain+density*overlap)
+		}
+	}
+}
+
+// Occupancy is the mean number of items in the cells holding any, the
+// figure to watch when deciding whether to Repartition
+func (sg *SpatialGrid[T]) Occupancy() float64 {
+	sg = sg.rlock()
+	defer sg.runlock()
+
+	items, cells := 0, 0
+	for x := range sg.Nodes {
+		for _, node := range sg.Nodes[x] {
+			if len(node.Items) == 0 {
+				continue
+			}
+			items += len(node.Items)
+			cells++
+		}
+	}
+	if cells == 0 {
+		return 0
+	}
+
+	return float64(items) / float64(cells)
+}
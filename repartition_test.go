@@ -0,0 +1,124 @@
+package lattice_test
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/mosaic"
+)
+
+func Test_spatial_grid_Repartition(t *testing.T) {
+	type want struct {
+		sizeX, sizeY int
+		occupancy    float64
+		terrain      float64
+	}
+	tests := []struct {
+		name string
+		size float64
+		want want
+	}{
+		{name: "coarser", size: 16, want: want{sizeX: 2, sizeY: 2, occupancy: 1.5, terrain: 64}},
+		{name: "finer", size: 4, want: want{sizeX: 8, sizeY: 8, occupancy: 1, terrain: 16}},
+		{name: "uneven", size: 12, want: want{sizeX: 3, sizeY: 3, occupancy: 1.5, terrain: 64}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg := lattice.NewSpatialGrid[int](4, 4, 8)
+			sg.InsertStatic(lattice.Item[int]{1, mosaic.NewRectangle(mosaic.NewVector(2, 2), 2, 2), 1})
+			sg.Insert(lattice.Item[int]{2, mosaic.NewRectangle(mosaic.NewVector(6, 6), 2, 2), 1})
+			sg.Insert(lattice.Item[int]{3, mosaic.NewRectangle(mosaic.NewVector(28, 28), 2, 2), math.Inf(1)})
+			sg.SetTerrain(0, 0, 64)
+
+			err := sg.Repartition(tt.size)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if sg.SizeX != tt.want.sizeX || sg.SizeY != tt.want.sizeY || sg.ChunkSize != tt.size {
+				t.Error(fmt.Errorf("spatialGrid.Repartition() want: %+v, got: %+v, %+v\n", tt.want, sg.SizeX, sg.SizeY))
+			}
+			got := sg.FindNear(mosaic.NewRectangle(mosaic.NewVector(16, 16), 32, 32))
+			slices.Sort(got)
+			if !slices.Equal(got, []int{1, 2, 3}) || sg.Size() != 3 {
+				t.Error(fmt.Errorf("spatialGrid.Repartition() want: %+v, got: %+v\n", []int{1, 2, 3}, got))
+			}
+			if sg.Occupancy() != tt.want.occupancy {
+				t.Error(fmt.Errorf("spatialGrid.Occupancy() want: %+v, got: %+v\n", tt.want.occupancy, sg.Occupancy()))
+			}
+
+			x, y := sg.Location(28, 28)
+			if !sg.Blocked(x, y) {
+				t.Error(fmt.Errorf("spatialGrid.Blocked() want: %+v, got: %+v\n", true, false))
+			}
+			if sg.Terrain(0, 0) != tt.want.terrain {
+				t.Error(fmt.Errorf("spatialGrid.Terrain() want: %+v, got: %+v\n", tt.want.terrain, sg.Terrain(0, 0)))
+			}
+
+			sg.DropDynamic()
+			if sg.Size() != 1 {
+				t.Error(fmt.Errorf("spatialGrid.DropDynamic() want: %+v, got: %+v\n", 1, sg.Size()))
+			}
+		})
+	}
+
+	err := lattice.NewSpatialGrid[int](4, 4, 8).Repartition(0)
+	if err != lattice.ErrInvalidChunkSize {
+		t.Error(fmt.Errorf("spatialGrid.Repartition() want error: %+v, got error: %+v\n", lattice.ErrInvalidChunkSize, err))
+	}
+}
+
+func Test_spatial_grid_Repartition_stats(t *testing.T) {
+	sg, err := lattice.NewSpatialGridOpts[int](4, 4, 8, lattice.WithBoundsDiagnostics())
+	if err != nil {
+		t.Fatal(err)
+	}
+	sg.Insert(lattice.Item[int]{1, mosaic.NewRectangle(mosaic.NewVector(1, 1), 4, 4), 1})
+	sg.Insert(lattice.Item[int]{2, mosaic.NewRectangle(mosaic.NewVector(6, 6), 2, 2), 1})
+	want := sg.Stats()
+
+	err = sg.Repartition(4)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := sg.Stats()
+	if got != want || got.OutOfBounds != 1 {
+		t.Error(fmt.Errorf("spatialGrid.Stats() want: %+v, got: %+v\n", want, got))
+	}
+}
+
+func Test_spatial_grid_Repartition_cellCap(t *testing.T) {
+	tests := []struct {
+		name     string
+		overflow lattice.Overflow
+	}{
+		{name: "reject", overflow: lattice.OverflowReject},
+		{name: "evict", overflow: lattice.OverflowEvict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg, err := lattice.NewSpatialGridOpts[int](4, 4, 8, lattice.WithCellCap(2, tt.overflow))
+			if err != nil {
+				t.Fatal(err)
+			}
+			sg.Insert(lattice.Item[int]{1, mosaic.NewRectangle(mosaic.NewVector(2, 2), 2, 2), 1})
+			sg.Insert(lattice.Item[int]{2, mosaic.NewRectangle(mosaic.NewVector(10, 2), 2, 2), 1})
+			sg.Insert(lattice.Item[int]{3, mosaic.NewRectangle(mosaic.NewVector(2, 10), 2, 2), 1})
+
+			err = sg.Repartition(16)
+			if err != lattice.ErrCellFull {
+				t.Error(fmt.Errorf("spatialGrid.Repartition() want error: %+v, got error: %+v\n", lattice.ErrCellFull, err))
+			}
+
+			got := sg.FindNear(mosaic.NewRectangle(mosaic.NewVector(16, 16), 32, 32))
+			slices.Sort(got)
+			if !slices.Equal(got, []int{1, 2, 3}) || sg.Size() != 3 || sg.SizeX != 4 || sg.ChunkSize != 8 {
+				t.Error(fmt.Errorf("spatialGrid.Repartition() want: %+v, got: %+v\n", []int{1, 2, 3}, got))
+			}
+		})
+	}
+}
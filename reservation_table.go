@@ -0,0 +1,289 @@
+package lattice
+
+import (
+	"errors"
+
+	"github.com/maladroitthief/mosaic"
+)
+
+type (
+	ReservationTable struct {
+		window       int
+		reservations map[reservation]int
+		agents       map[int][]reservation
+	}
+
+	reservation struct {
+		x    int
+		y    int
+		tick int
+	}
+)
+
+var (
+	ErrCellReserved = errors.New("cell is already reserved by another agent")
+)
+
+func NewReservationTable(window int) *ReservationTable {
+	return &ReservationTable{
+		window:       window,
+		reservations: map[reservation]int{},
+		agents:       map[int][]reservation{},
+	}
+}
+
+func (rt *ReservationTable) Window() int {
+	return rt.window
+}
+
+func (rt *ReservationTable) Reserve(agent, x, y, tick int) error {
+	key := reservation{x, y, tick}
+	owner, ok := rt.reservations[key]
+	if ok && owner != agent {
+		return ErrCellReserved
+	}
+	if ok {
+		return nil
+	}
+
+	rt.reservations[key] = agent
+	rt.agents[agent] = append(rt.agents[agent], key)
+
+	return nil
+}
+
+func (rt *ReservationTable) ReservedBy(x, y, tick int) (int, bool) {
+	agent, ok := rt.reservations[reservation{x, y, tick}]
+	return agent, ok
+}
+
+func (rt *ReservationTable) IsReserved(agent, x, y, tick int) bool {
+	owner, ok := rt.reservations[reservation{x, y, tick}]
+	return ok && owner != agent
+}
+
+func (rt *ReservationTable) Release(agent int) {
+	for _, key := range rt.agents[agent] {
+		if rt.reservations[key] == agent {
+			delete(rt.reservations, key)
+		}
+	}
+	delete(rt.agents, agent)
+}
+
+func (rt *ReservationTable) Prune(before int) {
+	for agent, keys := range rt.agents {
+		kept := keys[:0]
+		for _, key := range keys {
+			if key.tick >= before {
+				kept = append(kept, key)
+				continue
+			}
+			delete(rt.reservations, key)
+		}
+
+		if len(kept) == 0 {
+			delete(rt.agents, agent)
+			continue
+		}
+		rt.agents[agent] = kept
+	}
+}
+
+func (rt *ReservationTable) blocked(agent, fromX, fromY, toX, toY, tick int) bool {
+	if rt.IsReserved(agent, toX, toY, tick+1) {
+		return true
+	}
+
+	// head-on swap: another agent moves from our destination into our cell
+	owner, ok := rt.reservations[reservation{toX, toY, tick}]
+	if !ok || owner == agent {
+		return false
+	}
+	next, ok := rt.reservations[reservation{fromX, fromY, tick + 1}]
+
+	return ok && next == owner
+}
+
+func (sg *SpatialGrid[T]) ReservePath(rt *ReservationTable, agent int, path []mosaic.Vector, tick int) error {
+	sg = sg.rlock()
+	defer sg.runlock()
+
+	if len(path) == 0 {
+		return nil
+	}
+
+	// the whole plan is checked before any of it is written, so a rejected
+	// path leaves no reservations behind
+	planned := make([]reservation, 0, max(len(path), rt.window+1))
+	for i, point := range path {
+		x, y, err := sg.cell(point.X, point.Y)
+		if err != nil {
+			return err
+		}
+		planned = append(planned, reservation{x, y, tick + i})
+	}
+
+	// agents hold their goal cell for the rest of the window
+	last := planned[len(planned)-1]
+	for t := tick + len(path); t <= tick+rt.window; t++ {
+		planned = append(planned, reservation{last.x, last.y, t})
+	}
+
+	for _, r := range planned {
+		if owner, ok := rt.reservations[r]; ok && owner != agent {
+			return ErrCellReserved
+		}
+	}
+	for _, r := range planned {
+		err := rt.Reserve(agent, r.x, r.y, r.tick)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CooperativeSearch returns one waypoint per tick, waits included. It is
+// FindPath with Reservations set and MaxExpansions standing in for maxDepth.
+func (sg *SpatialGrid[T]) CooperativeSearch(
+	start mosaic.Vector,
+	end mosaic.Vector,
+	maxDepth int,
+	rt *ReservationTable,
+	agent int,
+	tick int,
+) ([]mosaic.Vector, error) {
+	path, err := sg.FindPath(start, end, PathOptions{
+		MaxExpansions: maxDepth + 1,
+		Reservations:  rt,
+		Agent:         agent,
+		Tick:          tick,
+	})
+	if err != nil {
+		return []mosaic.Vector{}, depthError(err)
+	}
+
+	return path.Waypoints, nil
+}
+
+// findTimed searches (cell, tick) states so that waiting in place is a move
+// and cells reserved by other agents are only avoided at the ticks they are
+// held. Every step, wait or portal takes one tick and costs one on top of the
+// cell's weight, so the route is one waypoint per tick.
+func (s *Searcher[T]) findTimed(start, end Cell, opts PathOptions) (Path, error) {
+	sg := s.grid
+	rt := opts.Reservations
+	clearance := sg.clearance(opts)
+	allowed := sg.allowedLabels(opts)
+	if !sg.validLayers(opts.Layers) {
+		return Path{Waypoints: s.path}, ErrInvalidOption
+	}
+
+	s.partial = false
+	s.spent = s.spent[:0]
+	s.path = s.path[:0]
+
+	// time past the window, or past MaxPathLength when that is longer,
+	// collapses into a single layer so the state space stays finite
+	type state struct {
+		cell int32
+		t    int
+	}
+	maxT := max(rt.window+1, opts.MaxPathLength)
+	endIndex := int32(sg.index(end.X, end.Y))
+	heuristic := func(cell int32) float64 {
+		return sg.heuristic(int(cell)%sg.SizeX-end.X, int(cell)/sg.SizeX-end.Y)
+	}
+
+	startState := state{int32(sg.index(start.X, start.Y)), 0}
+	cameFrom := map[state]state{startState: startState}
+	costs := map[state]float64{startState: 0}
+
+	// the heap orders indices into states, which holds every entry pushed
+	states := []state{startState}
+	pq := minHeap{}
+	pq = pq.Push(0, heuristic(startState.cell))
+
+	var goal state
+	found := false
+	expansions := 0
+	truncated := false
+	for pq.Len() > 0 {
+		if opts.MaxExpansions > 0 && expansions >= opts.MaxExpansions {
+			return Path{Waypoints: s.path}, ErrMaxExpansionsReached
+		}
+
+		var entry int32
+		entry, pq = pq.Pop()
+		current := states[entry]
+		if current.cell == endIndex {
+			goal = current
+			found = true
+			break
+		}
+		expansions++
+		if opts.MaxPathLength > 0 && current.t >= opts.MaxPathLength {
+			truncated = true
+			continue
+		}
+
+		currentX, currentY := int(current.cell)%sg.SizeX, int(current.cell)/sg.SizeX
+		move := func(next int32, _ int, cost float64) {
+			nextX, nextY := int(next)%sg.SizeX, int(next)/sg.SizeX
+			if allowed != nil && !allowed[sg.Nodes[nextX][nextY].label] {
+				return
+			}
+			if current.t < maxT && rt.blocked(opts.Agent, currentX, currentY, nextX, nextY, opts.Tick+current.t) {
+				return
+			}
+
+			newCost := costs[current] + cost + 1
+			if clearance != nil {
+				newCost += clearance[next]
+			}
+			if opts.Layers != nil {
+				newCost += layerCost(opts.Layers, nextX, nextY)
+			}
+
+			nextState := state{next, min(current.t+1, maxT)}
+			if nextState == current {
+				return
+			}
+			nextCost, ok := costs[nextState]
+			if ok && !(newCost < nextCost) {
+				return
+			}
+
+			costs[nextState] = newCost
+			cameFrom[nextState] = current
+			pq = pq.Push(int32(len(states)), newCost+heuristic(next))
+			states = append(states, nextState)
+		}
+		move(current.cell, -1, sg.Nodes[currentX][currentY].weight)
+		sg.goalEdges(current.cell, opts.Profile, move)
+	}
+
+	switch {
+	case found:
+	case truncated:
+		return Path{Waypoints: s.path}, ErrMaxPathLengthExceeded
+	default:
+		return Path{Waypoints: s.path}, ErrPathNotFound
+	}
+
+	states = states[:0]
+	for current := goal; current != startState; current = cameFrom[current] {
+		states = append(states, current)
+	}
+	states = append(states, startState)
+
+	for i := len(states) - 1; i >= 0; i-- {
+		x, y := int(states[i].cell)%sg.SizeX, int(states[i].cell)/sg.SizeX
+		s.path = append(s.path, sg.CellCenter(x, y))
+		s.spent = append(s.spent, costs[states[i]])
+	}
+
+	return Path{Waypoints: s.path, Costs: s.spent, Cost: costs[goal]}, nil
+}
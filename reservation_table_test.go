@@ -0,0 +1,198 @@
+package lattice_test
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/mosaic"
+)
+
+func Test_spatial_grid_CooperativeSearch(t *testing.T) {
+	type setup struct {
+		builder Builder
+	}
+	type params struct {
+		start  mosaic.Vector
+		end    mosaic.Vector
+		depth  int
+		window int
+	}
+	tests := []struct {
+		name   string
+		setup  setup
+		params params
+	}{
+		{
+			name: "head-on corridor",
+			setup: setup{
+				builder: Builder{
+					x:    5,
+					y:    5,
+					size: 32,
+					layout: "" +
+						"xxxxx" +
+						"00000" +
+						"xxx0x" +
+						"xxxxx" +
+						"xxxxx",
+				},
+			},
+			params: params{
+				start:  mosaic.NewVector(0, 1),
+				end:    mosaic.NewVector(4, 1),
+				depth:  256,
+				window: 16,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scale := func(b Builder, v float64) float64 {
+				return v*float64(b.size) + float64(b.size)/2
+			}
+			sg := lattice.NewSpatialGrid[int](
+				tt.setup.builder.x,
+				tt.setup.builder.y,
+				float64(tt.setup.builder.size),
+			)
+			setup_grid(sg, tt.setup.builder)
+			start := mosaic.NewVector(
+				scale(tt.setup.builder, tt.params.start.X),
+				scale(tt.setup.builder, tt.params.start.Y),
+			)
+			end := mosaic.NewVector(
+				scale(tt.setup.builder, tt.params.end.X),
+				scale(tt.setup.builder, tt.params.end.Y),
+			)
+
+			rt := lattice.NewReservationTable(tt.params.window)
+			first, err := sg.CooperativeSearch(start, end, tt.params.depth, rt, 1, 0)
+			if err != nil {
+				t.Fatal(fmt.Errorf("spatialGrid.CooperativeSearch() first agent error: %+v\n", err))
+			}
+			err = sg.ReservePath(rt, 1, first, 0)
+			if err != nil {
+				t.Fatal(fmt.Errorf("spatialGrid.ReservePath() error: %+v\n", err))
+			}
+
+			second, err := sg.CooperativeSearch(end, start, tt.params.depth, rt, 2, 0)
+			if err != nil {
+				t.Fatal(fmt.Errorf("spatialGrid.CooperativeSearch() second agent error: %+v\n", err))
+			}
+			if second[len(second)-1] != start {
+				t.Errorf("spatialGrid.CooperativeSearch() did not reach goal: %+v", second)
+			}
+
+			at := func(path []mosaic.Vector, tick int) mosaic.Vector {
+				if tick >= len(path) {
+					return path[len(path)-1]
+				}
+				return path[tick]
+			}
+			for tick := 0; tick < max(len(first), len(second)); tick++ {
+				if at(first, tick) == at(second, tick) {
+					t.Errorf("spatialGrid.CooperativeSearch() agents collide at tick %d: %+v", tick, at(first, tick))
+				}
+				if tick == 0 {
+					continue
+				}
+				if at(first, tick) == at(second, tick-1) && at(second, tick) == at(first, tick-1) {
+					t.Errorf("spatialGrid.CooperativeSearch() agents swap at tick %d", tick)
+				}
+			}
+		})
+	}
+}
+
+func Test_spatial_grid_ReservePath_rejected(t *testing.T) {
+	sg := lattice.NewSpatialGrid[int](5, 5, 32)
+	rt := lattice.NewReservationTable(8)
+	err := rt.Reserve(2, 2, 1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := []mosaic.Vector{sg.CellCenter(0, 1), sg.CellCenter(1, 1), sg.CellCenter(2, 1)}
+	err = sg.ReservePath(rt, 1, path, 0)
+	if err != lattice.ErrCellReserved {
+		t.Error(fmt.Errorf("spatialGrid.ReservePath() want: %+v, got: %+v\n", lattice.ErrCellReserved, err))
+	}
+	for tick, point := range path[:2] {
+		x, y := sg.Location(point.X, point.Y)
+		if agent, ok := rt.ReservedBy(x, y, tick); ok {
+			t.Error(fmt.Errorf("spatialGrid.ReservePath() want: no reservation at tick %d, got: agent %d\n", tick, agent))
+		}
+	}
+}
+
+func Test_spatial_grid_FindPath_Reservations(t *testing.T) {
+	type params struct {
+		reserved bool
+	}
+	tests := []struct {
+		name   string
+		params params
+		want   []lattice.Cell
+	}{
+		{
+			name:   "takes the portal",
+			params: params{reserved: false},
+			want:   []lattice.Cell{{X: 0, Y: 1}, {X: 3, Y: 1}, {X: 4, Y: 1}},
+		},
+		{
+			name:   "waits for the exit to clear",
+			params: params{reserved: true},
+			want:   []lattice.Cell{{X: 0, Y: 1}, {X: 0, Y: 1}, {X: 3, Y: 1}, {X: 4, Y: 1}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := Builder{
+				x:    5,
+				y:    5,
+				size: 32,
+				layout: "" +
+					"xxxxx" +
+					"00x00" +
+					"xxxxx" +
+					"xxxxx" +
+					"xxxxx",
+			}
+			sg := lattice.NewSpatialGrid[int](b.x, b.y, float64(b.size))
+			setup_grid(sg, b)
+			err := sg.AddPortal(0, 1, 3, 1, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			rt := lattice.NewReservationTable(8)
+			if tt.params.reserved {
+				err = rt.Reserve(2, 3, 1, 1)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			path, err := sg.FindPath(sg.CellCenter(0, 1), sg.CellCenter(4, 1), lattice.PathOptions{
+				Reservations: rt,
+				Agent:        1,
+			})
+			if err != nil {
+				t.Fatal(fmt.Errorf("spatialGrid.FindPath() error: %+v\n", err))
+			}
+			got := []lattice.Cell{}
+			for _, point := range path.Waypoints {
+				x, y := sg.Location(point.X, point.Y)
+				got = append(got, lattice.Cell{X: x, Y: y})
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Error(fmt.Errorf("spatialGrid.FindPath() want: %+v, got: %+v\n", tt.want, got))
+			}
+			if len(path.Costs) != len(path.Waypoints) {
+				t.Error(fmt.Errorf("spatialGrid.FindPath() want: %d costs, got: %d\n", len(path.Waypoints), len(path.Costs)))
+			}
+		})
+	}
+}
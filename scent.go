@@ -0,0 +1,59 @@
+package lattice
+
+import "math"
+
+const scentEpsilon = 1e-9
+
+func (sg *SpatialGrid[T]) SetScentDecay(rate float64) {
+	sg.lock()
+	defer sg.unlock()
+
+	sg.scentDecay = rate
+}
+
+func (sg *SpatialGrid[T]) AddScent(x, y, amount float64) {
+	sg.lock()
+	defer sg.unlock()
+
+	xIndex, yIndex := sg.Location(x, y)
+	sg.Nodes[xIndex][yIndex].scent += amount
+}
+
+func (sg *SpatialGrid[T]) Scent(x, y float64) float64 {
+	sg = sg.rlock()
+	defer sg.runlock()
+
+	xIndex, yIndex := sg.Location(x, y)
+	return sg.Nodes[xIndex][yIndex].scent
+}
+
+func (sg *SpatialGrid[T]) ClearScent() {
+	sg.lock()
+	defer sg.unlock()
+
+	for x := range sg.Nodes {
+		for y := range sg.Nodes[x] {
+			sg.Nodes[x][y].scent = 0
+		}
+	}
+}
+
+func (sg *SpatialGrid[T]) Tick(dt float64) {
+	sg.lock()
+	defer sg.unlock()
+
+	falloff := math.Exp(-sg.scentDecay * dt)
+	for x := range sg.Nodes {
+		for y := range sg.Nodes[x] {
+			scent := sg.Nodes[x][y].scent * falloff
+			if math.Abs(scent) < scentEpsilon {
+				scent = 0
+			}
+			sg.Nodes[x][y].scent = scent
+		}
+	}
+}
+
+func (sgn spatialGridNode[T]) Scent() float64 {
+	return sgn.scent
+}
@@ -0,0 +1,59 @@
+package lattice_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+)
+
+func Test_spatial_grid_Tick(t *testing.T) {
+	type params struct {
+		decay  float64
+		amount float64
+		ticks  int
+		dt     float64
+	}
+	tests := []struct {
+		name   string
+		params params
+		want   float64
+	}{
+		{
+			name:   "no decay",
+			params: params{decay: 0, amount: 10, ticks: 10, dt: 1},
+			want:   10,
+		},
+		{
+			name:   "half life",
+			params: params{decay: math.Ln2, amount: 8, ticks: 3, dt: 1},
+			want:   1,
+		},
+		{
+			name:   "fades out",
+			params: params{decay: 10, amount: 1, ticks: 100, dt: 1},
+			want:   0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg := lattice.NewSpatialGrid[int](4, 4, 8)
+			sg.SetScentDecay(tt.params.decay)
+			sg.AddScent(12, 12, tt.params.amount)
+			for range tt.params.ticks {
+				sg.Tick(tt.params.dt)
+			}
+
+			got := sg.Scent(12, 12)
+			if math.Abs(tt.want-got) > 1e-9 {
+				t.Error(fmt.Errorf("spatialGrid.Scent() want: %+v, got: %+v\n", tt.want, got))
+			}
+
+			got = sg.Scent(4, 4)
+			if got != 0 {
+				t.Error(fmt.Errorf("spatialGrid.Scent() [untouched cell] want: 0, got: %+v\n", got))
+			}
+		})
+	}
+}
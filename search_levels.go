@@ -0,0 +1,47 @@
+package lattice
+
+import "errors"
+
+var (
+	ErrStopSearch = errors.New("search stopped by caller")
+)
+
+// SearchLevels returns nil when process stops the search with ErrStopSearch
+func (sg *SpatialGrid[T]) SearchLevels(
+	x float64,
+	y float64,
+	maxDepth int,
+	process func(depth int, items []T) error,
+) error {
+	sg = sg.rlock()
+	defer sg.runlock()
+
+	startX, startY, err := sg.cell(x, y)
+	if err != nil {
+		return err
+	}
+
+	items := []T{}
+	visit := func(_ int, sgn spatialGridNode[T]) error {
+		for i := 0; i < len(sgn.Items); i++ {
+			items = append(items, sgn.Items[i].value)
+		}
+		return nil
+	}
+	levelDone := func(depth int) error {
+		if len(items) == 0 {
+			return nil
+		}
+
+		err := process(depth, items)
+		items = items[:0]
+		return err
+	}
+
+	_, err = sg.search([]spatialGridNode[T]{sg.Nodes[startX][startY]}, maxDepth, visit, levelDone, nil)
+	if errors.Is(err, ErrStopSearch) {
+		return nil
+	}
+
+	return err
+}
@@ -0,0 +1,124 @@
+package lattice_test
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+)
+
+func Test_spatial_grid_SearchLevels(t *testing.T) {
+	type setup struct {
+		builder Builder
+	}
+	type params struct {
+		x     float64
+		y     float64
+		depth int
+		stop  int
+	}
+	type want struct {
+		depths []int
+		counts []int
+		err    error
+	}
+	tests := []struct {
+		name   string
+		setup  setup
+		params params
+		want   want
+	}{
+		{
+			name: "rings",
+			setup: setup{
+				builder: Builder{
+					x:    5,
+					y:    5,
+					size: 32,
+					layout: "" +
+						"00000" +
+						"00100" +
+						"01010" +
+						"00100" +
+						"10000",
+				},
+			},
+			params: params{x: 80, y: 80, depth: 8, stop: -1},
+			want: want{
+				depths: []int{1, 4},
+				counts: []int{4, 1},
+			},
+		},
+		{
+			name: "stop at first ring",
+			setup: setup{
+				builder: Builder{
+					x:    5,
+					y:    5,
+					size: 32,
+					layout: "" +
+						"00000" +
+						"00100" +
+						"01010" +
+						"00100" +
+						"10000",
+				},
+			},
+			params: params{x: 80, y: 80, depth: 8, stop: 1},
+			want: want{
+				depths: []int{1},
+				counts: []int{4},
+			},
+		},
+		{
+			name: "depth limit",
+			setup: setup{
+				builder: Builder{
+					x:    5,
+					y:    5,
+					size: 32,
+					layout: "" +
+						"00000" +
+						"00100" +
+						"01010" +
+						"00100" +
+						"10000",
+				},
+			},
+			params: params{x: 80, y: 80, depth: 2, stop: -1},
+			want: want{
+				depths: []int{1},
+				counts: []int{4},
+				err:    lattice.ErrMaxDepthReached,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg := lattice.NewSpatialGrid[int](
+				tt.setup.builder.x,
+				tt.setup.builder.y,
+				float64(tt.setup.builder.size),
+			)
+			setup_grid(sg, tt.setup.builder)
+
+			depths, counts := []int{}, []int{}
+			err := sg.SearchLevels(tt.params.x, tt.params.y, tt.params.depth, func(depth int, items []int) error {
+				depths = append(depths, depth)
+				counts = append(counts, len(items))
+				if depth == tt.params.stop {
+					return lattice.ErrStopSearch
+				}
+				return nil
+			})
+
+			if err != tt.want.err {
+				t.Error(fmt.Errorf("spatialGrid.SearchLevels() want error: %+v, got error: %+v\n", tt.want.err, err))
+			}
+			if !slices.Equal(tt.want.depths, depths) || !slices.Equal(tt.want.counts, counts) {
+				t.Error(fmt.Errorf("spatialGrid.SearchLevels() want: %+v %+v, got: %+v %+v\n", tt.want.depths, tt.want.counts, depths, counts))
+			}
+		})
+	}
+}
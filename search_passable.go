@@ -0,0 +1,29 @@
+package lattice
+
+// SearchPassable is Search that will not step into a cell weighing more than
+// threshold, so blocked cells and walls bound the reachable area. The start
+// cell is visited whatever its weight.
+func (sg *SpatialGrid[T]) SearchPassable(
+	x float64,
+	y float64,
+	maxDepth int,
+	threshold float64,
+	process func([]T) error,
+) (SearchResult, error) {
+	sg = sg.rlock()
+	defer sg.runlock()
+
+	startX, startY, err := sg.cell(x, y)
+	if err != nil {
+		return SearchResult{}, err
+	}
+
+	visit := func(_ int, sgn spatialGridNode[T]) error {
+		return process(sgn.Values())
+	}
+	passable := func(sgn spatialGridNode[T]) bool {
+		return sgn.weight <= threshold
+	}
+
+	return sg.search([]spatialGridNode[T]{sg.Nodes[startX][startY]}, maxDepth, visit, nil, passable)
+}
@@ -0,0 +1,72 @@
+package lattice_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+)
+
+func Test_spatial_grid_SearchPassable(t *testing.T) {
+	builder := Builder{x: 5, y: 5, size: 16, layout: "" +
+		"01x00" +
+		"01x00" +
+		"00x00" +
+		"00x00" +
+		"00x00"}
+	tests := []struct {
+		name      string
+		threshold float64
+		depth     int
+		want      int
+		result    lattice.SearchResult
+	}{
+		{
+			name:      "open cells only",
+			threshold: 0,
+			depth:     10,
+			want:      8,
+			result:    lattice.SearchResult{Depth: 5, Exhausted: true},
+		},
+		{
+			name:      "weighted cells passable",
+			threshold: 256,
+			depth:     10,
+			want:      10,
+			result:    lattice.SearchResult{Depth: 5, Exhausted: true},
+		},
+		{
+			name:      "walls passable",
+			threshold: math.Inf(1),
+			depth:     10,
+			want:      25,
+			result:    lattice.SearchResult{Depth: 8, Exhausted: true},
+		},
+		{
+			name:      "within steps",
+			threshold: 0,
+			depth:     1,
+			want:      2,
+			result:    lattice.SearchResult{Depth: 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg := lattice.NewSpatialGrid[int](builder.x, builder.y, float64(builder.size))
+			setup_grid(sg, builder)
+
+			got := 0
+			result, _ := sg.SearchPassable(8, 8, tt.depth, tt.threshold, func([]int) error {
+				got++
+				return nil
+			})
+			if got != tt.want {
+				t.Error(fmt.Errorf("spatialGrid.SearchPassable() want: %+v, got: %+v\n", tt.want, got))
+			}
+			if result != tt.result {
+				t.Error(fmt.Errorf("spatialGrid.SearchPassable() want: %+v, got: %+v\n", tt.result, result))
+			}
+		})
+	}
+}
@@ -0,0 +1,373 @@
+package lattice
+
+import (
+	"math"
+	"slices"
+
+	"github.com/maladroitthief/mosaic"
+)
+
+type Searcher[T comparable] struct {
+	grid       *SpatialGrid[T]
+	costs      []float64
+	cameFrom   []int32
+	steps      []int32
+	floor      []int32
+	stamps     []uint32
+	generation uint32
+	open       indexedHeap
+	partial    bool
+	trace      *SearchTrace
+	cells      []int32
+	spent      []float64
+	path       []mosaic.Vector
+}
+
+func (sg *SpatialGrid[T]) NewSearcher() *Searcher[T] {
+	s := &Searcher[T]{grid: sg}
+	s.resize(sg.SizeX * sg.SizeY)
+
+	return s
+}
+
+func (sg *SpatialGrid[T]) searcher() *Searcher[T] {
+	s, ok := sg.searchers.Get().(*Searcher[T])
+	if !ok {
+		return sg.NewSearcher()
+	}
+
+	return s
+}
+
+func (s *Searcher[T]) resize(cells int) {
+	s.costs = make([]float64, cells)
+	s.cameFrom = make([]int32, cells)
+	s.steps = make([]int32, cells)
+	s.stamps = make([]uint32, cells)
+	s.generation = 0
+}
+
+func (s *Searcher[T]) reset(states int) {
+	if len(s.stamps) < states {
+		s.resize(states)
+	}
+
+	s.generation++
+	if s.generation == 0 {
+		clear(s.stamps)
+		s.generation = 1
+	}
+	s.open.reset(states)
+	s.partial = false
+	s.cells = s.cells[:0]
+	s.spent = s.spent[:0]
+	s.path = s.path[:0]
+}
+
+// resetFloor forgets the fewest steps any expanded state of each cell and
+// heading was reached in
+func (s *Searcher[T]) resetFloor(states int) {
+	if len(s.floor) < states {
+		s.floor = make([]int32, states)
+	}
+	for i := range s.floor[:states] {
+		s.floor[i] = math.MaxInt32
+	}
+}
+
+func (s *Searcher[T]) seen(i int32) bool {
+	return s.stamps[i] == s.generation
+}
+
+// dominated reports whether the recorded route to state i is at least as
+// good as a new one
+func (s *Searcher[T]) dominated(i int32, cost float64) bool {
+	return s.seen(i) && cost >= s.costs[i]
+}
+
+func (s *Searcher[T]) visit(i int32, cost float64, from int32, steps int32) {
+	s.stamps[i] = s.generation
+	s.costs[i] = cost
+	s.cameFrom[i] = from
+	s.steps[i] = steps
+}
+
+func (s *Searcher[T]) FindPath(start, end mosaic.Vector, opts PathOptions) (Path, error) {
+	grid := s.grid
+	s.grid = grid.rlock()
+	defer func() {
+		s.grid.runlock()
+		s.grid = grid
+	}()
+
+	return s.findPath(start, end, opts)
+}
+
+// WeightedSearch matches SpatialGrid.WeightedSearch, but the returned path is
+// owned by the searcher and only valid until its next search.
+func (s *Searcher[T]) WeightedSearch(start, end mosaic.Vector, maxDepth int) ([]mosaic.Vector, error) {
+	if maxDepth < 0 {
+		return []mosaic.Vector{}, ErrMaxDepthReached
+	}
+
+	path, err := s.FindPath(start, end, PathOptions{MaxExpansions: maxDepth + 1})
+	return path.Waypoints, depthError(err)
+}
+
+func (s *Searcher[T]) findPath(start, end mosaic.Vector, opts PathOptions) (Path, error) {
+	sg := s.grid
+	startX, startY, err := sg.cell(start.X, start.Y)
+	if err != nil {
+		return Path{Waypoints: s.path[:0]}, err
+	}
+	endX, endY, err := sg.cell(end.X, end.Y)
+	if err != nil {
+		return Path{Waypoints: s.path[:0]}, err
+	}
+	if opts.Reservations != nil {
+		return s.findTimed(Cell{startX, startY}, Cell{endX, endY}, opts)
+	}
+
+	cost, err := s.findCells(Cell{startX, startY}, Cell{endX, endY}, opts)
+	if err != nil {
+		return Path{Waypoints: s.path}, err
+	}
+
+	for i := len(s.cells) - 1; i >= 0; i-- {
+		x, y := int(s.cells[i])%sg.SizeX, int(s.cells[i])/sg.SizeX
+		s.path = append(s.path, sg.CellCenter(x, y))
+	}
+	slices.Reverse(s.spent)
+
+	return Path{Waypoints: s.path, Costs: s.spent, Cost: cost, Partial: s.partial}, nil
+}
+
+// heuristic is the fewest moves that could cover dx, dy given the longest
+// offset in the neighbor set, which is plain Manhattan distance for 4-way
+func (sg *SpatialGrid[T]) heuristic(dx, dy int) float64 {
+	dx, dy = abs(dx), abs(dy)
+	return max(float64(dx+dy)/sg.config.stepL1, float64(max(dx, dy))/sg.config.stepLInf)
+}
+
+// stepLayers is how many step counts a search tells apart. A route with
+// fewer steps can be worth keeping beside a cheaper one while MaxPathLength
+// binds, so every count gets its own states; a limit no simple route
+// reaches cannot bind and is searched as if unset.
+func (sg *SpatialGrid[T]) stepLayers(opts PathOptions) int32 {
+	if opts.MaxPathLength <= 0 || opts.MaxPathLength >= sg.SizeX*sg.SizeY-1 {
+		return 1
+	}
+	return int32(opts.MaxPathLength + 1)
+}
+
+func (sg *SpatialGrid[T]) searchStates(opts PathOptions) int32 {
+	if opts.TurnPenalty > 0 {
+		return int32(len(sg.config.neighbors) + 1)
+	}
+	return 1
+}
+
+// findCells leaves the route in s.cells from end back to start, with the cost
+// of reaching each in s.spent. With a turn penalty every cell is split into
+// one search state per incoming direction, plus a final state for "no
+// direction" used by the start and portal exits, and each of those again
+// into one state per step count under a binding MaxPathLength.
+func (s *Searcher[T]) findCells(start, end Cell, opts PathOptions) (float64, error) {
+	sg := s.grid
+	states := sg.searchStates(opts)
+	undirected := states - 1
+	layers := sg.stepLayers(opts)
+	stride := states * layers
+	prune := sg.prunes(opts)
+	landmarks := sg.landmarks(opts)
+	clearance := sg.clearance(opts)
+	allowed := sg.allowedLabels(opts)
+	if !sg.validLayers(opts.Layers) {
+		return 0, ErrInvalidOption
+	}
+	s.reset(sg.SizeX * sg.SizeY * int(stride))
+	if layers > 1 {
+		s.resetFloor(sg.SizeX * sg.SizeY * int(states))
+	}
+
+	startIndex := int32(sg.index(start.X, start.Y))
+	endIndex := int32(sg.index(end.X, end.Y))
+	startState := (startIndex*states + undirected) * layers
+	endState := int32(-1)
+
+	s.visit(startState, 0, startState, 0)
+	s.open.Push(startState, 0)
+	closest := closestState{state: startState, h: sg.estimate(landmarks, startIndex, endIndex, start.X-end.X, start.Y-end.Y)}
+
+	expansions := 0
+	truncated := false
+	var limit error
+HeapLoop:
+	for s.open.Len() > 0 {
+		if opts.MaxExpansions > 0 && expansions >= opts.MaxExpansions {
+			limit = ErrMaxExpansionsReached
+			break HeapLoop
+		}
+
+		current := s.open.Pop()
+		if current/stride == endIndex {
+			endState = current
+			break HeapLoop
+		}
+		// states leave the heap cheapest first, so one reached in no fewer
+		// steps than an earlier one of the same cell and heading can do
+		// nothing that one could not
+		if layers > 1 {
+			if s.floor[current/layers] <= s.steps[current] {
+				continue
+			}
+			s.floor[current/layers] = s.steps[current]
+		}
+		if s.trace != nil {
+			s.record(current/stride, expansions, stride)
+		}
+
+		steps := s.steps[current] + 1
+		if opts.MaxPathLength > 0 && int(steps) > opts.MaxPathLength {
+			truncated = true
+			expansions++
+			continue
+		}
+		layer := int32(0)
+		if layers > 1 {
+			layer = steps
+		}
+
+		cell, heading := current/stride, current/layers%states
+		currentX, currentY := int(cell)%sg.SizeX, int(cell)/sg.SizeX
+		for d, direction := range sg.config.neighbors {
+			nextX := currentX + direction[0]
+			nextY := currentY + direction[1]
+			if nextX < 0 || nextX >= sg.SizeX || nextY < 0 || nextY >= sg.SizeY {
+				continue
+			}
+
+			nextCell := int32(sg.index(nextX, nextY))
+			if sg.blocked.get(int(nextCell)) {
+				continue
+			}
+			if allowed != nil && !allowed[sg.Nodes[nextX][nextY].label] {
+				continue
+			}
+			if !sg.edgeAllowed(int(cell), direction[0], direction[1], opts.Profile) {
+				continue
+			}
+			if prune && !sg.towardGoal(int(cell), d, end) {
+				continue
+			}
+
+			newCost := s.costs[current] + sg.Nodes[nextX][nextY].weight
+			if clearance != nil {
+				newCost += clearance[nextCell]
+			}
+			if opts.Layers != nil {
+				newCost += layerCost(opts.Layers, nextX, nextY)
+			}
+			next := nextCell * states
+			if states > 1 {
+				next += int32(d)
+				if heading != undirected && heading != int32(d) {
+					newCost += opts.TurnPenalty
+				}
+			}
+			next = next*layers + layer
+			if s.dominated(next, newCost) {
+				continue
+			}
+
+			s.visit(next, newCost, current, steps)
+			h := sg.estimate(landmarks, nextCell, endIndex, nextX-end.X, nextY-end.Y)
+			priority := newCost + h + sg.tieBreak(nextX, nextY, start, end, opts)
+			s.open.Push(next, priority)
+			if opts.AllowPartial {
+				closest = closest.offer(next, h, newCost)
+			}
+
+			// the legacy search stops as soon as the goal is queued; a turn
+			// penalty makes that first route too likely to be a bad one, so
+			// directional searches wait until the goal is popped
+			if nextCell == endIndex && stride == 1 {
+				endState = next
+				break HeapLoop
+			}
+		}
+
+		for _, p := range sg.portals[int(cell)] {
+			next := (int32(p.to)*states+undirected)*layers + layer
+			if sg.blocked.get(p.to) {
+				continue
+			}
+			if allowed != nil && !allowed[sg.Nodes[p.to%sg.SizeX][p.to/sg.SizeX].label] {
+				continue
+			}
+
+			newCost := s.costs[current] + p.cost + sg.Nodes[p.to%sg.SizeX][p.to/sg.SizeX].weight
+			if clearance != nil {
+				newCost += clearance[p.to]
+			}
+			if opts.Layers != nil {
+				newCost += layerCost(opts.Layers, p.to%sg.SizeX, p.to/sg.SizeX)
+			}
+			if s.dominated(next, newCost) {
+				continue
+			}
+
+			s.visit(next, newCost, current, steps)
+			toX, toY := p.to%sg.SizeX, p.to/sg.SizeX
+			h := sg.estimate(landmarks, int32(p.to), endIndex, toX-end.X, toY-end.Y)
+			priority := newCost + h + sg.tieBreak(toX, toY, start, end, opts)
+			s.open.Push(next, priority)
+			if opts.AllowPartial {
+				closest = closest.offer(next, h, newCost)
+			}
+
+			if int32(p.to) == endIndex && stride == 1 {
+				endState = next
+				break HeapLoop
+			}
+		}
+		expansions++
+	}
+
+	switch {
+	case endState >= 0:
+	case opts.AllowPartial:
+		endState = closest.state
+		s.partial = true
+	case limit != nil:
+		return 0, limit
+	case truncated:
+		return 0, ErrMaxPathLengthExceeded
+	default:
+		return 0, ErrPathNotFound
+	}
+
+	for current := endState; current != startState; current = s.cameFrom[current] {
+		s.cells = append(s.cells, current/stride)
+		s.spent = append(s.spent, s.costs[current])
+	}
+	s.cells = append(s.cells, startIndex)
+	s.spent = append(s.spent, 0)
+
+	return s.costs[endState], nil
+}
+
+// closestState is the fallback goal for partial paths: the fewest estimated
+// moves from the real goal, then the cheapest to reach
+type closestState struct {
+	state int32
+	h     float64
+	cost  float64
+}
+
+func (c closestState) offer(state int32, h, cost float64) closestState {
+	if h < c.h || (h == c.h && cost < c.cost) {
+		return closestState{state: state, h: h, cost: cost}
+	}
+	return c
+}
@@ -0,0 +1,136 @@
+package lattice_test
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/mosaic"
+)
+
+func Test_searcher_WeightedSearch(t *testing.T) {
+	type setup struct {
+		builder Builder
+	}
+	tests := []struct {
+		name  string
+		setup setup
+	}{
+		{
+			name: "maze",
+			setup: setup{
+				builder: Builder{
+					x:    9,
+					y:    9,
+					size: 32,
+					layout: "" +
+						"000000000" +
+						"0xxxxxxx0" +
+						"0x00000x0" +
+						"0x0x0x0x0" +
+						"0x0x0x0x0" +
+						"0x0xxx0x0" +
+						"0x00000x0" +
+						"0x0xxx0x0" +
+						"000000000",
+				},
+			},
+		},
+		{
+			name: "weighted",
+			setup: setup{
+				builder: Builder{
+					x:    9,
+					y:    9,
+					size: 32,
+					layout: "" +
+						"100000000" +
+						"011111110" +
+						"010000010" +
+						"010101010" +
+						"010101010" +
+						"010111010" +
+						"010000010" +
+						"011111010" +
+						"000000000",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg := lattice.NewSpatialGrid[int](
+				tt.setup.builder.x,
+				tt.setup.builder.y,
+				float64(tt.setup.builder.size),
+			)
+			setup_grid(sg, tt.setup.builder)
+			searcher := sg.NewSearcher()
+			size := float64(tt.setup.builder.size)
+			point := func() mosaic.Vector {
+				return mosaic.NewVector(
+					rand.Float64()*size*float64(tt.setup.builder.x),
+					rand.Float64()*size*float64(tt.setup.builder.y),
+				)
+			}
+
+			for i := 0; i < 64; i++ {
+				start, end := point(), point()
+				want, wantErr := sg.WeightedSearch(start, end, 64)
+				got, err := searcher.WeightedSearch(start, end, 64)
+				if err != wantErr {
+					t.Error(fmt.Errorf("searcher.WeightedSearch() want error: %+v, got error: %+v\n", wantErr, err))
+				}
+				if !slices.Equal(want, got) {
+					t.Error(fmt.Errorf("searcher.WeightedSearch() want: %+v, got: %+v\n", want, got))
+				}
+			}
+
+			start, end := mosaic.NewVector(16, 16), mosaic.NewVector(144, 144)
+			allocs := testing.AllocsPerRun(16, func() {
+				searcher.WeightedSearch(start, end, 64)
+			})
+			if allocs != 0 {
+				t.Error(fmt.Errorf("searcher.WeightedSearch() want: 0 allocations, got: %+v\n", allocs))
+			}
+		})
+	}
+}
+
+func BenchmarkSearcherWeightedSearch(b *testing.B) {
+	sg := lattice.NewSpatialGrid[int](GridX, GridY, GridSize)
+	entities := []mosaic.Vector{}
+	for i := 0; i < ContainerSize; i++ {
+		x0 := float64(rand.Intn(GridX) * rand.Intn(int(GridSize)))
+		y0 := float64(rand.Intn(GridY) * rand.Intn(int(GridSize)))
+		sizeX := GridSize * rand.Float64()
+		sizeY := GridSize * rand.Float64()
+		entities = append(
+			entities,
+			mosaic.NewVector(
+				float64(rand.Intn(GridX)*rand.Intn(int(GridSize))),
+				float64(rand.Intn(GridY)*rand.Intn(int(GridSize))),
+			),
+		)
+
+		sg.Insert(
+			lattice.Item[int]{
+				rand.Int(),
+				mosaic.NewRectangle(mosaic.Vector{X: x0, Y: y0}, sizeX, sizeY),
+				rand.Float64(),
+			},
+		)
+	}
+	searcher := sg.NewSearcher()
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		searcher.WeightedSearch(
+			entities[n%len(entities)],
+			entities[(n+1)%len(entities)],
+			10,
+		)
+	}
+}
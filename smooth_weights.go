@@ -0,0 +1,62 @@
+package lattice
+
+import "math"
+
+// SmoothWeights returns a Gaussian blurred copy of the weights, indexed like
+// Nodes so it can serve as a WeightLayer or a heatmap. The kernel reaches
+// radius cells each way. Blocked cells keep an infinite weight and stay out
+// of their neighbors' averages, as do cells past the edge of the grid.
+func (sg *SpatialGrid[T]) SmoothWeights(radius int, sigma float64) ([][]float64, error) {
+	if radius < 0 || sigma <= 0 || math.IsInf(sigma, 0) || math.IsNaN(sigma) {
+		return nil, ErrInvalidOption
+	}
+
+	sg = sg.rlock()
+	defer sg.runlock()
+
+	kernel := make([]float64, 2*radius+1)
+	for i := range kernel {
+		d := float64(i - radius)
+		kernel[i] = math.Exp(-d * d / (2 * sigma * sigma))
+	}
+
+	// the blur is separable, so the masked sums run along x and then along y
+	cells := sg.SizeX * sg.SizeY
+	sums, norms := make([]float64, cells), make([]float64, cells)
+	for y := 0; y < sg.SizeY; y++ {
+		for x := 0; x < sg.SizeX; x++ {
+			for k, factor := range kernel {
+				nx := x + k - radius
+				if nx < 0 || nx >= sg.SizeX || sg.blocked.get(sg.index(nx, y)) {
+					continue
+				}
+				sums[sg.index(x, y)] += factor * sg.Nodes[nx][y].weight
+				norms[sg.index(x, y)] += factor
+			}
+		}
+	}
+
+	smoothed := make([][]float64, sg.SizeX)
+	for x := range smoothed {
+		smoothed[x] = make([]float64, sg.SizeY)
+		for y := range smoothed[x] {
+			if sg.blocked.get(sg.index(x, y)) {
+				smoothed[x][y] = math.Inf(1)
+				continue
+			}
+
+			sum, norm := 0.0, 0.0
+			for k, factor := range kernel {
+				ny := y + k - radius
+				if ny < 0 || ny >= sg.SizeY {
+					continue
+				}
+				sum += factor * sums[sg.index(x, ny)]
+				norm += factor * norms[sg.index(x, ny)]
+			}
+			smoothed[x][y] = sum / norm
+		}
+	}
+
+	return smoothed, nil
+}
@@ -0,0 +1,88 @@
+package lattice_test
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+)
+
+func Test_spatial_grid_SmoothWeights(t *testing.T) {
+	a := math.Exp(-0.5)
+	inf := math.Inf(1)
+	tests := []struct {
+		name    string
+		weights [][]float64
+		radius  int
+		sigma   float64
+		want    [][]float64
+		err     error
+	}{
+		{
+			name:    "no radius",
+			weights: [][]float64{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}},
+			radius:  0,
+			sigma:   1,
+			want:    [][]float64{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}},
+		},
+		{
+			name:    "uniform stays uniform",
+			weights: [][]float64{{2, 2, 2}, {2, 2, 2}, {2, 2, 2}},
+			radius:  2,
+			sigma:   1,
+			want:    [][]float64{{2, 2, 2}, {2, 2, 2}, {2, 2, 2}},
+		},
+		{
+			name:    "spike",
+			weights: [][]float64{{0, 0, 0}, {0, 9, 0}, {0, 0, 0}},
+			radius:  1,
+			sigma:   1,
+			want: [][]float64{
+				{9 * a * a / ((1 + a) * (1 + a)), 9 * a / ((1 + a) * (1 + 2*a)), 9 * a * a / ((1 + a) * (1 + a))},
+				{9 * a / ((1 + a) * (1 + 2*a)), 9 / ((1 + 2*a) * (1 + 2*a)), 9 * a / ((1 + a) * (1 + 2*a))},
+				{9 * a * a / ((1 + a) * (1 + a)), 9 * a / ((1 + a) * (1 + 2*a)), 9 * a * a / ((1 + a) * (1 + a))},
+			},
+		},
+		{
+			name:    "walls stay out",
+			weights: [][]float64{{3, 3, 3}, {inf, inf, inf}, {3, 3, 3}},
+			radius:  1,
+			sigma:   1,
+			want:    [][]float64{{3, 3, 3}, {inf, inf, inf}, {3, 3, 3}},
+		},
+		{
+			name:    "invalid sigma",
+			weights: [][]float64{{0}},
+			radius:  1,
+			sigma:   0,
+			err:     lattice.ErrInvalidOption,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg := lattice.NewSpatialGrid[int](len(tt.weights), len(tt.weights[0]), 8)
+			for x := range tt.weights {
+				for y, w := range tt.weights[x] {
+					sg.SetTerrain(x, y, w)
+				}
+			}
+
+			got, err := sg.SmoothWeights(tt.radius, tt.sigma)
+			if !errors.Is(err, tt.err) {
+				t.Fatal(fmt.Errorf("spatialGrid.SmoothWeights() want: %+v, got: %+v\n", tt.err, err))
+			}
+			if len(got) != len(tt.want) {
+				t.Fatal(fmt.Errorf("spatialGrid.SmoothWeights() want: %+v, got: %+v\n", tt.want, got))
+			}
+			for x := range tt.want {
+				for y := range tt.want[x] {
+					if tt.want[x][y] != got[x][y] && math.Abs(tt.want[x][y]-got[x][y]) > 1e-12 {
+						t.Error(fmt.Errorf("spatialGrid.SmoothWeights() want: %+v, got: %+v\n", tt.want, got))
+					}
+				}
+			}
+		})
+	}
+}
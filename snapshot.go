@@ -0,0 +1,43 @@
+package lattice
+
+import "slices"
+
+// clone deep copies everything a read can observe. Cell data is copied by
+// reference.
+func (sg *SpatialGrid[T]) clone() *SpatialGrid[T] {
+	nodes := make([][]spatialGridNode[T], len(sg.Nodes))
+	for x := range sg.Nodes {
+		nodes[x] = make([]spatialGridNode[T], len(sg.Nodes[x]))
+		for y, node := range sg.Nodes[x] {
+			node.Items = slices.Clone(node.Items)
+			node.packed = node.packed.clone()
+			node.history = node.history.clone()
+			nodes[x][y] = node
+		}
+	}
+
+	cfg := sg.config
+	cfg.locking = lockingSnapshot
+
+	return &SpatialGrid[T]{
+		Nodes:      nodes,
+		SizeX:      sg.SizeX,
+		SizeY:      sg.SizeY,
+		ChunkSize:  sg.ChunkSize,
+		origin:     sg.origin,
+		overflow:   slices.Clone(sg.overflow),
+		itemCount:  sg.itemCount,
+		scentDecay: sg.scentDecay,
+		heatCool:   sg.heatCool,
+		heatCurve:  sg.heatCurve,
+		blocked:    sg.blocked.clone(),
+		blockedAt:  sg.blockedAt,
+		portals:    sg.clonePortals(),
+		edgeRules:  sg.cloneEdgeRules(),
+		writes:     sg.writes,
+		shape:      sg.shape,
+		goalBounds: sg.goalBounds,
+		stats:      sg.stats,
+		config:     cfg,
+	}
+}
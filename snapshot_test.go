@@ -0,0 +1,56 @@
+package lattice_test
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/mosaic"
+)
+
+func Test_spatial_grid_WithReadOptimized(t *testing.T) {
+	sg, err := lattice.NewSpatialGridOpts[int](4, 4, 8, lattice.WithReadOptimized())
+	if err != nil {
+		t.Fatal(err)
+	}
+	query := mosaic.NewRectangle(mosaic.Vector{X: 16, Y: 16}, 32, 32)
+
+	got := sg.FindNear(query)
+	if len(got) != 0 {
+		t.Error(fmt.Errorf("spatialGrid.FindNear() want: [], got: %+v\n", got))
+	}
+
+	sg.Insert(lattice.Item[int]{1, mosaic.NewRectangle(mosaic.Vector{X: 4, Y: 4}, 2, 2), 1.0})
+	sg.Insert(lattice.Item[int]{2, mosaic.NewRectangle(mosaic.Vector{X: 12, Y: 4}, 2, 2), 1.0})
+	got = sg.FindNear(query)
+	slices.Sort(got)
+	if !slices.Equal([]int{1, 2}, got) {
+		t.Error(fmt.Errorf("spatialGrid.FindNear() want: %+v, got: %+v\n", []int{1, 2}, got))
+	}
+	if weight := sg.GetLocationWeight(0, 0); weight != 4 {
+		t.Error(fmt.Errorf("spatialGrid.GetLocationWeight() want: %+v, got: %+v\n", 4, weight))
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 64; j++ {
+				sg.FindNear(query)
+				sg.WeightedSearch(mosaic.Vector{X: 4, Y: 4}, mosaic.Vector{X: 28, Y: 28}, 64)
+			}
+		}()
+	}
+	for i := 0; i < 64; i++ {
+		bounds := mosaic.NewRectangle(mosaic.Vector{X: float64(i%32) + 0.5, Y: 20}, 1, 1)
+		sg.Insert(lattice.Item[int]{100 + i, bounds, 1.0})
+	}
+	wg.Wait()
+
+	if sg.Size() != 66 || len(sg.FindNear(query)) != 66 {
+		t.Error(fmt.Errorf("spatialGrid.FindNear() want: %+v items, got: %+v\n", 66, len(sg.FindNear(query))))
+	}
+}
@@ -4,20 +4,44 @@ import (
 	"errors"
 	"math"
 	"sync"
+	"sync/atomic"
+	"time"
 
-	"github.com/maladroitthief/caravan"
 	"github.com/maladroitthief/mosaic"
-	"golang.org/x/exp/maps"
 )
 
 type (
 	SpatialGrid[T comparable] struct {
-		Nodes     [][]spatialGridNode[T]
-		nodesMu   sync.RWMutex
-		SizeX     int
-		SizeY     int
-		ChunkSize float64
-		itemCount int
+		Nodes      [][]spatialGridNode[T]
+		nodesMu    sync.RWMutex
+		access     atomic.Int32
+		SizeX      int
+		SizeY      int
+		ChunkSize  float64
+		origin     mosaic.Vector
+		overflow   []spilled[T]
+		itemCount  int
+		scentDecay float64
+		heatCool   float64
+		heatCurve  HeatFalloff
+		blocked    bitset
+		blockedAt  float64
+		portals    map[int][]portal
+		edgeRules  map[edgeKey]EdgeRule
+		writes     uint64
+		shape      uint64
+		goalBounds goalBounds
+		subs       []*subscription[T]
+		nextSub    int
+		notices    []regionNotice[T]
+		expiries   map[T]time.Time
+		metrics    *lockMetrics
+		stats      Stats[T]
+		stamp      uint64
+		hotspots   hotspotIndex
+		searchers  sync.Pool
+		snapshot   atomic.Pointer[SpatialGrid[T]]
+		config     config
 	}
 
 	spatialGridNode[T comparable] struct {
@@ -25,7 +49,32 @@ type (
 		y      int
 		bounds mosaic.Rectangle
 		weight float64
-		Items  []spatialGridNodeItem[T]
+		// terrain is weight owned by the cell itself rather than by an item
+		terrain float64
+		// paint is hand-tuned weight from PaintWeights
+		paint float64
+		// restored is static item weight read by ImportStaticLayers, held
+		// until the cell's static items are inserted again
+		restored float64
+		label    string
+		data     any
+		scent    float64
+		heat     float64
+		Items    []spatialGridNodeItem[T]
+		packed   packedBounds
+		static   int
+		pinned   int
+		history  *cellHistory[T]
+	}
+
+	// partition is the part of a node an item is stored in
+	partition int
+
+	// spilled is an item parked in the overflow list, along with the
+	// partition it was headed for
+	spilled[T comparable] struct {
+		Item[T]
+		partition partition
 	}
 
 	spatialGridNodeItem[T comparable] struct {
@@ -40,6 +89,13 @@ type (
 		Bounds     mosaic.Rectangle
 		Multiplier float64
 	}
+
+	// SearchResult reports the deepest level a search visited and whether it
+	// ran out of cells rather than stopping on a limit or an error
+	SearchResult struct {
+		Depth     int
+		Exhausted bool
+	}
 )
 
 var (
@@ -49,136 +105,269 @@ var (
 )
 
 func NewSpatialGrid[T comparable](x, y int, size float64) *SpatialGrid[T] {
-	nodes := make([][]spatialGridNode[T], x)
-	for iX := range nodes {
-		nodes[iX] = make([]spatialGridNode[T], y)
-		for iY := range y {
-			nodes[iX][iY] = newSpatialGridNode[T](
-				iX,
-				iY,
-				mosaic.NewRectangle(
-					mosaic.NewVector(
-						(float64(iX)*size)+size/2,
-						(float64(iY)*size)+size/2,
-					),
-					size,
-					size,
-				),
-			)
+	sg, err := NewSpatialGridOpts[T](x, y, size)
+	if err != nil {
+		panic(err)
+	}
+
+	return sg
+}
+
+func NewSpatialGridOpts[T comparable](x, y int, size float64, opts ...Option) (*SpatialGrid[T], error) {
+	if x <= 0 || y <= 0 {
+		return nil, ErrInvalidDimensions
+	}
+	if size <= 0 || math.IsInf(size, 0) || math.IsNaN(size) {
+		return nil, ErrInvalidChunkSize
+	}
+
+	cfg := defaultConfig()
+	for _, opt := range opts {
+		err := opt(&cfg)
+		if err != nil {
+			return nil, err
 		}
 	}
 
-	return &SpatialGrid[T]{
+	return newSpatialGrid[T](x, y, size, cfg), nil
+}
+
+func newSpatialGrid[T comparable](x, y int, size float64, cfg config) *SpatialGrid[T] {
+	sg := &SpatialGrid[T]{
 		SizeX:     x,
 		SizeY:     y,
 		ChunkSize: size,
-		Nodes:     nodes,
+		origin:    cfg.origin,
+		config:    cfg,
+		blocked:   newBitset(x * y),
+		blockedAt: math.Inf(1),
 	}
+
+	sg.Nodes = sg.newNodes()
+	if cfg.lockMetrics {
+		sg.metrics = newLockMetrics()
+	}
+
+	if cfg.locking == LockingReadOptimized {
+		sg.snapshot.Store(sg.clone())
+	}
+
+	return sg
+}
+
+func (sg *SpatialGrid[T]) newNodes() [][]spatialGridNode[T] {
+	nodes := make([][]spatialGridNode[T], sg.SizeX)
+	for x := range nodes {
+		nodes[x] = make([]spatialGridNode[T], sg.SizeY)
+		for y := range sg.SizeY {
+			nodes[x][y] = newSpatialGridNode[T](x, y, sg.CellBounds(x, y), sg.config.capacity)
+		}
+	}
+
+	return nodes
 }
 
 func (sg *SpatialGrid[T]) Size() int {
 	return sg.itemCount
 }
 
-func (sg *SpatialGrid[T]) Insert(item Item[T]) {
-	sg.nodesMu.Lock()
-	defer sg.nodesMu.Unlock()
+func (sg *SpatialGrid[T]) Insert(item Item[T]) error {
+	sg.lock()
+	defer sg.unlock()
+
+	err := sg.insert(item)
+	if err == nil {
+		sg.track(item.Value, item.Bounds, true)
+	}
+
+	return err
+}
 
-	sg.insert(item)
+func (sg *SpatialGrid[T]) insert(item Item[T]) error {
+	return sg.insertAs(item, partitionDynamic)
 }
 
-func (sg *SpatialGrid[T]) insert(item Item[T]) {
-	x, y := sg.Location(item.Bounds.Position.X, item.Bounds.Position.Y)
-	sg.Nodes[x][y] = sg.Nodes[x][y].Insert(item.Value, item.Bounds, item.Multiplier)
+// insertAs stores item in the given partition of the node under its center
+func (sg *SpatialGrid[T]) insertAs(item Item[T], part partition) error {
+	sg.growToFit(item.Bounds.Position)
+	sg.diagnose(item)
+	return sg.place(item, part)
+}
+
+// place is insertAs for items the grid already held, which need neither room
+// to grow nor another count in Stats
+func (sg *SpatialGrid[T]) place(item Item[T], part partition) error {
+	x, y, err := sg.cell(item.Bounds.Position.X, item.Bounds.Position.Y)
+	if err != nil {
+		return err
+	}
+	store, err := sg.admit(x, y, item, part)
+	if !store {
+		return err
+	}
+
+	node := sg.Nodes[x][y]
+	switch part {
+	case partitionPinned:
+		node = node.InsertPinned(item.Value, item.Bounds, item.Multiplier, sg.config.weigh)
+	case partitionStatic:
+		node = node.InsertStatic(item.Value, item.Bounds, item.Multiplier, sg.config.weigh)
+	default:
+		node = node.Insert(item.Value, item.Bounds, item.Multiplier, sg.config.weigh)
+	}
+	sg.Nodes[x][y] = sg.record(node, CellInserted, item.Value, item.Bounds)
+	sg.updateBlocked(x, y)
 	sg.itemCount++
+
+	return nil
 }
 
-func (sg *SpatialGrid[T]) Update(item Item[T], oldBounds mosaic.Rectangle) {
-	sg.nodesMu.Lock()
-	defer sg.nodesMu.Unlock()
+// Update moves the item stored under oldBounds to item.Bounds. A full cell
+// refuses the move with ErrCellFull and the item stays where it was.
+func (sg *SpatialGrid[T]) Update(item Item[T], oldBounds mosaic.Rectangle) error {
+	sg.lock()
+	defer sg.unlock()
 
-	sg.delete(item.Value, oldBounds)
-	sg.insert(item)
+	sg.growToFit(item.Bounds.Position)
+	x, y, err := sg.cell(item.Bounds.Position.X, item.Bounds.Position.Y)
+	if err != nil {
+		return err
+	}
+	// refuse before the delete, or a rejected move loses the item
+	if !sg.wouldAdmit(x, y, item) {
+		return ErrCellFull
+	}
+
+	// an item that was never stored, or was evicted, is simply inserted
+	err = sg.delete(item.Value, oldBounds)
+	if err != nil && !errors.Is(err, ErrItemNotFound) {
+		return err
+	}
+
+	err = sg.insert(item)
+	sg.track(item.Value, item.Bounds, err == nil)
+
+	return err
 }
 
-func (sg *SpatialGrid[T]) Delete(val T, bounds mosaic.Rectangle) {
-	sg.nodesMu.Lock()
-	defer sg.nodesMu.Unlock()
+// Delete removes val from the cell under bounds, reporting ErrItemNotFound
+// when it is not stored there, e.g. after an OverflowEvict
+func (sg *SpatialGrid[T]) Delete(val T, bounds mosaic.Rectangle) error {
+	sg.lock()
+	defer sg.unlock()
 
-	sg.delete(val, bounds)
+	err := sg.delete(val, bounds)
+	if err == nil {
+		delete(sg.expiries, val)
+		sg.track(val, bounds, false)
+	}
+
+	return err
 }
 
-func (sg *SpatialGrid[T]) delete(val T, bounds mosaic.Rectangle) {
-	x, y := sg.Location(bounds.Position.X, bounds.Position.Y)
-	sg.Nodes[x][y] = sg.Nodes[x][y].Delete(val)
+func (sg *SpatialGrid[T]) delete(val T, bounds mosaic.Rectangle) error {
+	if len(sg.overflow) > 0 && sg.unspill(val) {
+		return nil
+	}
+
+	x, y, err := sg.cell(bounds.Position.X, bounds.Position.Y)
+	if err != nil {
+		return err
+	}
 
-	sg.itemCount--
+	node, removed := sg.Nodes[x][y].Delete(val)
+	if removed == 0 {
+		return ErrItemNotFound
+	}
+	for i := 0; i < removed; i++ {
+		node = sg.record(node, CellDeleted, val, bounds)
+	}
+	sg.Nodes[x][y] = node
+	sg.updateBlocked(x, y)
+	sg.autoCompact(x, y)
+	sg.itemCount -= removed
+
+	return nil
 }
 
-func (sg *SpatialGrid[T]) Reset(items []Item[T]) {
-	sg.nodesMu.Lock()
-	defer sg.nodesMu.Unlock()
+// Reset inserts every item it can and reports ErrOutOfBounds if any were
+// rejected by strict bounds. Pinned items are kept.
+func (sg *SpatialGrid[T]) Reset(items []Item[T]) error {
+	sg.lock()
+	defer sg.unlock()
 
 	sg.drop()
+	clear(sg.expiries)
+
+	var err error
 	for i := 0; i < len(items); i++ {
-		sg.insert(items[i])
+		insertErr := sg.insert(items[i])
+		if insertErr != nil {
+			err = insertErr
+		}
 	}
+	sg.resync()
+
+	return err
 }
 
 func (sg *SpatialGrid[T]) FindNear(bounds mosaic.Rectangle) []T {
-	sg.nodesMu.RLock()
-	defer sg.nodesMu.RUnlock()
+	sg = sg.rlock()
+	defer sg.runlock()
+
+	if sg.config.precise {
+		return sg.findIntersecting(bounds)
+	}
 
-	set := map[T]struct{}{}
-	minPoint, maxPoint := bounds.MinPoint(), bounds.MaxPoint()
-	xMinIndex, yMinIndex := sg.Location(minPoint.X, minPoint.Y)
-	xMaxIndex, yMaxIndex := sg.Location(maxPoint.X, maxPoint.Y)
+	set := sg.newValueSet()
+	xMinIndex, yMinIndex, xMaxIndex, yMaxIndex, ok := sg.cellRange(bounds)
+	if !ok {
+		return set.values()
+	}
 
 	for x, xn := xMinIndex, xMaxIndex; x <= xn; x++ {
 		for y, yn := yMinIndex, yMaxIndex; y <= yn; y++ {
 			for _, item := range sg.Nodes[x][y].Items {
-				set[item.value] = struct{}{}
+				set.add(item.value)
 			}
 		}
 	}
 
-	return maps.Keys(set)
+	return set.values()
 }
 
+// Drop removes every item except the pinned ones
 func (sg *SpatialGrid[T]) Drop() {
-	sg.nodesMu.Lock()
-	defer sg.nodesMu.Unlock()
+	sg.lock()
+	defer sg.unlock()
 
 	sg.drop()
+	clear(sg.expiries)
+	sg.resync()
 }
 
+// drop clears every cell down to its pinned items
 func (sg *SpatialGrid[T]) drop() {
-	nodes := make([][]spatialGridNode[T], sg.SizeX)
-	for iX := range nodes {
-		nodes[iX] = make([]spatialGridNode[T], sg.SizeY)
-		for iY := range sg.SizeY {
-			nodes[iX][iY] = newSpatialGridNode[T](
-				iX,
-				iY,
-				mosaic.NewRectangle(
-					mosaic.NewVector(
-						(float64(iX)*sg.ChunkSize)+sg.ChunkSize/2,
-						(float64(iY)*sg.ChunkSize)+sg.ChunkSize/2,
-					),
-					sg.ChunkSize,
-					sg.ChunkSize,
-				),
-			)
+	sg.itemCount = 0
+	for iX := range sg.Nodes {
+		for iY := range sg.Nodes[iX] {
+			node := sg.recordRemoved(sg.Nodes[iX][iY], sg.Nodes[iX][iY].pinned)
+			if node.pinned > 0 {
+				sg.Nodes[iX][iY] = node.keepPinned()
+				sg.itemCount += node.pinned
+			} else {
+				sg.Nodes[iX][iY] = node.clear(sg.config.capacity)
+			}
+			sg.updateBlocked(iX, iY)
 		}
 	}
 
-	sg.Nodes = nodes
-	sg.itemCount = 0
+	sg.overflow = nil
 }
 
 func (sg *SpatialGrid[T]) Location(x, y float64) (xIndex, yIndex int) {
-	xIndex = int((x / sg.ChunkSize))
-	yIndex = int((y / sg.ChunkSize))
+	xIndex = int(((x - sg.origin.X) / sg.ChunkSize))
+	yIndex = int(((y - sg.origin.Y) / sg.ChunkSize))
 
 	if xIndex < 0 {
 		xIndex = 0
@@ -196,39 +385,52 @@ func (sg *SpatialGrid[T]) Location(x, y float64) (xIndex, yIndex int) {
 }
 
 func (sg *SpatialGrid[T]) GetItemsAtLocation(x, y int) []T {
-	sg.nodesMu.RLock()
-	defer sg.nodesMu.RUnlock()
+	sg = sg.rlock()
+	defer sg.runlock()
 
 	return sg.Nodes[x][y].Values()
 }
 
 func (sg *SpatialGrid[T]) GetLocationWeight(x, y int) float64 {
-	sg.nodesMu.RLock()
-	defer sg.nodesMu.RUnlock()
+	sg = sg.rlock()
+	defer sg.runlock()
 
 	return sg.Nodes[x][y].weight
 }
 
-func (sg *SpatialGrid[T]) NodeAtPosition(x, y float64) spatialGridNode[T] {
+func (sg *SpatialGrid[T]) NodeAtPosition(x, y float64) NodeView[T] {
+	sg = sg.rlock()
+	defer sg.runlock()
+
 	xIndex, yIndex := sg.Location(x, y)
-	return sg.Node(xIndex, yIndex)
+	return sg.Nodes[xIndex][yIndex].view()
 }
 
-func (sg *SpatialGrid[T]) Node(x, y int) spatialGridNode[T] {
-	return spatialGridNode[T]{
-		Items:  sg.Nodes[x][y].Items,
-		x:      sg.Nodes[x][y].x,
-		y:      sg.Nodes[x][y].y,
-		bounds: sg.Nodes[x][y].bounds,
-		weight: sg.Nodes[x][y].weight,
+func (sg *SpatialGrid[T]) Node(x, y int) NodeView[T] {
+	sg = sg.rlock()
+	defer sg.runlock()
+
+	return sg.Nodes[x][y].view()
+}
+
+func (sg *SpatialGrid[T]) Edges(node NodeView[T]) []NodeView[T] {
+	sg = sg.rlock()
+	defer sg.runlock()
+
+	edges := sg.edges(node.x, node.y)
+	views := make([]NodeView[T], len(edges))
+	for i, edge := range edges {
+		views[i] = edge.view()
 	}
+
+	return views
 }
 
-func (sg *SpatialGrid[T]) Edges(sgn spatialGridNode[T]) []spatialGridNode[T] {
+func (sg *SpatialGrid[T]) edges(x, y int) []spatialGridNode[T] {
 	edges := []spatialGridNode[T]{}
-	for _, direction := range directions {
-		nextX := sgn.x + direction[0]
-		nextY := sgn.y + direction[1]
+	for _, direction := range sg.config.neighbors {
+		nextX := x + direction[0]
+		nextY := y + direction[1]
 		if nextX < 0 || nextX >= sg.SizeX {
 			continue
 		}
@@ -236,7 +438,10 @@ func (sg *SpatialGrid[T]) Edges(sgn spatialGridNode[T]) []spatialGridNode[T] {
 			continue
 		}
 
-		edges = append(edges, sg.Node(nextX, nextY))
+		edges = append(edges, sg.Nodes[nextX][nextY])
+	}
+	for _, p := range sg.portals[sg.index(x, y)] {
+		edges = append(edges, sg.Nodes[p.to%sg.SizeX][p.to/sg.SizeX])
 	}
 
 	return edges
@@ -247,154 +452,120 @@ func (sg *SpatialGrid[T]) Search(
 	y float64,
 	maxDepth int,
 	process func([]T) error,
-) error {
-	sg.nodesMu.RLock()
-	defer sg.nodesMu.RUnlock()
+) (SearchResult, error) {
+	sg = sg.rlock()
+	defer sg.runlock()
 
-	start := sg.NodeAtPosition(x, y)
+	startX, startY, err := sg.cell(x, y)
+	if err != nil {
+		return SearchResult{}, err
+	}
 
-	type index struct{ x, y int }
-	visited := map[index]struct{}{}
+	visit := func(_ int, sgn spatialGridNode[T]) error {
+		return process(sgn.Values())
+	}
 
-	queue := caravan.NewQueue[spatialGridNode[T]]()
-	queue.Enqueue(start)
+	return sg.search([]spatialGridNode[T]{sg.Nodes[startX][startY]}, maxDepth, visit, nil, nil)
+}
 
+// SearchFrom runs Search with every seed cell at depth zero
+func (sg *SpatialGrid[T]) SearchFrom(
+	seeds []mosaic.Vector,
+	maxDepth int,
+	process func([]T) error,
+) (SearchResult, error) {
+	sg = sg.rlock()
+	defer sg.runlock()
+
+	visit := func(_ int, sgn spatialGridNode[T]) error {
+		return process(sgn.Values())
+	}
+
+	starts := make([]spatialGridNode[T], len(seeds))
+	for i, seed := range seeds {
+		x, y, err := sg.cell(seed.X, seed.Y)
+		if err != nil {
+			return SearchResult{}, err
+		}
+		starts[i] = sg.Nodes[x][y]
+	}
+
+	return sg.search(starts, maxDepth, visit, nil, nil)
+}
+
+func (sg *SpatialGrid[T]) search(
+	starts []spatialGridNode[T],
+	maxDepth int,
+	visit func(depth int, sgn spatialGridNode[T]) error,
+	levelDone func(depth int) error,
+	passable func(sgn spatialGridNode[T]) bool,
+) (SearchResult, error) {
+	visited := make([]bool, sg.SizeX*sg.SizeY)
+
+	queue := ringQueue[spatialGridNode[T]]{}
+	for _, start := range starts {
+		queue.Enqueue(start)
+	}
+
+	result := SearchResult{}
 	currentDepth := 0
 	for queue.Len() > 0 {
 		if currentDepth > maxDepth {
-			return ErrMaxDepthReached
+			return result, ErrMaxDepthReached
 		}
 
 		nodesAtDepth := queue.Len()
 		for i := 0; i < nodesAtDepth; i++ {
-			currentNode, err := queue.Dequeue()
-			if err != nil {
-				return err
-			}
-			_, ok := visited[index{currentNode.x, currentNode.y}]
-			if ok {
+			currentNode := queue.Dequeue()
+			if visited[sg.index(currentNode.x, currentNode.y)] {
 				continue
 			}
-			visited[index{currentNode.x, currentNode.y}] = struct{}{}
+			visited[sg.index(currentNode.x, currentNode.y)] = true
 
-			err = process(currentNode.Values())
+			result.Depth = currentDepth
+			err := visit(currentDepth, currentNode)
 			if err != nil {
-				return err
+				return result, err
 			}
 
-			edges := sg.Edges(currentNode)
+			edges := sg.edges(currentNode.x, currentNode.y)
 			if len(edges) <= 0 {
 				continue
 			}
 
 			for _, edge := range edges {
+				if passable != nil && !passable(edge) {
+					continue
+				}
 				queue.Enqueue(edge)
 			}
 		}
-		currentDepth++
-	}
-
-	return nil
-}
-
-func (sg *SpatialGrid[T]) WeightedSearch(start, end mosaic.Vector, maxDepth int) ([]mosaic.Vector, error) {
-	sg.nodesMu.RLock()
-	defer sg.nodesMu.RUnlock()
-
-	heuristic := func(from, to spatialGridNode[T]) float64 {
-		return math.Abs(float64(from.x-to.x)) + math.Abs(float64(from.y-to.y))
-	}
-
-	type index struct {
-		X int
-		Y int
-	}
-
-	startNode := sg.NodeAtPosition(start.X, start.Y)
-	endNode := sg.NodeAtPosition(end.X, end.Y)
-
-	cameFrom := map[index]spatialGridNode[T]{}
-	cameFrom[index{startNode.x, startNode.y}] = startNode
-	costs := map[index]float64{}
-	costs[index{startNode.x, startNode.y}] = 0
-
-	pq := caravan.NewPQ[spatialGridNode[T]](true)
-	pq.Enqueue(startNode, 0)
-
-	currentDepth := 0
-PQLoop:
-	for pq.Len() > 0 {
-		if currentDepth > maxDepth {
-			return []mosaic.Vector{}, ErrMaxDepthReached
-		}
-
-		currentNode, err := pq.Dequeue()
-		if err != nil {
-			return []mosaic.Vector{}, err
-		}
-
-		if currentNode.x == endNode.x && currentNode.y == endNode.y {
-			break PQLoop
-		}
-
-		edges := sg.Edges(currentNode)
-		for i := 0; i < len(edges); i++ {
-			newCost := costs[index{currentNode.x, currentNode.y}] + edges[i].weight
-			if math.IsInf(newCost, 1) {
-				continue
-			}
-
-			edgeCost, ok := costs[index{edges[i].x, edges[i].y}]
-			if ok && newCost >= edgeCost {
-				continue
-			}
 
-			costs[index{edges[i].x, edges[i].y}] = newCost
-			node := spatialGridNode[T]{
-				Items:  edges[i].Items,
-				x:      edges[i].x,
-				y:      edges[i].y,
-				bounds: edges[i].bounds,
-				weight: edges[i].weight,
-			}
-			priority := newCost + heuristic(edges[i], endNode)
-			pq.Enqueue(node, priority)
-			cameFrom[index{edges[i].x, edges[i].y}] = currentNode
-
-			if edges[i].x == endNode.x && edges[i].y == endNode.y {
-				break PQLoop
+		if levelDone != nil {
+			err := levelDone(currentDepth)
+			if err != nil {
+				return result, err
 			}
 		}
 		currentDepth++
 	}
+	result.Exhausted = true
 
-	pathNodes := []spatialGridNode[T]{}
-	currentNode := endNode
-	_, ok := cameFrom[index{endNode.x, endNode.y}]
-	if !ok {
-		return []mosaic.Vector{}, ErrPathNotFound
-	}
-
-	for (index{currentNode.x, currentNode.y} != index{startNode.x, startNode.y}) {
-		pathNodes = append(pathNodes, currentNode)
-		currentNode = cameFrom[index{currentNode.x, currentNode.y}]
-	}
+	return result, nil
+}
 
-	pathNodes = append(pathNodes, startNode)
-	path := make([]mosaic.Vector, len(pathNodes))
-	for i := len(pathNodes) - 1; i >= 0; i-- {
-		path[len(pathNodes)-1-i] = mosaic.NewVector(
-			(float64(pathNodes[i].x)*sg.ChunkSize)+sg.ChunkSize/2,
-			(float64(pathNodes[i].y)*sg.ChunkSize)+sg.ChunkSize/2,
-		)
+func (sg *SpatialGrid[T]) WeightedSearch(start, end mosaic.Vector, maxDepth int) ([]mosaic.Vector, error) {
+	if maxDepth < 0 {
+		return []mosaic.Vector{}, ErrMaxDepthReached
 	}
 
-	return path, nil
+	path, err := sg.FindPath(start, end, PathOptions{MaxExpansions: maxDepth + 1})
+	return path.Waypoints, depthError(err)
 }
 
-func newSpatialGridNode[T comparable](x, y int, bounds mosaic.Rectangle) spatialGridNode[T] {
+func newSpatialGridNode[T comparable](x, y int, bounds mosaic.Rectangle, capacity int) spatialGridNode[T] {
 	return spatialGridNode[T]{
-		Items:  make([]spatialGridNodeItem[T], 0, 512),
+		Items:  make([]spatialGridNodeItem[T], 0, capacity),
 		x:      x,
 		y:      y,
 		bounds: bounds,
@@ -411,31 +582,99 @@ func (sgn spatialGridNode[T]) Values() []T {
 	return values
 }
 
-func (sgn spatialGridNode[T]) Insert(item T, bounds mosaic.Rectangle, multiplier float64) spatialGridNode[T] {
-	weight := sgn.bounds.AreaOfOverlap(bounds) * multiplier
+func (sgn spatialGridNode[T]) clear(capacity int) spatialGridNode[T] {
+	sgn.Items = make([]spatialGridNodeItem[T], 0, capacity)
+	sgn.packed = sgn.packed.reset()
+	sgn.static = 0
+	sgn.pinned = 0
+	sgn.weight = sgn.base()
+
+	return sgn
+}
+
+func (sgn spatialGridNode[T]) Insert(item T, bounds mosaic.Rectangle, multiplier float64, weigh WeightFunc) spatialGridNode[T] {
+	weight := weigh(sgn.bounds, bounds, multiplier)
 
 	sgn.Items = append(
 		sgn.Items,
 		newSpatialGridNodeItem(item, bounds, weight, multiplier),
 	)
+	sgn.packed = sgn.packed.append(bounds)
 	sgn.weight += weight
 
 	return sgn
 }
 
-func (sgn spatialGridNode[T]) Delete(item T) spatialGridNode[T] {
-	for i := 0; i < len(sgn.Items); i++ {
+// Delete removes every copy of item and reports how many there were
+func (sgn spatialGridNode[T]) Delete(item T) (spatialGridNode[T], int) {
+	count := 0
+	// removal swaps another item into i, so only advance past keepers
+	for i := 0; i < len(sgn.Items); {
 		if sgn.Items[i].value != item {
+			i++
 			continue
 		}
-		sgn.weight = sgn.weight - sgn.Items[i].weight
-		sgn.Items[i] = sgn.Items[len(sgn.Items)-1]
-		sgn.Items = sgn.Items[:len(sgn.Items)-1]
+		removed := sgn.Items[i].weight
+		sgn.weight = sgn.weight - removed
+		sgn = sgn.removeAt(i)
+		count++
+
+		// Inf - Inf is NaN, so rebuild the sum instead of subtracting
+		if math.IsInf(removed, 0) {
+			sgn.weight = sgn.itemWeights()
+		}
+	}
+
+	return sgn, count
+}
+
+// removeAt keeps pinned and then static items packed at the front of Items
+func (sgn spatialGridNode[T]) removeAt(i int) spatialGridNode[T] {
+	last := len(sgn.Items) - 1
+	if i < sgn.pinned {
+		sgn.pinned--
+		sgn = sgn.swap(i, sgn.pinned)
+		i = sgn.pinned
 	}
+	if i < sgn.static {
+		sgn.static--
+		sgn = sgn.swap(i, sgn.static)
+		i = sgn.static
+	}
+	sgn = sgn.swap(i, last)
+	sgn.Items = sgn.Items[:last]
+	sgn.packed = sgn.packed.truncate(last)
+
+	return sgn
+}
+
+func (sgn spatialGridNode[T]) swap(i, j int) spatialGridNode[T] {
+	sgn.Items[i], sgn.Items[j] = sgn.Items[j], sgn.Items[i]
+	sgn.packed = sgn.packed.swap(i, j)
 
 	return sgn
 }
 
+// base is the weight a cell has with no items in it. Negative paint can
+// lower it to zero but no further.
+func (sgn spatialGridNode[T]) base() float64 {
+	ground := sgn.terrain + sgn.restored
+	if sgn.paint >= 0 {
+		return ground + sgn.paint
+	}
+
+	return max(ground+sgn.paint, min(ground, 0))
+}
+
+func (sgn spatialGridNode[T]) itemWeights() float64 {
+	weight := sgn.base()
+	for i := 0; i < len(sgn.Items); i++ {
+		weight += sgn.Items[i].weight
+	}
+
+	return weight
+}
+
 func newSpatialGridNodeItem[T comparable](value T, bounds mosaic.Rectangle, weight float64, multiplier float64) spatialGridNodeItem[T] {
 	return spatialGridNodeItem[T]{
 		value:      value,
@@ -1,6 +1,7 @@
 package lattice_test
 
 import (
+	"errors"
 	"fmt"
 	"math"
 	"math/rand"
@@ -262,6 +263,103 @@ func Test_spatial_grid_Search(t *testing.T) {
 	}
 }
 
+func Test_spatial_grid_Search_result(t *testing.T) {
+	errFound := errors.New("found")
+	type want struct {
+		result lattice.SearchResult
+		err    error
+	}
+	tests := []struct {
+		name  string
+		depth int
+		stop  bool
+		want  want
+	}{
+		{
+			name:  "exhausted",
+			depth: 10,
+			want:  want{result: lattice.SearchResult{Depth: 4, Exhausted: true}},
+		},
+		{
+			name:  "depth limit",
+			depth: 2,
+			want:  want{result: lattice.SearchResult{Depth: 2}, err: lattice.ErrMaxDepthReached},
+		},
+		{
+			name:  "stopped by process",
+			depth: 10,
+			stop:  true,
+			want:  want{result: lattice.SearchResult{Depth: 2}, err: errFound},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg := lattice.NewSpatialGrid[int](3, 3, 8)
+			sg.Insert(lattice.Item[int]{1, mosaic.NewRectangle(mosaic.Vector{X: 12, Y: 12}, 2, 2), 1.0})
+
+			process := func(items []int) error {
+				if tt.stop && len(items) > 0 {
+					return errFound
+				}
+				return nil
+			}
+			result, err := sg.Search(4, 4, tt.depth, process)
+			got := want{result: result, err: err}
+			if got != tt.want {
+				t.Error(fmt.Errorf("spatialGrid.Search() want: %+v, got: %+v\n", tt.want, got))
+			}
+		})
+	}
+}
+
+func Test_spatial_grid_SearchFrom(t *testing.T) {
+	tests := []struct {
+		name  string
+		seeds []mosaic.Vector
+		depth int
+		want  []int
+	}{
+		{
+			name:  "two corners",
+			seeds: []mosaic.Vector{{X: 4, Y: 4}, {X: 36, Y: 36}},
+			depth: 1,
+			want:  []int{0, 1, 5, 19, 23, 24},
+		},
+		{
+			name:  "overlapping seeds",
+			seeds: []mosaic.Vector{{X: 4, Y: 4}, {X: 12, Y: 4}},
+			depth: 0,
+			want:  []int{0, 1},
+		},
+		{
+			name:  "no seeds",
+			seeds: []mosaic.Vector{},
+			depth: 4,
+			want:  []int{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg := lattice.NewSpatialGrid[int](5, 5, 8)
+			for i := 0; i < 25; i++ {
+				center := mosaic.Vector{X: float64(i%5)*8 + 4, Y: float64(i/5)*8 + 4}
+				sg.Insert(lattice.Item[int]{i, mosaic.NewRectangle(center, 2, 2), 1.0})
+			}
+
+			got := []int{}
+			process := func(items []int) error {
+				got = append(got, items...)
+				return nil
+			}
+			sg.SearchFrom(tt.seeds, tt.depth, process)
+			slices.Sort(got)
+			if !slices.Equal(tt.want, got) {
+				t.Error(fmt.Errorf("spatialGrid.SearchFrom() want: %+v, got: %+v\n", tt.want, got))
+			}
+		})
+	}
+}
+
 func Test_spatial_grid_WeightedSearch(t *testing.T) {
 	type setup struct {
 		builder Builder
@@ -629,3 +727,80 @@ func BenchmarkSpatialGridWeightedSearch(b *testing.B) {
 		)
 	}
 }
+
+func BenchmarkSpatialGridWeightedSearchLongPath(b *testing.B) {
+	builder := Builder{
+		x:    18,
+		y:    18,
+		size: 32,
+		layout: "" +
+			"0xxxxxx00xxxx0x0x0" +
+			"00000000000000x0x0" +
+			"0xxxxxx00xxxx0xx00" +
+			"00x00000000000x000" +
+			"00x0xx00xxxxxxx000" +
+			"00x000000000000000" +
+			"0xxx00000x0x0x0x00" +
+			"00x0000000x0000000" +
+			"000xx00000xxx0x000" +
+			"00x000x00000x0x0x0" +
+			"0x00xxxx00xxxxxxx0" +
+			"0x00x00x00x00000x0" +
+			"0000x00x00x0x0x0x0" +
+			"0x00000000x0x0x0x0" +
+			"00x0x00x00x0xxx0x0" +
+			"0xx0000x00x00000x0" +
+			"0x00x00x00x0xxx0x0" +
+			"000000000000000000",
+	}
+	sg := lattice.NewSpatialGrid[int](builder.x, builder.y, float64(builder.size))
+	setup_grid(sg, builder)
+	start := mosaic.NewVector(9*32+16, 9*32+16)
+	end := mosaic.NewVector(13*32+16, 13*32+16)
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		sg.WeightedSearch(start, end, 64)
+	}
+}
+
+func BenchmarkSpatialGridWeightedSearchLargeGrid(b *testing.B) {
+	const size = 128
+	rng := rand.New(rand.NewSource(1))
+	sg := lattice.NewSpatialGrid[int](size, size, GridSize)
+	for x := 0; x < size; x++ {
+		for y := 0; y < size; y++ {
+			if rng.Float64() > 0.25 || (x == 0 && y == 0) {
+				continue
+			}
+			sg.Insert(
+				lattice.Item[int]{
+					x*size + y,
+					mosaic.NewRectangle(
+						mosaic.NewVector(float64(x)*GridSize+GridSize/2, float64(y)*GridSize+GridSize/2),
+						GridSize,
+						GridSize,
+					),
+					rng.Float64() * 4,
+				},
+			)
+		}
+	}
+	start := mosaic.NewVector(GridSize/2, GridSize/2)
+	end := mosaic.NewVector(size*GridSize-GridSize/2, size*GridSize-GridSize/2)
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		sg.WeightedSearch(start, end, size*size)
+	}
+}
+
+func BenchmarkSpatialGridSearch(b *testing.B) {
+	sg := lattice.NewSpatialGrid[int](64, 64, GridSize)
+	process := func([]int) error { return nil }
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		sg.Search(32*GridSize, 32*GridSize, 16, process)
+	}
+}
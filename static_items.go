@@ -0,0 +1,92 @@
+package lattice
+
+import "github.com/maladroitthief/mosaic"
+
+// InsertStatic stores the item in its node's static partition, which
+// DropDynamic leaves alone. Update reinserts items as dynamic.
+func (sg *SpatialGrid[T]) InsertStatic(item Item[T]) error {
+	sg.lock()
+	defer sg.unlock()
+
+	err := sg.insertAs(item, partitionStatic)
+	if err == nil {
+		sg.track(item.Value, item.Bounds, true)
+	}
+
+	return err
+}
+
+func (sg *SpatialGrid[T]) DropDynamic() {
+	sg.lock()
+	defer sg.unlock()
+
+	for x := range sg.Nodes {
+		for y := range sg.Nodes[x] {
+			node := sg.recordRemoved(sg.Nodes[x][y], sg.Nodes[x][y].static)
+			sg.itemCount -= len(node.Items) - node.static
+			sg.Nodes[x][y] = node.dropDynamic()
+			sg.updateBlocked(x, y)
+		}
+	}
+	kept := sg.overflow[:0]
+	for _, item := range sg.overflow {
+		if item.partition != partitionDynamic {
+			kept = append(kept, item)
+			continue
+		}
+		sg.itemCount--
+	}
+	clear(sg.overflow[len(kept):])
+	sg.overflow = kept
+	clear(sg.expiries)
+	sg.resync()
+}
+
+func (sg *SpatialGrid[T]) FindNearStatic(bounds mosaic.Rectangle) []T {
+	sg = sg.rlock()
+	defer sg.runlock()
+
+	set := sg.newValueSet()
+	xMinIndex, yMinIndex, xMaxIndex, yMaxIndex, ok := sg.cellRange(bounds)
+	if !ok {
+		return set.values()
+	}
+
+	for x := xMinIndex; x <= xMaxIndex; x++ {
+		for y := yMinIndex; y <= yMaxIndex; y++ {
+			node := sg.Nodes[x][y]
+			for _, item := range node.Items[:node.static] {
+				if sg.config.precise && !bounds.Intersects(item.bounds) {
+					continue
+				}
+				set.add(item.value)
+			}
+		}
+	}
+
+	return set.values()
+}
+
+func (sgn spatialGridNode[T]) InsertStatic(item T, bounds mosaic.Rectangle, multiplier float64, weigh WeightFunc) spatialGridNode[T] {
+	sgn = sgn.Insert(item, bounds, multiplier, weigh)
+	sgn = sgn.swap(sgn.static, len(sgn.Items)-1)
+	sgn.static++
+	if sgn.restored != 0 {
+		sgn.restored = 0
+		sgn.weight = sgn.itemWeights()
+	}
+
+	return sgn
+}
+
+func (sgn spatialGridNode[T]) dropDynamic() spatialGridNode[T] {
+	if sgn.static == len(sgn.Items) {
+		return sgn
+	}
+
+	sgn.Items = sgn.Items[:sgn.static]
+	sgn.packed = sgn.packed.truncate(sgn.static)
+	sgn.weight = sgn.itemWeights()
+
+	return sgn
+}
@@ -0,0 +1,94 @@
+package lattice_test
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/mosaic"
+)
+
+func Test_spatial_grid_DropDynamic(t *testing.T) {
+	type params struct {
+		item   int
+		bounds mosaic.Rectangle
+		static bool
+	}
+	tests := []struct {
+		name    string
+		params  []params
+		deleted []params
+		want    []int
+		static  []int
+	}{
+		{
+			name: "walls survive",
+			params: []params{
+				{item: 1, bounds: mosaic.NewRectangle(mosaic.Vector{X: 4, Y: 4}, 8, 8), static: true},
+				{item: 2, bounds: mosaic.NewRectangle(mosaic.Vector{X: 5, Y: 5}, 1, 1)},
+				{item: 3, bounds: mosaic.NewRectangle(mosaic.Vector{X: 3, Y: 3}, 2, 2), static: true},
+				{item: 4, bounds: mosaic.NewRectangle(mosaic.Vector{X: 12, Y: 4}, 1, 1)},
+			},
+			want:   []int{1, 3},
+			static: []int{1, 3},
+		},
+		{
+			name: "delete keeps partitions apart",
+			params: []params{
+				{item: 1, bounds: mosaic.NewRectangle(mosaic.Vector{X: 4, Y: 4}, 8, 8), static: true},
+				{item: 2, bounds: mosaic.NewRectangle(mosaic.Vector{X: 5, Y: 5}, 1, 1)},
+				{item: 3, bounds: mosaic.NewRectangle(mosaic.Vector{X: 3, Y: 3}, 2, 2), static: true},
+				{item: 4, bounds: mosaic.NewRectangle(mosaic.Vector{X: 6, Y: 6}, 1, 1)},
+			},
+			deleted: []params{
+				{item: 1, bounds: mosaic.NewRectangle(mosaic.Vector{X: 4, Y: 4}, 8, 8)},
+			},
+			want:   []int{3},
+			static: []int{3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg := lattice.NewSpatialGrid[int](4, 4, 8)
+			for _, param := range tt.params {
+				item := lattice.Item[int]{param.item, param.bounds, 1.0}
+				if param.static {
+					sg.InsertStatic(item)
+					continue
+				}
+				sg.Insert(item)
+			}
+			for _, param := range tt.deleted {
+				sg.Delete(param.item, param.bounds)
+			}
+
+			query := mosaic.NewRectangle(mosaic.Vector{X: 8, Y: 8}, 16, 16)
+			static := sg.FindNearStatic(query)
+			slices.Sort(static)
+			if !slices.Equal(tt.static, static) {
+				t.Error(fmt.Errorf("spatialGrid.FindNearStatic() want: %+v, got: %+v\n", tt.static, static))
+			}
+
+			sg.DropDynamic()
+			got := sg.FindNear(query)
+			slices.Sort(got)
+			if !slices.Equal(tt.want, got) {
+				t.Error(fmt.Errorf("spatialGrid.DropDynamic() want: %+v, got: %+v\n", tt.want, got))
+			}
+			if sg.Size() != len(tt.want) {
+				t.Error(fmt.Errorf("spatialGrid.Size() want: %+v, got: %+v\n", len(tt.want), sg.Size()))
+			}
+
+			want := 0.0
+			for _, param := range tt.params {
+				if param.static && slices.Contains(tt.want, param.item) {
+					want += param.bounds.Area()
+				}
+			}
+			if got := sg.GetLocationWeight(0, 0); got != want {
+				t.Error(fmt.Errorf("spatialGrid.GetLocationWeight() want: %+v, got: %+v\n", want, got))
+			}
+		})
+	}
+}
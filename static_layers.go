@@ -0,0 +1,123 @@
+package lattice
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+)
+
+// Static layers are streamed as a header of magic "LTCL", version, SizeX,
+// SizeY and ChunkSize, then for every cell in index order its terrain, its
+// paint and the summed weight of its static items, all little endian.
+const (
+	layersMagic   = "LTCL"
+	layersVersion = 1
+	layersHeader  = 24
+)
+
+var (
+	ErrLayerMismatch = errors.New("saved layers do not match the grid's dimensions")
+)
+
+// ExportStaticLayers writes the authored weights of every cell: terrain,
+// paint and static items. Dynamic items are left out, they are expected to
+// insert themselves again after a restart.
+func (sg *SpatialGrid[T]) ExportStaticLayers(w io.Writer) error {
+	sg = sg.rlock()
+	defer sg.runlock()
+
+	bw := bufio.NewWriter(w)
+	header := make([]byte, layersHeader)
+	copy(header[0:4], layersMagic)
+	binary.LittleEndian.PutUint32(header[4:8], layersVersion)
+	binary.LittleEndian.PutUint32(header[8:12], uint32(sg.SizeX))
+	binary.LittleEndian.PutUint32(header[12:16], uint32(sg.SizeY))
+	binary.LittleEndian.PutUint64(header[16:24], math.Float64bits(sg.ChunkSize))
+	_, err := bw.Write(header)
+	if err != nil {
+		return err
+	}
+
+	cell := make([]byte, 24)
+	for y := 0; y < sg.SizeY; y++ {
+		for x := 0; x < sg.SizeX; x++ {
+			node := sg.Nodes[x][y]
+			static := node.restored
+			for i := 0; i < node.static; i++ {
+				static += node.Items[i].weight
+			}
+			binary.LittleEndian.PutUint64(cell[0:8], math.Float64bits(node.terrain))
+			binary.LittleEndian.PutUint64(cell[8:16], math.Float64bits(node.paint))
+			binary.LittleEndian.PutUint64(cell[16:24], math.Float64bits(static))
+			_, err = bw.Write(cell)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return bw.Flush()
+}
+
+// ImportStaticLayers replaces the terrain and paint of every cell with data
+// from ExportStaticLayers. Static item values are not saved, so their weight
+// is restored on its own and stays until static items are inserted into the
+// cell again; cells that already hold static items skip it. Items already in
+// the grid stay put and the grid is left untouched when the data is invalid.
+func (sg *SpatialGrid[T]) ImportStaticLayers(r io.Reader) error {
+	header := make([]byte, layersHeader)
+	_, err := io.ReadFull(r, header)
+	if err != nil {
+		return truncated(err)
+	}
+	if string(header[0:4]) != layersMagic || binary.LittleEndian.Uint32(header[4:8]) != layersVersion {
+		return ErrInvalidFormat
+	}
+
+	sg.lock()
+	defer sg.unlock()
+
+	sizeX := int(binary.LittleEndian.Uint32(header[8:12]))
+	sizeY := int(binary.LittleEndian.Uint32(header[12:16]))
+	size := math.Float64frombits(binary.LittleEndian.Uint64(header[16:24]))
+	if sizeX != sg.SizeX || sizeY != sg.SizeY || size != sg.ChunkSize {
+		return ErrLayerMismatch
+	}
+
+	data := make([]byte, 24*sizeX*sizeY)
+	_, err = io.ReadFull(r, data)
+	if err != nil {
+		return truncated(err)
+	}
+
+	for i := 0; i < sizeX*sizeY; i++ {
+		cell := data[24*i:]
+		terrain := math.Float64frombits(binary.LittleEndian.Uint64(cell[0:8]))
+		paint := math.Float64frombits(binary.LittleEndian.Uint64(cell[8:16]))
+		static := math.Float64frombits(binary.LittleEndian.Uint64(cell[16:24]))
+
+		x, y := i%sizeX, i/sizeX
+		node := sg.Nodes[x][y]
+		node.terrain = terrain
+		node.paint = paint
+		node.restored = static
+		if node.static > 0 {
+			node.restored = 0
+		}
+		node.weight = node.itemWeights()
+		sg.Nodes[x][y] = node
+		sg.updateBlocked(x, y)
+	}
+
+	return nil
+}
+
+func truncated(err error) error {
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return ErrInvalidFormat
+	}
+
+	return err
+}
@@ -0,0 +1,142 @@
+package lattice_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/mosaic"
+)
+
+func Test_spatial_grid_ImportStaticLayers(t *testing.T) {
+	source := lattice.NewSpatialGrid[int](3, 3, 16)
+	source.SetTerrain(0, 0, 2)
+	source.PaintWeights(mosaic.NewRectangle(mosaic.NewVector(24, 8), 4, 4), 3)
+	source.InsertStatic(lattice.Item[int]{1, mosaic.NewRectangle(mosaic.NewVector(40, 40), 16, 16), math.Inf(1)})
+	source.Insert(lattice.Item[int]{2, mosaic.NewRectangle(mosaic.NewVector(8, 40), 16, 16), 1})
+
+	var saved bytes.Buffer
+	err := source.ExportStaticLayers(&saved)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type want struct {
+		err error
+	}
+	tests := []struct {
+		name string
+		data []byte
+		grid func() *lattice.SpatialGrid[int]
+		want want
+	}{
+		{
+			name: "round trip",
+			data: saved.Bytes(),
+			grid: func() *lattice.SpatialGrid[int] { return lattice.NewSpatialGrid[int](3, 3, 16) },
+		},
+		{
+			name: "other dimensions",
+			data: saved.Bytes(),
+			grid: func() *lattice.SpatialGrid[int] { return lattice.NewSpatialGrid[int](4, 3, 16) },
+			want: want{err: lattice.ErrLayerMismatch},
+		},
+		{
+			name: "truncated",
+			data: saved.Bytes()[:saved.Len()-1],
+			grid: func() *lattice.SpatialGrid[int] { return lattice.NewSpatialGrid[int](3, 3, 16) },
+			want: want{err: lattice.ErrInvalidFormat},
+		},
+		{
+			name: "not layers",
+			data: []byte("LTCG"),
+			grid: func() *lattice.SpatialGrid[int] { return lattice.NewSpatialGrid[int](3, 3, 16) },
+			want: want{err: lattice.ErrInvalidFormat},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg := tt.grid()
+			err := sg.ImportStaticLayers(bytes.NewReader(tt.data))
+			if !errors.Is(err, tt.want.err) {
+				t.Fatal(fmt.Errorf("spatialGrid.ImportStaticLayers() want: %+v, got: %+v\n", tt.want.err, err))
+			}
+			if err != nil {
+				if sg.Terrain(0, 0) != 0 {
+					t.Error(fmt.Errorf("spatialGrid.ImportStaticLayers() want an untouched grid, got terrain: %+v\n", sg.Terrain(0, 0)))
+				}
+				return
+			}
+
+			if sg.Terrain(0, 0) != 2 || sg.Paint(1, 0) != 3 {
+				t.Error(fmt.Errorf("spatialGrid.ImportStaticLayers() want terrain 2 and paint 3, got: %+v, %+v\n", sg.Terrain(0, 0), sg.Paint(1, 0)))
+			}
+			if !sg.Blocked(2, 2) || sg.Size() != 0 {
+				t.Error(fmt.Errorf("spatialGrid.ImportStaticLayers() want the static wall as terrain, got blocked: %+v, size: %+v\n", sg.Blocked(2, 2), sg.Size()))
+			}
+			if sg.GetLocationWeight(0, 2) != 0 {
+				t.Error(fmt.Errorf("spatialGrid.ImportStaticLayers() want no dynamic weight, got: %+v\n", sg.GetLocationWeight(0, 2)))
+			}
+		})
+	}
+}
+
+func Test_spatial_grid_ImportStaticLayers_static_once(t *testing.T) {
+	rock := lattice.Item[int]{1, mosaic.NewRectangle(mosaic.NewVector(40, 40), 16, 16), 5}
+	source := lattice.NewSpatialGrid[int](3, 3, 16)
+	source.InsertStatic(rock)
+	want := source.GetLocationWeight(2, 2)
+
+	var saved bytes.Buffer
+	err := source.ExportStaticLayers(&saved)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		load func(sg *lattice.SpatialGrid[int]) error
+	}{
+		{
+			name: "imported twice",
+			load: func(sg *lattice.SpatialGrid[int]) error {
+				err := sg.ImportStaticLayers(bytes.NewReader(saved.Bytes()))
+				if err != nil {
+					return err
+				}
+				return sg.ImportStaticLayers(bytes.NewReader(saved.Bytes()))
+			},
+		},
+		{
+			name: "static items inserted before",
+			load: func(sg *lattice.SpatialGrid[int]) error {
+				sg.InsertStatic(rock)
+				return sg.ImportStaticLayers(bytes.NewReader(saved.Bytes()))
+			},
+		},
+		{
+			name: "static items inserted after",
+			load: func(sg *lattice.SpatialGrid[int]) error {
+				err := sg.ImportStaticLayers(bytes.NewReader(saved.Bytes()))
+				sg.InsertStatic(rock)
+				return err
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg := lattice.NewSpatialGrid[int](3, 3, 16)
+			err := tt.load(sg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if sg.GetLocationWeight(2, 2) != want || sg.Terrain(2, 2) != 0 {
+				t.Error(fmt.Errorf("spatialGrid.ImportStaticLayers() want weight %+v and no terrain, got: %+v, %+v\n", want, sg.GetLocationWeight(2, 2), sg.Terrain(2, 2)))
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package lattice
+
+import "github.com/maladroitthief/mosaic"
+
+type (
+	// Stats is a diagnostic summary of the grid. The out of bounds figures
+	// are only recorded under WithBoundsDiagnostics: OutOfBounds counts the
+	// inserts whose bounds reached past the world rectangle and Worst is the
+	// one that reached furthest, by Overhang world units.
+	Stats[T comparable] struct {
+		Items       int
+		OutOfBounds int
+		Worst       OutOfBoundsInsert[T]
+	}
+
+	OutOfBoundsInsert[T comparable] struct {
+		Value    T
+		Bounds   mosaic.Rectangle
+		Overhang float64
+	}
+)
+
+// WithBoundsDiagnostics records inserts whose bounds do not fit the grid for
+// Stats, since the grid otherwise clamps them without a word. It is meant
+// for playtest builds chasing misconfigured entity sizes.
+func WithBoundsDiagnostics() Option {
+	return func(c *config) error {
+		c.boundsDiagnostics = true
+		return nil
+	}
+}
+
+func (sg *SpatialGrid[T]) Stats() Stats[T] {
+	sg = sg.rlock()
+	defer sg.runlock()
+
+	stats := sg.stats
+	stats.Items = sg.itemCount
+	return stats
+}
+
+// ResetStats forgets the out of bounds inserts recorded so far
+func (sg *SpatialGrid[T]) ResetStats() {
+	sg.lock()
+	defer sg.unlock()
+
+	sg.stats = Stats[T]{}
+}
+
+// diagnose records item when its bounds reach past the world rectangle
+func (sg *SpatialGrid[T]) diagnose(item Item[T]) {
+	if !sg.config.boundsDiagnostics {
+		return
+	}
+
+	low, high := sg.CellToWorld(0, 0), sg.CellToWorld(sg.SizeX, sg.SizeY)
+	minPoint, maxPoint := item.Bounds.MinPoint(), item.Bounds.MaxPoint()
+	overhang := max(low.X-minPoint.X, low.Y-minPoint.Y, maxPoint.X-high.X, maxPoint.Y-high.Y)
+	if overhang <= 0 {
+		return
+	}
+
+	sg.stats.OutOfBounds++
+	if overhang > sg.stats.Worst.Overhang {
+		sg.stats.Worst = OutOfBoundsInsert[T]{Value: item.Value, Bounds: item.Bounds, Overhang: overhang}
+	}
+}
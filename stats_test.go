@@ -0,0 +1,91 @@
+package lattice_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/mosaic"
+)
+
+func Test_spatial_grid_Stats(t *testing.T) {
+	tests := []struct {
+		name  string
+		opts  []lattice.Option
+		items []lattice.Item[int]
+		want  lattice.Stats[int]
+	}{
+		{
+			name: "inside",
+			opts: []lattice.Option{lattice.WithBoundsDiagnostics()},
+			items: []lattice.Item[int]{
+				{1, mosaic.NewRectangle(mosaic.NewVector(16, 16), 32, 32), 1},
+			},
+			want: lattice.Stats[int]{Items: 1},
+		},
+		{
+			name: "worst offender",
+			opts: []lattice.Option{lattice.WithBoundsDiagnostics()},
+			items: []lattice.Item[int]{
+				{1, mosaic.NewRectangle(mosaic.NewVector(2, 16), 8, 8), 1},
+				{2, mosaic.NewRectangle(mosaic.NewVector(30, 30), 20, 4), 1},
+				{3, mosaic.NewRectangle(mosaic.NewVector(16, 16), 4, 4), 1},
+				{4, mosaic.NewRectangle(mosaic.NewVector(40, 16), 2, 2), 1},
+			},
+			want: lattice.Stats[int]{
+				Items:       4,
+				OutOfBounds: 3,
+				Worst: lattice.OutOfBoundsInsert[int]{
+					Value:    4,
+					Bounds:   mosaic.NewRectangle(mosaic.NewVector(40, 16), 2, 2),
+					Overhang: 9,
+				},
+			},
+		},
+		{
+			name: "off by default",
+			items: []lattice.Item[int]{
+				{1, mosaic.NewRectangle(mosaic.NewVector(2, 16), 8, 8), 1},
+			},
+			want: lattice.Stats[int]{Items: 1},
+		},
+		{
+			name: "read optimized",
+			opts: []lattice.Option{lattice.WithBoundsDiagnostics(), lattice.WithLocking(lattice.LockingReadOptimized)},
+			items: []lattice.Item[int]{
+				{1, mosaic.NewRectangle(mosaic.NewVector(2, 16), 8, 8), 1},
+			},
+			want: lattice.Stats[int]{
+				Items:       1,
+				OutOfBounds: 1,
+				Worst: lattice.OutOfBoundsInsert[int]{
+					Value:    1,
+					Bounds:   mosaic.NewRectangle(mosaic.NewVector(2, 16), 8, 8),
+					Overhang: 2,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg, err := lattice.NewSpatialGridOpts[int](2, 2, 16, tt.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, item := range tt.items {
+				sg.Insert(item)
+			}
+
+			got := sg.Stats()
+			if got != tt.want {
+				t.Error(fmt.Errorf("spatialGrid.Stats() want: %+v, got: %+v\n", tt.want, got))
+			}
+
+			sg.ResetStats()
+			want := lattice.Stats[int]{Items: len(tt.items)}
+			if got := sg.Stats(); got != want {
+				t.Error(fmt.Errorf("spatialGrid.ResetStats() want: %+v, got: %+v\n", want, got))
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package lattice
+
+import "github.com/maladroitthief/mosaic"
+
+type (
+	RegionChange int
+
+	RegionEvent[T comparable] struct {
+		Subscription int
+		Value        T
+		Change       RegionChange
+	}
+
+	subscription[T comparable] struct {
+		id     int
+		bounds mosaic.Rectangle
+		notify func(RegionEvent[T])
+		inside map[T]struct{}
+	}
+
+	regionNotice[T comparable] struct {
+		notify func(RegionEvent[T])
+		event  RegionEvent[T]
+	}
+)
+
+const (
+	RegionEnter RegionChange = iota
+	RegionLeave
+)
+
+// Subscribe calls notify whenever an item's bounds start or stop intersecting
+// bounds, starting with an enter for every item already inside. Events are
+// delivered after the write that caused them releases the grid, so notify may
+// query or modify the grid itself.
+func (sg *SpatialGrid[T]) Subscribe(bounds mosaic.Rectangle, notify func(RegionEvent[T])) int {
+	sg.lock()
+	defer sg.unlock()
+
+	sg.nextSub++
+	sub := &subscription[T]{
+		id:     sg.nextSub,
+		bounds: bounds,
+		notify: notify,
+		inside: map[T]struct{}{},
+	}
+	sg.subs = append(sg.subs, sub)
+	sg.resyncSubscription(sub)
+
+	return sub.id
+}
+
+// Unsubscribe stops the subscription without sending leave events
+func (sg *SpatialGrid[T]) Unsubscribe(id int) {
+	sg.lock()
+	defer sg.unlock()
+
+	for i, sub := range sg.subs {
+		if sub.id == id {
+			sg.subs = append(sg.subs[:i], sg.subs[i+1:]...)
+			return
+		}
+	}
+}
+
+// track records value as now having bounds, or as gone from the grid when
+// present is false, and queues the enters and leaves that follow
+func (sg *SpatialGrid[T]) track(value T, bounds mosaic.Rectangle, present bool) {
+	for _, sub := range sg.subs {
+		now := present && sub.bounds.Intersects(bounds)
+		_, was := sub.inside[value]
+		switch {
+		case now && !was:
+			sub.inside[value] = struct{}{}
+			sg.notice(sub, value, RegionEnter)
+		case was && !now:
+			delete(sub.inside, value)
+			sg.notice(sub, value, RegionLeave)
+		}
+	}
+}
+
+// resync rebuilds every subscription from the grid contents, for writes that
+// replace items wholesale
+func (sg *SpatialGrid[T]) resync() {
+	for _, sub := range sg.subs {
+		sg.resyncSubscription(sub)
+	}
+}
+
+func (sg *SpatialGrid[T]) resyncSubscription(sub *subscription[T]) {
+	inside := map[T]struct{}{}
+	add := func(value T, bounds mosaic.Rectangle) {
+		if !sub.bounds.Intersects(bounds) {
+			return
+		}
+		inside[value] = struct{}{}
+		_, was := sub.inside[value]
+		if !was {
+			sg.notice(sub, value, RegionEnter)
+		}
+	}
+
+	for x := range sg.Nodes {
+		for y := range sg.Nodes[x] {
+			for _, item := range sg.Nodes[x][y].Items {
+				add(item.value, item.bounds)
+			}
+		}
+	}
+	for _, item := range sg.overflow {
+		add(item.Value, item.Bounds)
+	}
+
+	for value := range sub.inside {
+		_, now := inside[value]
+		if !now {
+			sg.notice(sub, value, RegionLeave)
+		}
+	}
+	sub.inside = inside
+}
+
+func (sg *SpatialGrid[T]) notice(sub *subscription[T], value T, change RegionChange) {
+	sg.notices = append(sg.notices, regionNotice[T]{
+		notify: sub.notify,
+		event:  RegionEvent[T]{Subscription: sub.id, Value: value, Change: change},
+	})
+}
+
+func (sg *SpatialGrid[T]) deliver(notices []regionNotice[T]) {
+	for _, n := range notices {
+		n.notify(n.event)
+	}
+}
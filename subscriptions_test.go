@@ -0,0 +1,129 @@
+package lattice_test
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/mosaic"
+)
+
+func Test_spatial_grid_Subscribe(t *testing.T) {
+	square := func(x, y float64) mosaic.Rectangle {
+		return mosaic.NewRectangle(mosaic.NewVector(x, y), 8, 8)
+	}
+	region := mosaic.NewRectangle(mosaic.NewVector(64, 64), 32, 32)
+	type want struct {
+		events []lattice.RegionEvent[int]
+	}
+	tests := []struct {
+		name  string
+		setup []lattice.Item[int]
+		write func(sg *lattice.SpatialGrid[int])
+		want  want
+	}{
+		{
+			name:  "existing items enter on subscribe",
+			setup: []lattice.Item[int]{{1, square(64, 64), 1}, {2, square(8, 8), 1}},
+			write: func(sg *lattice.SpatialGrid[int]) {},
+			want: want{events: []lattice.RegionEvent[int]{
+				{Subscription: 1, Value: 1, Change: lattice.RegionEnter},
+			}},
+		},
+		{
+			name: "insert inside",
+			write: func(sg *lattice.SpatialGrid[int]) {
+				sg.Insert(lattice.Item[int]{1, square(64, 64), 1})
+				sg.Insert(lattice.Item[int]{2, square(8, 8), 1})
+			},
+			want: want{events: []lattice.RegionEvent[int]{
+				{Subscription: 1, Value: 1, Change: lattice.RegionEnter},
+			}},
+		},
+		{
+			name:  "moving within the region is silent",
+			setup: []lattice.Item[int]{{1, square(56, 56), 1}},
+			write: func(sg *lattice.SpatialGrid[int]) {
+				sg.Update(lattice.Item[int]{1, square(72, 72), 1}, square(56, 56))
+				sg.UpdateBatch([]lattice.BoundsUpdate[int]{
+					{Value: 1, OldBounds: square(72, 72), NewBounds: square(60, 60), Multiplier: 1},
+				})
+			},
+			want: want{events: []lattice.RegionEvent[int]{
+				{Subscription: 1, Value: 1, Change: lattice.RegionEnter},
+			}},
+		},
+		{
+			name:  "leave and reenter",
+			setup: []lattice.Item[int]{{1, square(64, 64), 1}},
+			write: func(sg *lattice.SpatialGrid[int]) {
+				sg.Update(lattice.Item[int]{1, square(8, 8), 1}, square(64, 64))
+				sg.UpdateBatch([]lattice.BoundsUpdate[int]{
+					{Value: 1, OldBounds: square(8, 8), NewBounds: square(64, 64), Multiplier: 1},
+				})
+			},
+			want: want{events: []lattice.RegionEvent[int]{
+				{Subscription: 1, Value: 1, Change: lattice.RegionEnter},
+				{Subscription: 1, Value: 1, Change: lattice.RegionLeave},
+				{Subscription: 1, Value: 1, Change: lattice.RegionEnter},
+			}},
+		},
+		{
+			name:  "delete and drop",
+			setup: []lattice.Item[int]{{1, square(64, 64), 1}, {2, square(70, 70), 1}},
+			write: func(sg *lattice.SpatialGrid[int]) {
+				sg.Delete(1, square(64, 64))
+				sg.Drop()
+			},
+			want: want{events: []lattice.RegionEvent[int]{
+				{Subscription: 1, Value: 1, Change: lattice.RegionEnter},
+				{Subscription: 1, Value: 2, Change: lattice.RegionEnter},
+				{Subscription: 1, Value: 1, Change: lattice.RegionLeave},
+				{Subscription: 1, Value: 2, Change: lattice.RegionLeave},
+			}},
+		},
+		{
+			name:  "reset keeps survivors",
+			setup: []lattice.Item[int]{{1, square(64, 64), 1}},
+			write: func(sg *lattice.SpatialGrid[int]) {
+				sg.Reset([]lattice.Item[int]{{1, square(64, 64), 1}, {2, square(64, 64), 1}})
+			},
+			want: want{events: []lattice.RegionEvent[int]{
+				{Subscription: 1, Value: 1, Change: lattice.RegionEnter},
+				{Subscription: 1, Value: 2, Change: lattice.RegionEnter},
+			}},
+		},
+		{
+			name:  "unsubscribe",
+			setup: []lattice.Item[int]{{1, square(64, 64), 1}},
+			write: func(sg *lattice.SpatialGrid[int]) {
+				sg.Unsubscribe(1)
+				sg.Delete(1, square(64, 64))
+			},
+			want: want{events: []lattice.RegionEvent[int]{
+				{Subscription: 1, Value: 1, Change: lattice.RegionEnter},
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg := lattice.NewSpatialGrid[int](8, 8, 16)
+			for _, item := range tt.setup {
+				sg.Insert(item)
+			}
+
+			got := []lattice.RegionEvent[int]{}
+			sg.Subscribe(region, func(event lattice.RegionEvent[int]) {
+				// the grid is unlocked by the time events arrive
+				sg.FindNear(region)
+				got = append(got, event)
+			})
+			tt.write(sg)
+
+			if !slices.Equal(tt.want.events, got) {
+				t.Error(fmt.Errorf("spatialGrid.Subscribe() want: %+v, got: %+v\n", tt.want.events, got))
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package lattice
+
+// SetTerrain gives cell x, y a weight of its own that no item owns. It
+// survives Drop and Reset, only another SetTerrain changes it.
+func (sg *SpatialGrid[T]) SetTerrain(x, y int, weight float64) error {
+	sg.lock()
+	defer sg.unlock()
+
+	if !sg.inBounds(x, y) {
+		return ErrOutOfBounds
+	}
+
+	sg.setTerrain(x, y, weight)
+	return nil
+}
+
+func (sg *SpatialGrid[T]) Terrain(x, y int) float64 {
+	sg = sg.rlock()
+	defer sg.runlock()
+
+	return sg.Nodes[x][y].terrain
+}
+
+func (sg *SpatialGrid[T]) setTerrain(x, y int, weight float64) {
+	node := sg.Nodes[x][y]
+	node.terrain = weight
+	node.weight = node.itemWeights()
+	sg.Nodes[x][y] = node
+	sg.updateBlocked(x, y)
+}
@@ -0,0 +1,49 @@
+package lattice_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/mosaic"
+)
+
+func Test_spatial_grid_SetTerrain(t *testing.T) {
+	tests := []struct {
+		name    string
+		terrain float64
+		want    float64
+		blocked bool
+	}{
+		{name: "adds to items", terrain: 10, want: 14},
+		{name: "blocking terrain", terrain: math.Inf(1), want: math.Inf(1), blocked: true},
+		{name: "no terrain", terrain: 0, want: 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg := lattice.NewSpatialGrid[int](2, 2, 8)
+			bounds := mosaic.NewRectangle(mosaic.NewVector(4, 4), 2, 2)
+			sg.Insert(lattice.Item[int]{1, bounds, 1})
+
+			err := sg.SetTerrain(0, 0, tt.terrain)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if sg.GetLocationWeight(0, 0) != tt.want || sg.Blocked(0, 0) != tt.blocked {
+				t.Error(fmt.Errorf("spatialGrid.SetTerrain() want: %+v, got: %+v\n", tt.want, sg.GetLocationWeight(0, 0)))
+			}
+
+			sg.Delete(1, bounds)
+			sg.Drop()
+			if sg.GetLocationWeight(0, 0) != tt.terrain || sg.Terrain(0, 0) != tt.terrain {
+				t.Error(fmt.Errorf("spatialGrid.Drop() want terrain: %+v, got: %+v\n", tt.terrain, sg.GetLocationWeight(0, 0)))
+			}
+		})
+	}
+
+	err := lattice.NewSpatialGrid[int](2, 2, 8).SetTerrain(2, 0, 1)
+	if err != lattice.ErrOutOfBounds {
+		t.Error(fmt.Errorf("spatialGrid.SetTerrain() want error: %+v, got error: %+v\n", lattice.ErrOutOfBounds, err))
+	}
+}
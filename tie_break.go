@@ -0,0 +1,33 @@
+package lattice
+
+type TieBreak int
+
+const (
+	TieBreakNone TieBreak = iota
+	// TieBreakCrossProduct favors cells near the straight line from start to
+	// end, which keeps open-field routes from hugging one edge
+	TieBreakCrossProduct
+	// TieBreakJitter perturbs priorities by a per-cell amount derived from
+	// PathOptions.Seed, so agents with different seeds spread across equally
+	// good routes while each seed stays reproducible
+	TieBreakJitter
+)
+
+// tieEpsilon bounds the nudge so it only reorders entries whose priorities
+// are already equal for practical purposes
+const tieEpsilon = 1e-3
+
+func (sg *SpatialGrid[T]) tieBreak(x, y int, start, end Cell, opts PathOptions) float64 {
+	switch opts.TieBreak {
+	case TieBreakCrossProduct:
+		dx1, dy1 := x-end.X, y-end.Y
+		dx2, dy2 := start.X-end.X, start.Y-end.Y
+		span := float64(sg.SizeX * sg.SizeY)
+		return float64(abs(dx1*dy2-dx2*dy1)) / span * tieEpsilon
+	case TieBreakJitter:
+		bits := mix64(opts.Seed ^ mix64(uint64(sg.index(x, y))))
+		return float64(bits>>11) / (1 << 53) * tieEpsilon
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,56 @@
+package lattice_test
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/mosaic"
+)
+
+func Test_spatial_grid_FindPath_TieBreak(t *testing.T) {
+	sg := lattice.NewSpatialGrid[int](6, 6, 8)
+	start, end := mosaic.Vector{X: 4, Y: 4}, mosaic.Vector{X: 44, Y: 44}
+
+	t.Run("jitter spreads routes", func(t *testing.T) {
+		routes := [][]mosaic.Vector{}
+		for seed := uint64(0); seed < 16; seed++ {
+			opts := lattice.PathOptions{TieBreak: lattice.TieBreakJitter, Seed: seed}
+			got, err := sg.FindPath(start, end, opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			again, _ := sg.FindPath(start, end, opts)
+			if !slices.Equal(got.Waypoints, again.Waypoints) {
+				t.Error(fmt.Errorf("spatialGrid.FindPath() seed %+v not reproducible: %+v, %+v\n", seed, got.Waypoints, again.Waypoints))
+			}
+			if len(got.Waypoints) != 11 || got.Cost != 0 {
+				t.Error(fmt.Errorf("spatialGrid.FindPath() want: 10 steps at cost 0, got: %+v\n", got))
+			}
+
+			if !slices.ContainsFunc(routes, func(route []mosaic.Vector) bool {
+				return slices.Equal(route, got.Waypoints)
+			}) {
+				routes = append(routes, got.Waypoints)
+			}
+		}
+		if len(routes) < 2 {
+			t.Error(fmt.Errorf("spatialGrid.FindPath() want: several routes, got: %+v\n", len(routes)))
+		}
+	})
+
+	t.Run("cross product hugs the diagonal", func(t *testing.T) {
+		got, err := sg.FindPath(start, end, lattice.PathOptions{TieBreak: lattice.TieBreakCrossProduct})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, waypoint := range got.Waypoints {
+			x, y := sg.Location(waypoint.X, waypoint.Y)
+			if x-y > 1 || y-x > 1 {
+				t.Error(fmt.Errorf("spatialGrid.FindPath() strayed from the diagonal: %+v\n", got.Waypoints))
+				break
+			}
+		}
+	})
+}
@@ -0,0 +1,252 @@
+package lattice
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/csv"
+	"encoding/xml"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Tiled keeps flip and rotation flags in the top bits of every gid
+const tiledFlags = 0xF0000000
+
+type (
+	// TileMapping is handed the 0-based id of a tile within its tileset, the
+	// id Tiled's CSV export writes
+	TileMapping func(tileID int) (multiplier float64, blocked bool)
+
+	tmxMap struct {
+		Tilesets []struct {
+			FirstGID int `xml:"firstgid,attr"`
+		} `xml:"tileset"`
+		Layers []struct {
+			Width int `xml:"width,attr"`
+			Data  struct {
+				Encoding    string `xml:"encoding,attr"`
+				Compression string `xml:"compression,attr"`
+				Text        string `xml:",chardata"`
+				Tiles       []struct {
+					GID uint32 `xml:"gid,attr"`
+				} `xml:"tile"`
+			} `xml:"data"`
+		} `xml:"layer"`
+	}
+)
+
+// LoadTiles sets the terrain of every cell from a Tiled layer, either a CSV
+// export or the first tile layer of a TMX map. Each tile's terrain is the
+// cell area times its multiplier, or infinite when blocked. TMX gids are
+// turned into ids local to the tileset they fall in, so both formats map
+// alike. Empty tiles (-1 in CSV, gid 0 in TMX) are left alone, as are tiles
+// past the grid's edge, which are reported as ErrOutOfBounds once the rest are
+// loaded.
+func (sg *SpatialGrid[T]) LoadTiles(r io.Reader, mapping TileMapping) error {
+	reader := bufio.NewReader(r)
+	tmx := false
+	for {
+		b, err := reader.Peek(1)
+		if err != nil {
+			break
+		}
+		if !unicode.IsSpace(rune(b[0])) {
+			tmx = b[0] == '<'
+			break
+		}
+		reader.ReadByte()
+	}
+
+	var rows [][]int
+	var err error
+	if tmx {
+		rows, err = readTMX(reader)
+	} else {
+		rows, err = readTileCSV(reader)
+	}
+	if err != nil {
+		return err
+	}
+
+	sg.lock()
+	defer sg.unlock()
+
+	area := sg.ChunkSize * sg.ChunkSize
+	for y, row := range rows {
+		for x, tile := range row {
+			if tile < 0 {
+				continue
+			}
+			if !sg.inBounds(x, y) {
+				err = ErrOutOfBounds
+				continue
+			}
+
+			multiplier, blocked := mapping(tile)
+			weight := area * multiplier
+			if blocked {
+				weight = math.Inf(1)
+			}
+			sg.setTerrain(x, y, weight)
+		}
+	}
+
+	return err
+}
+
+func readTileCSV(r io.Reader) ([][]int, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	rows := [][]int{}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		row := make([]int, 0, len(record))
+		for _, field := range record {
+			field = strings.TrimSpace(field)
+			// Tiled ends every TMX row but the last with a trailing comma
+			if field == "" {
+				continue
+			}
+
+			tile, err := strconv.ParseInt(field, 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			row = append(row, int(tile))
+		}
+		if len(row) > 0 {
+			rows = append(rows, row)
+		}
+	}
+}
+
+func readTMX(r io.Reader) ([][]int, error) {
+	var m tmxMap
+	err := xml.NewDecoder(r).Decode(&m)
+	if err != nil {
+		return nil, err
+	}
+	if len(m.Layers) == 0 {
+		return [][]int{}, nil
+	}
+
+	layer := m.Layers[0]
+	gids := []uint32{}
+	switch layer.Data.Encoding {
+	case "csv":
+		rows, err := readTileCSV(strings.NewReader(layer.Data.Text))
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			for _, tile := range row {
+				gids = append(gids, uint32(tile))
+			}
+		}
+	case "base64":
+		gids, err = decodeTMXBase64(layer.Data.Text, layer.Data.Compression)
+		if err != nil {
+			return nil, err
+		}
+	case "":
+		for _, tile := range layer.Data.Tiles {
+			gids = append(gids, tile.GID)
+		}
+	default:
+		return nil, ErrInvalidFormat
+	}
+
+	if layer.Width <= 0 {
+		return nil, ErrInvalidFormat
+	}
+	firstGIDs := []int{}
+	for _, tileset := range m.Tilesets {
+		firstGIDs = append(firstGIDs, tileset.FirstGID)
+	}
+	if len(firstGIDs) == 0 {
+		firstGIDs = append(firstGIDs, 1)
+	}
+	slices.Sort(firstGIDs)
+
+	rows := [][]int{}
+	for i, gid := range gids {
+		if i%layer.Width == 0 {
+			rows = append(rows, make([]int, 0, layer.Width))
+		}
+		rows[len(rows)-1] = append(rows[len(rows)-1], localTile(int(gid&^tiledFlags), firstGIDs))
+	}
+
+	return rows, nil
+}
+
+// localTile turns a gid into an id within the tileset holding it, the one
+// with the highest firstgid not past it, or -1 for empty tiles and gids
+// before every tileset
+func localTile(gid int, firstGIDs []int) int {
+	if gid == 0 {
+		return -1
+	}
+
+	i, found := slices.BinarySearch(firstGIDs, gid)
+	if !found {
+		i--
+	}
+	if i < 0 {
+		return -1
+	}
+
+	return gid - firstGIDs[i]
+}
+
+func decodeTMXBase64(text, compression string) ([]uint32, error) {
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(text))
+	if err != nil {
+		return nil, err
+	}
+
+	var data io.Reader = bytes.NewReader(raw)
+	switch compression {
+	case "":
+	case "gzip":
+		data, err = gzip.NewReader(data)
+	case "zlib":
+		data, err = zlib.NewReader(data)
+	default:
+		return nil, ErrInvalidFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	raw, err = io.ReadAll(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(raw)%4 != 0 {
+		return nil, ErrInvalidFormat
+	}
+
+	gids := make([]uint32, len(raw)/4)
+	for i := range gids {
+		gids[i] = binary.LittleEndian.Uint32(raw[4*i:])
+	}
+
+	return gids, nil
+}
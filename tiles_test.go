@@ -0,0 +1,102 @@
+package lattice_test
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+)
+
+func Test_spatial_grid_LoadTiles(t *testing.T) {
+	encode := func(gids ...uint32) string {
+		raw := make([]byte, 4*len(gids))
+		for i, gid := range gids {
+			binary.LittleEndian.PutUint32(raw[4*i:], gid)
+		}
+		var buf bytes.Buffer
+		w := zlib.NewWriter(&buf)
+		w.Write(raw)
+		w.Close()
+		return base64.StdEncoding.EncodeToString(buf.Bytes())
+	}
+	mapping := func(tileID int) (float64, bool) {
+		if tileID == 3 {
+			return 0, true
+		}
+		return float64(tileID), false
+	}
+	tests := []struct {
+		name  string
+		input string
+		want  [][]float64
+		err   error
+	}{
+		{
+			name:  "csv export",
+			input: "-1,1\n2,3\n",
+			want:  [][]float64{{0, 64}, {128, math.Inf(1)}},
+		},
+		{
+			name: "tmx csv layer",
+			input: `<?xml version="1.0" encoding="UTF-8"?>
+<map width="2" height="2" tilewidth="8" tileheight="8">
+ <tileset firstgid="1" source="collision.tsx"/>
+ <layer id="1" name="collision" width="2" height="2">
+  <data encoding="csv">
+0,2,
+3,2147483652
+</data>
+ </layer>
+</map>`,
+			want: [][]float64{{0, 64}, {128, math.Inf(1)}},
+		},
+		{
+			name: "tmx base64 zlib layer",
+			input: `<map width="2" height="2"><tileset firstgid="5"/><layer width="2" height="2"><data encoding="base64" compression="zlib">` +
+				encode(6, 0, 0, 7) + `</data></layer></map>`,
+			want: [][]float64{{64, 0}, {0, 128}},
+		},
+		{
+			name: "tmx xml tiles",
+			input: `<map><layer width="2" height="2"><data>` +
+				`<tile gid="3"/><tile gid="3"/><tile/><tile gid="2"/></data></layer></map>`,
+			want: [][]float64{{128, 128}, {0, 64}},
+		},
+		{
+			name: "tmx second tileset",
+			input: `<map><tileset firstgid="1"/><tileset firstgid="10"/><layer width="2" height="1"><data>` +
+				`<tile gid="11"/><tile gid="13"/></data></layer></map>`,
+			want: [][]float64{{64, math.Inf(1)}},
+		},
+		{
+			name:  "larger than the grid",
+			input: "1,1,1\n1,1,1\n",
+			want:  [][]float64{{64, 64}, {64, 64}},
+			err:   lattice.ErrOutOfBounds,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg := lattice.NewSpatialGrid[int](2, 2, 8)
+			err := sg.LoadTiles(strings.NewReader(tt.input), mapping)
+			if !errors.Is(err, tt.err) {
+				t.Fatal(fmt.Errorf("spatialGrid.LoadTiles() want error: %+v, got error: %+v\n", tt.err, err))
+			}
+
+			for y, row := range tt.want {
+				for x, weight := range row {
+					if sg.Terrain(x, y) != weight {
+						t.Error(fmt.Errorf("spatialGrid.LoadTiles() cell %+v, %+v want: %+v, got: %+v\n", x, y, weight, sg.Terrain(x, y)))
+					}
+				}
+			}
+		})
+	}
+}
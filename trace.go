@@ -0,0 +1,90 @@
+package lattice
+
+import (
+	"math"
+
+	"github.com/maladroitthief/mosaic"
+)
+
+type (
+	// SearchTrace shows how a search reached its result. Expanded lists cells
+	// in the order they were dequeued, Frontiers holds the open set every
+	// FrontierEvery expansions plus once more at the end, and Costs is the
+	// cheapest cost found to every cell the search touched.
+	SearchTrace struct {
+		Expanded      []Cell
+		Frontiers     [][]Cell
+		FrontierEvery int
+		Costs         map[Cell]float64
+	}
+)
+
+// FindPathTrace is FindPath with a SearchTrace of the work behind the
+// answer. It allocates freely and is meant for tooling, not the game loop.
+func (sg *SpatialGrid[T]) FindPathTrace(start, end mosaic.Vector, opts PathOptions, frontierEvery int) (Path, SearchTrace, error) {
+	sg = sg.rlock()
+	defer sg.runlock()
+
+	s := sg.NewSearcher()
+	s.trace = &SearchTrace{
+		Expanded:      []Cell{},
+		Frontiers:     [][]Cell{},
+		FrontierEvery: frontierEvery,
+		Costs:         map[Cell]float64{},
+	}
+
+	path, err := s.findPath(start, end, opts)
+	s.finishTrace(opts)
+	if err != nil {
+		return Path{Waypoints: []mosaic.Vector{}}, *s.trace, err
+	}
+
+	return path, *s.trace, nil
+}
+
+func (s *Searcher[T]) record(cell int32, expansions int, states int32) {
+	sg := s.grid
+	s.trace.Expanded = append(s.trace.Expanded, Cell{int(cell) % sg.SizeX, int(cell) / sg.SizeX})
+	if s.trace.FrontierEvery > 0 && (expansions+1)%s.trace.FrontierEvery == 0 {
+		s.trace.Frontiers = append(s.trace.Frontiers, s.frontier(states))
+	}
+}
+
+func (s *Searcher[T]) frontier(states int32) []Cell {
+	sg := s.grid
+	seen := map[int32]bool{}
+	cells := []Cell{}
+	for _, entry := range s.open.entries {
+		cell := entry.index / states
+		if seen[cell] {
+			continue
+		}
+		seen[cell] = true
+		cells = append(cells, Cell{int(cell) % sg.SizeX, int(cell) / sg.SizeX})
+	}
+
+	return cells
+}
+
+func (s *Searcher[T]) finishTrace(opts PathOptions) {
+	sg := s.grid
+	states := sg.searchStates(opts)
+	// the search never started, so no stamp belongs to it
+	if s.generation == 0 {
+		return
+	}
+
+	s.trace.Frontiers = append(s.trace.Frontiers, s.frontier(states))
+	for state := int32(0); state < int32(sg.SizeX*sg.SizeY)*states; state++ {
+		if !s.seen(state) {
+			continue
+		}
+
+		cell := Cell{int(state/states) % sg.SizeX, int(state/states) / sg.SizeX}
+		cost, ok := s.trace.Costs[cell]
+		if !ok {
+			cost = math.Inf(1)
+		}
+		s.trace.Costs[cell] = min(cost, s.costs[state])
+	}
+}
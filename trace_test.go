@@ -0,0 +1,76 @@
+package lattice_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/mosaic"
+)
+
+func Test_spatial_grid_FindPathTrace(t *testing.T) {
+	grid := Builder{
+		x:    5,
+		y:    5,
+		size: 8,
+		layout: "" +
+			"00000" +
+			"0xxx0" +
+			"000x0" +
+			"0x0x0" +
+			"00000",
+	}
+	center := func(x, y int) mosaic.Vector {
+		return mosaic.NewVector(float64(x*grid.size)+4, float64(y*grid.size)+4)
+	}
+	tests := []struct {
+		name  string
+		end   mosaic.Vector
+		every int
+		opts  lattice.PathOptions
+		err   error
+	}{
+		{name: "around the wall", end: center(2, 2), every: 1},
+		{name: "turn penalty", end: center(2, 2), every: 3, opts: lattice.PathOptions{TurnPenalty: 5}},
+		{name: "walled in", end: center(2, 1), every: 0, err: lattice.ErrPathNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg := lattice.NewSpatialGrid[int](grid.x, grid.y, float64(grid.size))
+			setup_grid(sg, grid)
+
+			path, trace, err := sg.FindPathTrace(center(0, 0), tt.end, tt.opts, tt.every)
+			if !errors.Is(err, tt.err) {
+				t.Fatal(fmt.Errorf("spatialGrid.FindPathTrace() want error: %+v, got error: %+v\n", tt.err, err))
+			}
+
+			plain, plainErr := sg.FindPath(center(0, 0), tt.end, tt.opts)
+			if !errors.Is(plainErr, tt.err) || path.Cost != plain.Cost || len(path.Waypoints) != len(plain.Waypoints) {
+				t.Error(fmt.Errorf("spatialGrid.FindPathTrace() want: %+v, got: %+v\n", plain, path))
+			}
+
+			if len(trace.Expanded) == 0 || trace.Expanded[0] != (lattice.Cell{X: 0, Y: 0}) {
+				t.Error(fmt.Errorf("searchTrace.Expanded want to start at: %+v, got: %+v\n", lattice.Cell{}, trace.Expanded))
+			}
+			frontiers := 1
+			if tt.every > 0 {
+				frontiers += len(trace.Expanded) / tt.every
+			}
+			if len(trace.Frontiers) != frontiers {
+				t.Error(fmt.Errorf("searchTrace.Frontiers want: %+v, got: %+v\n", frontiers, len(trace.Frontiers)))
+			}
+			for _, cell := range trace.Expanded {
+				if _, ok := trace.Costs[cell]; !ok {
+					t.Error(fmt.Errorf("searchTrace.Costs want entry for: %+v\n", cell))
+				}
+			}
+			if err == nil {
+				x, y := sg.Location(tt.end.X, tt.end.Y)
+				if trace.Costs[lattice.Cell{X: x, Y: y}] != path.Cost {
+					t.Error(fmt.Errorf("searchTrace.Costs want goal cost: %+v, got: %+v\n", path.Cost, trace.Costs[lattice.Cell{X: x, Y: y}]))
+				}
+			}
+		})
+	}
+}
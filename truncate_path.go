@@ -0,0 +1,64 @@
+package lattice
+
+import "github.com/maladroitthief/mosaic"
+
+// TruncatePath walks path from its first waypoint, which costs nothing, and
+// keeps every waypoint the budget pays for. Each later waypoint costs what a
+// search charges for the step into it: the cell's weight, plus the portal's
+// cost when the step is a portal jump. reached shares path's backing array and
+// remaining is what is left of budget.
+func (sg *SpatialGrid[T]) TruncatePath(path []mosaic.Vector, budget float64) (reached []mosaic.Vector, remaining float64) {
+	sg = sg.rlock()
+	defer sg.runlock()
+
+	if len(path) == 0 || budget < 0 {
+		return []mosaic.Vector{}, budget
+	}
+
+	fromX, fromY := sg.Location(path[0].X, path[0].Y)
+	remaining = budget
+	for i := 1; i < len(path); i++ {
+		toX, toY := sg.Location(path[i].X, path[i].Y)
+		cost := sg.stepCost(fromX, fromY, toX, toY)
+		if cost > remaining {
+			return path[:i:i], remaining
+		}
+
+		remaining -= cost
+		fromX, fromY = toX, toY
+	}
+
+	return path[:len(path):len(path)], remaining
+}
+
+// stepCost is the cost a search pays to move between the two cells, staying
+// inside one cell is free
+func (sg *SpatialGrid[T]) stepCost(fromX, fromY, toX, toY int) float64 {
+	if fromX == toX && fromY == toY {
+		return 0
+	}
+
+	cost := sg.Nodes[toX][toY].weight
+	if sg.neighborStep(toX-fromX, toY-fromY) {
+		return cost
+	}
+
+	to := sg.index(toX, toY)
+	for _, p := range sg.portals[sg.index(fromX, fromY)] {
+		if p.to == to {
+			return cost + p.cost
+		}
+	}
+
+	return cost
+}
+
+func (sg *SpatialGrid[T]) neighborStep(dx, dy int) bool {
+	for _, direction := range sg.config.neighbors {
+		if direction[0] == dx && direction[1] == dy {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,80 @@
+package lattice_test
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/mosaic"
+)
+
+func Test_spatial_grid_TruncatePath(t *testing.T) {
+	tests := []struct {
+		name      string
+		budget    float64
+		portal    bool
+		want      int
+		remaining float64
+	}{
+		{
+			name:      "whole path",
+			budget:    100,
+			want:      5,
+			remaining: 100 - 10,
+		},
+		{
+			name:      "split at budget",
+			budget:    5,
+			want:      3,
+			remaining: 0,
+		},
+		{
+			name:      "budget between steps",
+			budget:    4,
+			want:      2,
+			remaining: 2,
+		},
+		{
+			name:      "nothing affordable",
+			budget:    1,
+			want:      1,
+			remaining: 1,
+		},
+		{
+			name:      "portal",
+			budget:    100,
+			portal:    true,
+			want:      2,
+			remaining: 100 - 7,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg := lattice.NewSpatialGrid[int](5, 1, 8)
+			for x := 0; x < 5; x++ {
+				sg.SetTerrain(x, 0, float64(x)+1)
+			}
+			sg.SetTerrain(4, 0, 1)
+			if tt.portal {
+				sg.AddPortal(0, 0, 4, 0, 6)
+			}
+
+			path, err := sg.FindPath(mosaic.NewVector(4, 4), mosaic.NewVector(36, 4), lattice.PathOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			reached, remaining := sg.TruncatePath(path.Waypoints, tt.budget)
+			if !slices.Equal(path.Waypoints[:tt.want], reached) {
+				t.Error(fmt.Errorf("spatialGrid.TruncatePath() want: %+v, got: %+v\n", path.Waypoints[:tt.want], reached))
+			}
+			if remaining != tt.remaining {
+				t.Error(fmt.Errorf("spatialGrid.TruncatePath() want: %+v, got: %+v\n", tt.remaining, remaining))
+			}
+			if len(reached) == len(path.Waypoints) && tt.budget-remaining != path.Cost {
+				t.Error(fmt.Errorf("spatialGrid.TruncatePath() want: %+v, got: %+v\n", path.Cost, tt.budget-remaining))
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package lattice
+
+import (
+	"slices"
+	"time"
+
+	"github.com/maladroitthief/mosaic"
+)
+
+type (
+	expiry[T comparable] struct {
+		value T
+		at    time.Time
+	}
+)
+
+// InsertWithTTL inserts item as a dynamic item that Expire removes once ttl
+// has passed since now. now is on the caller's clock, the same one later
+// handed to Expire, so game and simulation time work as well as wall time.
+// Update keeps the deadline, Delete and the drops discard it.
+func (sg *SpatialGrid[T]) InsertWithTTL(item Item[T], now time.Time, ttl time.Duration) error {
+	sg.lock()
+	defer sg.unlock()
+
+	err := sg.insert(item)
+	if err != nil {
+		return err
+	}
+	sg.track(item.Value, item.Bounds, true)
+
+	if sg.expiries == nil {
+		sg.expiries = map[T]time.Time{}
+	}
+	sg.expiries[item.Value] = now.Add(ttl)
+
+	return nil
+}
+
+// Expire removes every item whose deadline is not after now and returns
+// them, soonest deadline first
+func (sg *SpatialGrid[T]) Expire(now time.Time) []T {
+	sg.lock()
+	defer sg.unlock()
+
+	due := []expiry[T]{}
+	for value, at := range sg.expiries {
+		if at.After(now) {
+			continue
+		}
+		due = append(due, expiry[T]{value, at})
+		delete(sg.expiries, value)
+	}
+	if len(due) == 0 {
+		return []T{}
+	}
+
+	expired := make(map[T]bool, len(due))
+	for _, e := range due {
+		expired[e.value] = false
+	}
+	for x := range sg.Nodes {
+		for y := range sg.Nodes[x] {
+			node, changed := sg.Nodes[x][y], false
+			// removal swaps another item into i, so only advance past keepers
+			for i := 0; i < len(node.Items); {
+				value, bounds := node.Items[i].value, node.Items[i].bounds
+				_, ok := expired[value]
+				if !ok {
+					i++
+					continue
+				}
+				var removed int
+				node, removed = node.Delete(value)
+				for j := 0; j < removed; j++ {
+					node = sg.record(node, CellDeleted, value, bounds)
+				}
+				sg.itemCount -= removed
+				expired[value], changed = true, true
+			}
+			if changed {
+				sg.Nodes[x][y] = node
+				sg.updateBlocked(x, y)
+			}
+		}
+	}
+	for value, found := range expired {
+		if !found && sg.unspill(value) {
+			expired[value] = true
+		}
+	}
+
+	slices.SortStableFunc(due, func(a, b expiry[T]) int {
+		return a.at.Compare(b.at)
+	})
+	values := make([]T, 0, len(due))
+	for _, e := range due {
+		// items evicted or otherwise gone before their deadline
+		if !expired[e.value] {
+			continue
+		}
+		values = append(values, e.value)
+		sg.track(e.value, mosaic.Rectangle{}, false)
+	}
+
+	return values
+}
@@ -0,0 +1,99 @@
+package lattice_test
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/mosaic"
+)
+
+func Test_spatial_grid_Expire(t *testing.T) {
+	// a simulation clock, nowhere near wall time
+	epoch := time.Unix(0, 0)
+	square := func(x, y float64) mosaic.Rectangle {
+		return mosaic.NewRectangle(mosaic.NewVector(x, y), 16, 16)
+	}
+	type params struct {
+		ttl   []time.Duration
+		write func(sg *lattice.SpatialGrid[int])
+		after time.Duration
+	}
+	type want struct {
+		expired []int
+		size    int
+	}
+	tests := []struct {
+		name   string
+		params params
+		want   want
+	}{
+		{
+			name:   "nothing due",
+			params: params{ttl: []time.Duration{time.Hour, time.Hour}},
+			want:   want{expired: []int{}, size: 3},
+		},
+		{
+			name:   "soonest first",
+			params: params{ttl: []time.Duration{2 * time.Second, time.Second}, after: time.Minute},
+			want:   want{expired: []int{1, 0}, size: 1},
+		},
+		{
+			name:   "partial",
+			params: params{ttl: []time.Duration{time.Second, time.Hour}, after: time.Minute},
+			want:   want{expired: []int{0}, size: 2},
+		},
+		{
+			name: "update keeps the deadline",
+			params: params{
+				ttl: []time.Duration{time.Second, time.Hour},
+				write: func(sg *lattice.SpatialGrid[int]) {
+					sg.Update(lattice.Item[int]{0, square(100, 100), 1}, square(16, 16))
+				},
+				after: time.Minute,
+			},
+			want: want{expired: []int{0}, size: 2},
+		},
+		{
+			name: "delete discards the deadline",
+			params: params{
+				ttl: []time.Duration{time.Second, time.Second},
+				write: func(sg *lattice.SpatialGrid[int]) {
+					sg.Delete(0, square(16, 16))
+					sg.Insert(lattice.Item[int]{0, square(16, 16), 1})
+				},
+				after: time.Minute,
+			},
+			want: want{expired: []int{1}, size: 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg := lattice.NewSpatialGrid[int](8, 8, 16)
+			sg.Insert(lattice.Item[int]{99, square(16, 16), 1})
+			weight := sg.GetLocationWeight(1, 1)
+			for i, ttl := range tt.params.ttl {
+				err := sg.InsertWithTTL(lattice.Item[int]{i, square(16, 16), 1}, epoch, ttl)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+			if tt.params.write != nil {
+				tt.params.write(sg)
+			}
+
+			got := sg.Expire(epoch.Add(tt.params.after))
+			if !slices.Equal(tt.want.expired, got) {
+				t.Error(fmt.Errorf("spatialGrid.Expire() want: %+v, got: %+v\n", tt.want.expired, got))
+			}
+			if sg.Size() != tt.want.size {
+				t.Error(fmt.Errorf("spatialGrid.Size() want: %+v, got: %+v\n", tt.want.size, sg.Size()))
+			}
+			if tt.params.write == nil && sg.GetLocationWeight(1, 1) != weight*float64(tt.want.size) {
+				t.Error(fmt.Errorf("spatialGrid.GetLocationWeight() want: %+v, got: %+v\n", weight*float64(tt.want.size), sg.GetLocationWeight(1, 1)))
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package lattice
+
+import "github.com/maladroitthief/mosaic"
+
+type BoundsUpdate[T comparable] struct {
+	Value      T
+	OldBounds  mosaic.Rectangle
+	NewBounds  mosaic.Rectangle
+	Multiplier float64
+}
+
+// UpdateBatch applies every update under a single lock. Items that stay in
+// the same cell are rewritten in place rather than deleted and reinserted.
+// Updates rejected by strict bounds are skipped and reported as
+// ErrOutOfBounds once the rest have been applied.
+func (sg *SpatialGrid[T]) UpdateBatch(updates []BoundsUpdate[T]) error {
+	sg.lock()
+	defer sg.unlock()
+
+	var err error
+	for _, update := range updates {
+		// grow first, or a move off the edge clamps into the border cell and
+		// looks like a move within it
+		sg.growToFit(update.NewBounds.Position)
+		oldX, oldY, oldErr := sg.cell(update.OldBounds.Position.X, update.OldBounds.Position.Y)
+		newX, newY, newErr := sg.cell(update.NewBounds.Position.X, update.NewBounds.Position.Y)
+		if oldErr != nil || newErr != nil {
+			err = ErrOutOfBounds
+			continue
+		}
+
+		if oldX == newX && oldY == newY {
+			node, ok := sg.Nodes[newX][newY].replace(update.Value, update.NewBounds, update.Multiplier, sg.config.weigh)
+			if ok {
+				// replays see the rewrite as the delete and insert it stands for
+				node = sg.record(node, CellDeleted, update.Value, update.OldBounds)
+				sg.Nodes[newX][newY] = sg.record(node, CellInserted, update.Value, update.NewBounds)
+				sg.updateBlocked(newX, newY)
+				sg.track(update.Value, update.NewBounds, true)
+				continue
+			}
+		}
+
+		sg.delete(update.Value, update.OldBounds)
+		insertErr := sg.insert(Item[T]{Value: update.Value, Bounds: update.NewBounds, Multiplier: update.Multiplier})
+		if insertErr != nil {
+			err = insertErr
+		}
+		sg.track(update.Value, update.NewBounds, insertErr == nil)
+	}
+
+	return err
+}
+
+func (sgn spatialGridNode[T]) replace(val T, bounds mosaic.Rectangle, multiplier float64, weigh WeightFunc) (spatialGridNode[T], bool) {
+	for i := 0; i < len(sgn.Items); i++ {
+		if sgn.Items[i].value != val {
+			continue
+		}
+
+		weight := weigh(sgn.bounds, bounds, multiplier)
+		sgn.Items[i] = newSpatialGridNodeItem(val, bounds, weight, multiplier)
+		sgn.packed = sgn.packed.set(i, bounds)
+		sgn.weight = sgn.itemWeights()
+
+		return sgn, true
+	}
+
+	return sgn, false
+}
@@ -0,0 +1,119 @@
+package lattice_test
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/mosaic"
+)
+
+func Test_spatial_grid_UpdateBatch(t *testing.T) {
+	box := func(x, y float64) mosaic.Rectangle {
+		return mosaic.NewRectangle(mosaic.Vector{X: x, Y: y}, 2, 2)
+	}
+	tests := []struct {
+		name    string
+		updates []lattice.BoundsUpdate[int]
+	}{
+		{
+			name: "within a cell",
+			updates: []lattice.BoundsUpdate[int]{
+				{Value: 1, OldBounds: box(4, 4), NewBounds: box(5, 5), Multiplier: 1},
+				{Value: 2, OldBounds: box(12, 4), NewBounds: box(11, 3), Multiplier: 2},
+			},
+		},
+		{
+			name: "across cells",
+			updates: []lattice.BoundsUpdate[int]{
+				{Value: 1, OldBounds: box(4, 4), NewBounds: box(28, 28), Multiplier: 1},
+				{Value: 2, OldBounds: box(12, 4), NewBounds: box(4, 4), Multiplier: 1},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			batched := lattice.NewSpatialGrid[int](4, 4, 8)
+			single := lattice.NewSpatialGrid[int](4, 4, 8)
+			for _, sg := range []*lattice.SpatialGrid[int]{batched, single} {
+				sg.Insert(lattice.Item[int]{1, box(4, 4), 1.0})
+				sg.Insert(lattice.Item[int]{2, box(12, 4), 1.0})
+				sg.Insert(lattice.Item[int]{3, box(6, 6), 1.0})
+			}
+
+			batched.UpdateBatch(tt.updates)
+			for _, update := range tt.updates {
+				single.Update(lattice.Item[int]{update.Value, update.NewBounds, update.Multiplier}, update.OldBounds)
+			}
+
+			if !batched.Equal(single) {
+				t.Error(fmt.Errorf("spatialGrid.UpdateBatch() want: %+v, got: %+v\n", single.Nodes, batched.Nodes))
+			}
+			for x := 0; x < 4; x++ {
+				for y := 0; y < 4; y++ {
+					if batched.GetLocationWeight(x, y) != single.GetLocationWeight(x, y) {
+						t.Error(fmt.Errorf("spatialGrid.UpdateBatch() want weight: %+v, got: %+v\n", single.GetLocationWeight(x, y), batched.GetLocationWeight(x, y)))
+					}
+				}
+			}
+
+			query := tt.updates[0].NewBounds
+			got := batched.FindIntersecting(query)
+			if !slices.Contains(got, tt.updates[0].Value) {
+				t.Error(fmt.Errorf("spatialGrid.FindIntersecting() want: %+v in %+v\n", tt.updates[0].Value, got))
+			}
+		})
+	}
+}
+
+func BenchmarkSpatialGridUpdateBatch(b *testing.B) {
+	sg := lattice.NewSpatialGrid[int](GridX, GridY, GridSize)
+	updates := make([]lattice.BoundsUpdate[int], 4096)
+	for i := range updates {
+		x := rand.Float64() * GridX * GridSize
+		y := rand.Float64() * GridY * GridSize
+		bounds := mosaic.NewRectangle(mosaic.Vector{X: x, Y: y}, 4, 4)
+		sg.Insert(lattice.Item[int]{i, bounds, 1.0})
+		updates[i] = lattice.BoundsUpdate[int]{Value: i, OldBounds: bounds, NewBounds: bounds, Multiplier: 1}
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for j := range updates {
+			updates[j].OldBounds = updates[j].NewBounds
+			updates[j].NewBounds.Position.X = updates[j].OldBounds.Position.X + rand.Float64() - 0.5
+		}
+		sg.UpdateBatch(updates)
+	}
+}
+
+func Test_spatial_grid_UpdateBatch_auto_grow(t *testing.T) {
+	sg, err := lattice.NewSpatialGridOpts[int](4, 4, 10, lattice.WithAutoGrow())
+	if err != nil {
+		t.Fatal(err)
+	}
+	old := mosaic.NewRectangle(mosaic.NewVector(35, 35), 2, 2)
+	moved := mosaic.NewRectangle(mosaic.NewVector(95, 95), 2, 2)
+	sg.Insert(lattice.Item[int]{1, old, 1})
+
+	err = sg.UpdateBatch([]lattice.BoundsUpdate[int]{{Value: 1, OldBounds: old, NewBounds: moved, Multiplier: 1}})
+	if err != nil {
+		t.Fatal(fmt.Errorf("spatialGrid.UpdateBatch() want error: %+v, got error: %+v\n", nil, err))
+	}
+
+	if sg.SizeX <= 9 || sg.SizeY <= 9 {
+		t.Error(fmt.Errorf("spatialGrid.UpdateBatch() want: grid grown past 9x9, got: %+vx%+v\n", sg.SizeX, sg.SizeY))
+	}
+	if got := sg.GetItemsAtLocation(3, 3); len(got) != 0 {
+		t.Error(fmt.Errorf("spatialGrid.GetItemsAtLocation(3, 3) want: %+v, got: %+v\n", []int{}, got))
+	}
+	x, y := sg.Location(95, 95)
+	if got := sg.GetItemsAtLocation(x, y); !slices.Equal(got, []int{1}) {
+		t.Error(fmt.Errorf("spatialGrid.GetItemsAtLocation(%d, %d) want: %+v, got: %+v\n", x, y, []int{1}, got))
+	}
+	if got := sg.Size(); got != 1 {
+		t.Error(fmt.Errorf("spatialGrid.Size() want: %+v, got: %+v\n", 1, got))
+	}
+}
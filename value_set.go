@@ -0,0 +1,50 @@
+package lattice
+
+import "golang.org/x/exp/maps"
+
+type valueSet[T comparable] struct {
+	seen    map[T]struct{}
+	order   []T
+	ordered bool
+}
+
+func (sg *SpatialGrid[T]) newValueSet() valueSet[T] {
+	return valueSet[T]{
+		seen:    map[T]struct{}{},
+		ordered: sg.config.deterministic,
+	}
+}
+
+func (vs *valueSet[T]) add(value T) {
+	if !vs.ordered {
+		vs.seen[value] = struct{}{}
+		return
+	}
+
+	_, ok := vs.seen[value]
+	if ok {
+		return
+	}
+	vs.seen[value] = struct{}{}
+	vs.order = append(vs.order, value)
+}
+
+func (vs *valueSet[T]) values() []T {
+	if !vs.ordered {
+		return maps.Keys(vs.seen)
+	}
+	if vs.order == nil {
+		return []T{}
+	}
+
+	return vs.order
+}
+
+func (vs *valueSet[T]) len() int {
+	return len(vs.seen)
+}
+
+func (vs *valueSet[T]) has(value T) bool {
+	_, ok := vs.seen[value]
+	return ok
+}
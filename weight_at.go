@@ -0,0 +1,43 @@
+package lattice
+
+import "math"
+
+// WeightAt samples the weight field at a world position, interpolating
+// bilinearly between the centers of the four nearest cells. Positions past
+// the outermost centers take the edge value. Any infinite weight that
+// contributes to the sample makes it infinite.
+func (sg *SpatialGrid[T]) WeightAt(x, y float64) float64 {
+	sg = sg.rlock()
+	defer sg.runlock()
+
+	return sg.weightAt(x, y)
+}
+
+func (sg *SpatialGrid[T]) weightAt(x, y float64) float64 {
+	x0, x1, tx := sg.lerpCells((x-sg.origin.X)/sg.ChunkSize, sg.SizeX)
+	y0, y1, ty := sg.lerpCells((y-sg.origin.Y)/sg.ChunkSize, sg.SizeY)
+
+	weight := 0.0
+	add := func(cx, cy int, factor float64) {
+		// skipping unused cells keeps an infinite neighbor from turning into NaN
+		if factor > 0 {
+			weight += factor * sg.Nodes[cx][cy].weight
+		}
+	}
+	add(x0, y0, (1-tx)*(1-ty))
+	add(x1, y0, tx*(1-ty))
+	add(x0, y1, (1-tx)*ty)
+	add(x1, y1, tx*ty)
+
+	return weight
+}
+
+// lerpCells finds the two cells whose centers bracket position, given in
+// cells from the origin, and how far it sits from the first toward the second
+func (sg *SpatialGrid[T]) lerpCells(position float64, size int) (int, int, float64) {
+	position = min(max(position-0.5, 0), float64(size-1))
+	first := int(math.Floor(position))
+	second := min(first+1, size-1)
+
+	return first, second, position - float64(first)
+}
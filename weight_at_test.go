@@ -0,0 +1,43 @@
+package lattice_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+)
+
+func Test_spatial_grid_WeightAt(t *testing.T) {
+	tests := []struct {
+		name string
+		x    float64
+		y    float64
+		want float64
+	}{
+		{name: "cell center", x: 4, y: 4, want: 0},
+		{name: "between centers", x: 8, y: 4, want: 4},
+		{name: "four cells", x: 8, y: 8, want: 5.5},
+		{name: "quarter way", x: 6, y: 4, want: 2},
+		{name: "past the edge", x: -10, y: 12, want: 2},
+		{name: "far corner", x: 100, y: 100, want: math.Inf(1)},
+		{name: "next to a wall", x: 12, y: 18, want: math.Inf(1)},
+		{name: "wall out of reach", x: 12, y: 12, want: 12},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg := lattice.NewSpatialGrid[int](3, 3, 8)
+			weights := [][]float64{{0, 2, 4}, {8, 12, math.Inf(1)}, {1, 1, math.Inf(1)}}
+			for x := range weights {
+				for y, w := range weights[x] {
+					sg.SetTerrain(x, y, w)
+				}
+			}
+
+			got := sg.WeightAt(tt.x, tt.y)
+			if got != tt.want {
+				t.Error(fmt.Errorf("spatialGrid.WeightAt() want: %+v, got: %+v\n", tt.want, got))
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package lattice
+
+import "github.com/maladroitthief/mosaic"
+
+type (
+	// WeightFunc decides how much an item adds to the weight of the cell it
+	// is stored in. Items only live in the cell holding their center, so cell
+	// is always that cell's bounds.
+	WeightFunc func(cell, item mosaic.Rectangle, multiplier float64) float64
+)
+
+// OverlapWeight is the default WeightFunc: the area the item covers of the
+// cell, scaled by its multiplier
+func OverlapWeight(cell, item mosaic.Rectangle, multiplier float64) float64 {
+	return cell.AreaOfOverlap(item) * multiplier
+}
+
+// WithWeightFunc replaces OverlapWeight for every insert, update and
+// SetMultiplier, e.g. to charge a flat multiplier per item regardless of
+// size. MaxWeight and NormalizedWeight still assume overlap weighting.
+func WithWeightFunc(weigh WeightFunc) Option {
+	return func(c *config) error {
+		if weigh == nil {
+			return ErrInvalidOption
+		}
+
+		c.weigh = weigh
+		return nil
+	}
+}
+
+// SetWeightFunc swaps the weight function and reweighs every stored item
+// with it. A nil function restores OverlapWeight.
+func (sg *SpatialGrid[T]) SetWeightFunc(weigh WeightFunc) {
+	sg.lock()
+	defer sg.unlock()
+
+	if weigh == nil {
+		weigh = OverlapWeight
+	}
+	sg.config.weigh = weigh
+	sg.reweigh()
+}
+
+// Reweigh recomputes every item's weight from its stored bounds and multiplier,
+// for weight functions that read settings which have since changed
+func (sg *SpatialGrid[T]) Reweigh() {
+	sg.lock()
+	defer sg.unlock()
+
+	sg.reweigh()
+}
+
+func (sg *SpatialGrid[T]) reweigh() {
+	for x := range sg.Nodes {
+		for y := range sg.Nodes[x] {
+			node := sg.Nodes[x][y]
+			if len(node.Items) == 0 {
+				continue
+			}
+			for i := range node.Items {
+				item := &node.Items[i]
+				item.weight = sg.config.weigh(node.bounds, item.bounds, item.multiplier)
+			}
+			node.weight = node.itemWeights()
+			sg.Nodes[x][y] = node
+			sg.updateBlocked(x, y)
+		}
+	}
+}
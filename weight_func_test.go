@@ -0,0 +1,147 @@
+package lattice_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/mosaic"
+)
+
+func Test_spatial_grid_WithWeightFunc(t *testing.T) {
+	flat := func(_, _ mosaic.Rectangle, multiplier float64) float64 {
+		return multiplier
+	}
+	falloff := func(cell, item mosaic.Rectangle, multiplier float64) float64 {
+		return multiplier * (8 - cell.Position.Subtract(item.Position).Magnitude())
+	}
+	small := mosaic.NewRectangle(mosaic.NewVector(4, 4), 1, 1)
+	offset := mosaic.NewRectangle(mosaic.NewVector(4, 7), 4, 4)
+	tests := []struct {
+		name   string
+		weigh  lattice.WeightFunc
+		mutate func(sg *lattice.SpatialGrid[int])
+		want   float64
+		err    error
+	}{
+		{
+			name:  "default overlap",
+			weigh: lattice.OverlapWeight,
+			mutate: func(sg *lattice.SpatialGrid[int]) {
+				sg.Insert(lattice.Item[int]{1, small, 2})
+			},
+			want: 2,
+		},
+		{
+			name:  "flat per item",
+			weigh: flat,
+			mutate: func(sg *lattice.SpatialGrid[int]) {
+				sg.Insert(lattice.Item[int]{1, small, 3})
+				sg.InsertStatic(lattice.Item[int]{2, small, 4})
+			},
+			want: 7,
+		},
+		{
+			name:  "set multiplier",
+			weigh: flat,
+			mutate: func(sg *lattice.SpatialGrid[int]) {
+				sg.Insert(lattice.Item[int]{1, small, 3})
+				sg.SetMultiplier(1, 5)
+			},
+			want: 5,
+		},
+		{
+			name:  "distance falloff",
+			weigh: falloff,
+			mutate: func(sg *lattice.SpatialGrid[int]) {
+				sg.Insert(lattice.Item[int]{1, small, 1})
+				sg.UpdateBatch([]lattice.BoundsUpdate[int]{{Value: 1, OldBounds: small, NewBounds: offset, Multiplier: 2}})
+			},
+			want: 2 * (8 - 3),
+		},
+		{
+			name: "nil",
+			err:  lattice.ErrInvalidOption,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg, err := lattice.NewSpatialGridOpts[int](2, 2, 8, lattice.WithWeightFunc(tt.weigh))
+			if !errors.Is(err, tt.err) {
+				t.Fatal(fmt.Errorf("lattice.WithWeightFunc() want: %+v, got: %+v\n", tt.err, err))
+			}
+			if err != nil {
+				return
+			}
+			tt.mutate(sg)
+
+			if got := sg.GetLocationWeight(0, 0); got != tt.want {
+				t.Error(fmt.Errorf("spatialGrid.GetLocationWeight() want: %+v, got: %+v\n", tt.want, got))
+			}
+		})
+	}
+}
+
+func Test_spatial_grid_Reweigh(t *testing.T) {
+	scale := 1.0
+	scaled := func(cell, item mosaic.Rectangle, multiplier float64) float64 {
+		return scale * lattice.OverlapWeight(cell, item, multiplier)
+	}
+	flat := func(_, _ mosaic.Rectangle, multiplier float64) float64 {
+		return multiplier
+	}
+	tests := []struct {
+		name   string
+		change func(sg *lattice.SpatialGrid[int])
+		want   []float64
+	}{
+		{
+			name: "settings changed",
+			change: func(sg *lattice.SpatialGrid[int]) {
+				scale = 3
+				sg.Reweigh()
+			},
+			want: []float64{3 * (4 + 2), 3 * 32},
+		},
+		{
+			name: "stale until reweighed",
+			change: func(sg *lattice.SpatialGrid[int]) {
+				scale = 3
+			},
+			want: []float64{4 + 2, 32},
+		},
+		{
+			name: "new function",
+			change: func(sg *lattice.SpatialGrid[int]) {
+				sg.SetWeightFunc(flat)
+			},
+			want: []float64{1 + 2, 2},
+		},
+		{
+			name: "back to overlap",
+			change: func(sg *lattice.SpatialGrid[int]) {
+				sg.SetWeightFunc(nil)
+			},
+			want: []float64{4 + 2, 32},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scale = 1
+			sg, err := lattice.NewSpatialGridOpts[int](2, 2, 8, lattice.WithWeightFunc(scaled))
+			if err != nil {
+				t.Fatal(err)
+			}
+			sg.Insert(lattice.Item[int]{1, mosaic.NewRectangle(mosaic.NewVector(4, 4), 2, 2), 1})
+			sg.InsertStatic(lattice.Item[int]{2, mosaic.NewRectangle(mosaic.NewVector(4, 4), 1, 1), 2})
+			sg.Insert(lattice.Item[int]{3, mosaic.NewRectangle(mosaic.NewVector(12, 12), 4, 4), 2})
+
+			tt.change(sg)
+			got := []float64{sg.GetLocationWeight(0, 0), sg.GetLocationWeight(1, 1)}
+			if got[0] != tt.want[0] || got[1] != tt.want[1] {
+				t.Error(fmt.Errorf("spatialGrid.Reweigh() want: %+v, got: %+v\n", tt.want, got))
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package lattice
+
+import (
+	"math"
+
+	"github.com/maladroitthief/mosaic"
+)
+
+// WeightGradient is the slope of the WeightAt field at a world position, in
+// weight per world unit, pointing toward heavier ground. Agents fleeing
+// crowds move against it. Infinite samples are left out of the estimate, so
+// next to a wall only the open side counts, and an axis with no finite
+// samples on either side has no slope.
+func (sg *SpatialGrid[T]) WeightGradient(x, y float64) mosaic.Vector {
+	sg = sg.rlock()
+	defer sg.runlock()
+
+	h := sg.ChunkSize / 2
+	center := sg.weightAt(x, y)
+
+	return mosaic.NewVector(
+		slope(sg.weightAt(x-h, y), center, sg.weightAt(x+h, y), h),
+		slope(sg.weightAt(x, y-h), center, sg.weightAt(x, y+h), h),
+	)
+}
+
+// slope differences the samples h either side of center, falling back to a
+// one sided difference when a sample is infinite
+func slope(before, center, after, h float64) float64 {
+	finite := func(w float64) bool { return !math.IsInf(w, 0) }
+	switch {
+	case finite(before) && finite(after):
+		return (after - before) / (2 * h)
+	case !finite(center):
+		return 0
+	case finite(after):
+		return (after - center) / h
+	case finite(before):
+		return (center - before) / h
+	default:
+		return 0
+	}
+}
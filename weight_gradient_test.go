@@ -0,0 +1,71 @@
+package lattice_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/mosaic"
+)
+
+func Test_spatial_grid_WeightGradient(t *testing.T) {
+	tests := []struct {
+		name    string
+		weights [][]float64
+		x       float64
+		y       float64
+		want    mosaic.Vector
+	}{
+		{
+			name:    "flat",
+			weights: [][]float64{{3, 3, 3}, {3, 3, 3}, {3, 3, 3}},
+			x:       12,
+			y:       12,
+			want:    mosaic.NewVector(0, 0),
+		},
+		{
+			name:    "ramp along x",
+			weights: [][]float64{{0, 0, 0}, {8, 8, 8}, {16, 16, 16}},
+			x:       12,
+			y:       12,
+			want:    mosaic.NewVector(1, 0),
+		},
+		{
+			name:    "ramp along y",
+			weights: [][]float64{{0, 4, 8}, {0, 4, 8}, {0, 4, 8}},
+			x:       12,
+			y:       12,
+			want:    mosaic.NewVector(0, 0.5),
+		},
+		{
+			name:    "wall on one side",
+			weights: [][]float64{{0, 0, 0}, {8, 8, 8}, {math.Inf(1), math.Inf(1), math.Inf(1)}},
+			x:       12,
+			y:       12,
+			want:    mosaic.NewVector(1, 0),
+		},
+		{
+			name:    "inside a wall",
+			weights: [][]float64{{math.Inf(1), math.Inf(1), math.Inf(1)}, {math.Inf(1), math.Inf(1), math.Inf(1)}, {math.Inf(1), math.Inf(1), math.Inf(1)}},
+			x:       12,
+			y:       12,
+			want:    mosaic.NewVector(0, 0),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg := lattice.NewSpatialGrid[int](3, 3, 8)
+			for x := range tt.weights {
+				for y, w := range tt.weights[x] {
+					sg.SetTerrain(x, y, w)
+				}
+			}
+
+			got := sg.WeightGradient(tt.x, tt.y)
+			if got != tt.want {
+				t.Error(fmt.Errorf("spatialGrid.WeightGradient() want: %+v, got: %+v\n", tt.want, got))
+			}
+		})
+	}
+}
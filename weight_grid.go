@@ -0,0 +1,202 @@
+package lattice
+
+import (
+	"sync"
+
+	"golang.org/x/exp/constraints"
+)
+
+type (
+	Weight interface {
+		constraints.Integer | constraints.Float
+	}
+
+	Cell struct {
+		X int
+		Y int
+	}
+
+	// WeightGrid is a tile-addressed grid with exact, caller-chosen weight
+	// arithmetic. Entering a cell costs the grid's base cost plus the costs of
+	// every item in it.
+	WeightGrid[T comparable, W Weight] struct {
+		SizeX   int
+		SizeY   int
+		base    W
+		cellsMu sync.RWMutex
+		cells   []weightGridCell[T, W]
+	}
+
+	weightGridCell[T comparable, W Weight] struct {
+		weight  W
+		blocked int
+		items   []weightGridItem[T, W]
+	}
+
+	weightGridItem[T comparable, W Weight] struct {
+		value    T
+		cost     W
+		blocking bool
+	}
+)
+
+func NewWeightGrid[T comparable, W Weight](x, y int, base W) *WeightGrid[T, W] {
+	return &WeightGrid[T, W]{
+		SizeX: x,
+		SizeY: y,
+		base:  base,
+		cells: make([]weightGridCell[T, W], x*y),
+	}
+}
+
+func (wg *WeightGrid[T, W]) Insert(x, y int, value T, cost W) {
+	wg.cellsMu.Lock()
+	defer wg.cellsMu.Unlock()
+
+	wg.insert(x, y, weightGridItem[T, W]{value: value, cost: cost})
+}
+
+func (wg *WeightGrid[T, W]) InsertBlocking(x, y int, value T) {
+	wg.cellsMu.Lock()
+	defer wg.cellsMu.Unlock()
+
+	wg.insert(x, y, weightGridItem[T, W]{value: value, blocking: true})
+}
+
+func (wg *WeightGrid[T, W]) insert(x, y int, item weightGridItem[T, W]) {
+	cell := &wg.cells[y*wg.SizeX+x]
+	cell.items = append(cell.items, item)
+	cell.weight += item.cost
+	if item.blocking {
+		cell.blocked++
+	}
+}
+
+func (wg *WeightGrid[T, W]) Delete(x, y int, value T) bool {
+	wg.cellsMu.Lock()
+	defer wg.cellsMu.Unlock()
+
+	cell := &wg.cells[y*wg.SizeX+x]
+	for i := 0; i < len(cell.items); i++ {
+		if cell.items[i].value != value {
+			continue
+		}
+
+		cell.weight -= cell.items[i].cost
+		if cell.items[i].blocking {
+			cell.blocked--
+		}
+		cell.items[i] = cell.items[len(cell.items)-1]
+		cell.items = cell.items[:len(cell.items)-1]
+		return true
+	}
+
+	return false
+}
+
+func (wg *WeightGrid[T, W]) Weight(x, y int) W {
+	wg.cellsMu.RLock()
+	defer wg.cellsMu.RUnlock()
+
+	return wg.base + wg.cells[y*wg.SizeX+x].weight
+}
+
+func (wg *WeightGrid[T, W]) Blocked(x, y int) bool {
+	wg.cellsMu.RLock()
+	defer wg.cellsMu.RUnlock()
+
+	return wg.cells[y*wg.SizeX+x].blocked > 0
+}
+
+func (wg *WeightGrid[T, W]) Items(x, y int) []T {
+	wg.cellsMu.RLock()
+	defer wg.cellsMu.RUnlock()
+
+	cell := wg.cells[y*wg.SizeX+x]
+	values := make([]T, len(cell.items))
+	for i := range cell.items {
+		values[i] = cell.items[i].value
+	}
+
+	return values
+}
+
+// FindPath returns the cheapest route and its exact cost. The step count is
+// only used as a heuristic when the base cost is positive.
+func (wg *WeightGrid[T, W]) FindPath(start, end Cell) ([]Cell, W, error) {
+	wg.cellsMu.RLock()
+	defer wg.cellsMu.RUnlock()
+
+	cells := len(wg.cells)
+	startIndex := int32(start.Y*wg.SizeX + start.X)
+	endIndex := int32(end.Y*wg.SizeX + end.X)
+
+	costs := make([]W, cells)
+	cameFrom := make([]int32, cells)
+	seen := make([]bool, cells)
+	closed := make([]bool, cells)
+
+	heuristic := func(x, y int) float64 {
+		if wg.base <= 0 {
+			return 0
+		}
+		return float64(abs(x-end.X)+abs(y-end.Y)) * float64(wg.base)
+	}
+
+	seen[startIndex] = true
+	cameFrom[startIndex] = startIndex
+	heap := minHeap{}.Push(startIndex, 0)
+
+	for heap.Len() > 0 {
+		var current int32
+		current, heap = heap.Pop()
+		if closed[current] {
+			continue
+		}
+		closed[current] = true
+		if current == endIndex {
+			break
+		}
+
+		currentX, currentY := int(current)%wg.SizeX, int(current)/wg.SizeX
+		for _, direction := range directions {
+			nextX := currentX + direction[0]
+			nextY := currentY + direction[1]
+			if nextX < 0 || nextX >= wg.SizeX || nextY < 0 || nextY >= wg.SizeY {
+				continue
+			}
+
+			next := int32(nextY*wg.SizeX + nextX)
+			if closed[next] || wg.cells[next].blocked > 0 {
+				continue
+			}
+
+			newCost := costs[current] + wg.base + wg.cells[next].weight
+			if seen[next] && newCost >= costs[next] {
+				continue
+			}
+
+			seen[next] = true
+			costs[next] = newCost
+			cameFrom[next] = current
+			heap = heap.Push(next, float64(newCost)+heuristic(nextX, nextY))
+		}
+	}
+
+	if !closed[endIndex] {
+		return []Cell{}, 0, ErrPathNotFound
+	}
+
+	reversed := []Cell{}
+	for current := endIndex; current != startIndex; current = cameFrom[current] {
+		reversed = append(reversed, Cell{X: int(current) % wg.SizeX, Y: int(current) / wg.SizeX})
+	}
+	reversed = append(reversed, start)
+
+	path := make([]Cell, len(reversed))
+	for i := range reversed {
+		path[i] = reversed[len(reversed)-1-i]
+	}
+
+	return path, costs[endIndex], nil
+}
@@ -0,0 +1,84 @@
+package lattice_test
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+)
+
+func Test_weight_grid_FindPath(t *testing.T) {
+	type params struct {
+		x        int
+		y        int
+		cost     int
+		blocking bool
+	}
+	type want struct {
+		path []lattice.Cell
+		cost int
+		err  error
+	}
+	tests := []struct {
+		name   string
+		params []params
+		start  lattice.Cell
+		end    lattice.Cell
+		want   want
+	}{
+		{
+			name:   "open ground",
+			params: []params{},
+			start:  lattice.Cell{X: 0, Y: 0},
+			end:    lattice.Cell{X: 2, Y: 0},
+			want: want{
+				path: []lattice.Cell{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 2, Y: 0}},
+				cost: 2,
+			},
+		},
+		{
+			name: "avoids swamp",
+			params: []params{
+				{x: 1, y: 0, cost: 5},
+			},
+			start: lattice.Cell{X: 0, Y: 0},
+			end:   lattice.Cell{X: 2, Y: 0},
+			want: want{
+				path: []lattice.Cell{{X: 0, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 1}, {X: 2, Y: 1}, {X: 2, Y: 0}},
+				cost: 4,
+			},
+		},
+		{
+			name: "walled off",
+			params: []params{
+				{x: 1, y: 0, blocking: true},
+				{x: 1, y: 1, blocking: true},
+				{x: 1, y: 2, blocking: true},
+			},
+			start: lattice.Cell{X: 0, Y: 0},
+			end:   lattice.Cell{X: 2, Y: 0},
+			want:  want{path: []lattice.Cell{}, err: lattice.ErrPathNotFound},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wg := lattice.NewWeightGrid[string, int](3, 3, 1)
+			for i, param := range tt.params {
+				if param.blocking {
+					wg.InsertBlocking(param.x, param.y, fmt.Sprint(i))
+					continue
+				}
+				wg.Insert(param.x, param.y, fmt.Sprint(i), param.cost)
+			}
+
+			got, cost, err := wg.FindPath(tt.start, tt.end)
+			if err != tt.want.err {
+				t.Error(fmt.Errorf("weightGrid.FindPath() want error: %+v, got error: %+v\n", tt.want.err, err))
+			}
+			if !slices.Equal(tt.want.path, got) || cost != tt.want.cost {
+				t.Error(fmt.Errorf("weightGrid.FindPath() want: %+v %d, got: %+v %d\n", tt.want.path, tt.want.cost, got, cost))
+			}
+		})
+	}
+}
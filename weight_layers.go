@@ -0,0 +1,45 @@
+package lattice
+
+import "math"
+
+type (
+	// WeightLayer is an extra cost map, indexed like Nodes, that a search adds
+	// on top of the grid's own weights after scaling it by Coefficient.
+	// Negative and NaN weights count as 0.
+	WeightLayer struct {
+		Weights     [][]float64
+		Coefficient float64
+	}
+)
+
+// validLayers reports whether every layer covers the grid with a finite,
+// non-negative coefficient
+func (sg *SpatialGrid[T]) validLayers(layers []WeightLayer) bool {
+	for _, layer := range layers {
+		if layer.Coefficient < 0 || math.IsInf(layer.Coefficient, 0) || math.IsNaN(layer.Coefficient) {
+			return false
+		}
+		if len(layer.Weights) != sg.SizeX {
+			return false
+		}
+		for x := range layer.Weights {
+			if len(layer.Weights[x]) != sg.SizeY {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func layerCost(layers []WeightLayer, x, y int) float64 {
+	cost := 0.0
+	for _, layer := range layers {
+		// the comparison also drops NaN
+		if w := layer.Weights[x][y]; w > 0 && layer.Coefficient != 0 {
+			cost += layer.Coefficient * w
+		}
+	}
+
+	return cost
+}
@@ -0,0 +1,101 @@
+package lattice_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/maladroitthief/lattice"
+	"github.com/maladroitthief/mosaic"
+)
+
+func Test_spatial_grid_FindPath_layers(t *testing.T) {
+	room := Builder{
+		x:    5,
+		y:    5,
+		size: 16,
+		layout: "" +
+			"00000" +
+			"00000" +
+			"00000" +
+			"00000" +
+			"00000",
+	}
+	// danger down the middle column, except along the bottom row
+	danger := make([][]float64, room.x)
+	for x := range danger {
+		danger[x] = make([]float64, room.y)
+	}
+	for y := 0; y < room.y-1; y++ {
+		danger[2][y] = 1
+	}
+	start, end := mosaic.NewVector(8, 8), mosaic.NewVector(72, 8)
+
+	type want struct {
+		cost  float64
+		steps int
+		err   error
+	}
+	tests := []struct {
+		name   string
+		layers []lattice.WeightLayer
+		want   want
+	}{
+		{name: "no layers", want: want{cost: 0, steps: 5}},
+		{
+			name:   "cheap danger is crossed",
+			layers: []lattice.WeightLayer{{Weights: danger, Coefficient: 1}},
+			want:   want{cost: 1, steps: 5},
+		},
+		{
+			name: "coefficients add up",
+			layers: []lattice.WeightLayer{
+				{Weights: danger, Coefficient: 1},
+				{Weights: danger, Coefficient: 2},
+			},
+			want: want{cost: 3, steps: 5},
+		},
+		{
+			name:   "zero coefficient",
+			layers: []lattice.WeightLayer{{Weights: danger, Coefficient: 0}},
+			want:   want{cost: 0, steps: 5},
+		},
+		{
+			name:   "negative coefficient",
+			layers: []lattice.WeightLayer{{Weights: danger, Coefficient: -1}},
+			want:   want{err: lattice.ErrInvalidOption},
+		},
+		{
+			name:   "wrong size",
+			layers: []lattice.WeightLayer{{Weights: danger[:2], Coefficient: 1}},
+			want:   want{err: lattice.ErrInvalidOption},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sg := lattice.NewSpatialGrid[int](room.x, room.y, float64(room.size))
+			setup_grid(sg, room)
+
+			path, err := sg.FindPath(start, end, lattice.PathOptions{Layers: tt.layers})
+			if !errors.Is(err, tt.want.err) {
+				t.Fatal(fmt.Errorf("spatialGrid.FindPath() want: %+v, got: %+v\n", tt.want.err, err))
+			}
+			if err != nil {
+				return
+			}
+			if path.Cost != tt.want.cost || len(path.Waypoints) != tt.want.steps {
+				t.Error(fmt.Errorf("spatialGrid.FindPath() want: %+v, got: %+v\n", tt.want, path))
+			}
+		})
+	}
+
+	// once crossing costs more than the detour along the bottom, the search
+	// takes the detour, whose cells cost nothing
+	sg := lattice.NewSpatialGrid[int](room.x, room.y, float64(room.size))
+	path, err := sg.FindPath(start, end, lattice.PathOptions{
+		Layers: []lattice.WeightLayer{{Weights: danger, Coefficient: 100}},
+	})
+	if err != nil || path.Cost != 0 || len(path.Waypoints) != 13 {
+		t.Error(fmt.Errorf("spatialGrid.FindPath() want: %+v, got: %+v\n", 13, path))
+	}
+}